@@ -17,6 +17,11 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/cmd/create"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/cmd/deploy"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/cmd/renew"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/cmd/rotate"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/cmd/show"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/cmd/sign"
 )
 
 // NewCLADMCommand returns a cobra.Command to run the cl-adm command.
@@ -29,6 +34,11 @@ func NewCLADMCommand() *cobra.Command {
 	}
 
 	cmds.AddCommand(create.NewCmdCreate())
+	cmds.AddCommand(deploy.NewCmdDeploy())
+	cmds.AddCommand(renew.NewCmdRenew())
+	cmds.AddCommand(rotate.NewCmdRotate())
+	cmds.AddCommand(show.NewCmdShow())
+	cmds.AddCommand(sign.NewCmdSign())
 
 	return cmds
 }
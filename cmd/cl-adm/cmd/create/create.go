@@ -25,6 +25,8 @@ func NewCmdCreate() *cobra.Command {
 
 	cmds.AddCommand(NewCmdCreateFabric())
 	cmds.AddCommand(NewCmdCreatePeer())
+	cmds.AddCommand(NewCmdCreatePeerCSR())
+	cmds.AddCommand(NewCmdCreatePeerCard())
 
 	return cmds
 }
@@ -14,35 +14,52 @@
 package create
 
 import (
-	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/config"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/util"
 	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
 )
 
+// FabricOptions contains everything necessary to create and run a 'create fabric' subcommand.
+type FabricOptions struct {
+	// Validity is the validity period of the fabric certificate.
+	Validity time.Duration
+}
+
+// AddFlags adds flags to fs and binds them to options.
+func (o *FabricOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&o.Validity, "validity", bootstrap.DefaultValidity, "Validity period of the fabric certificate.")
+}
+
+// Run the 'create fabric' subcommand.
+func (o *FabricOptions) Run() error {
+	fabricCert, err := bootstrap.CreateFabricCertificate(o.Validity)
+	if err != nil {
+		return err
+	}
+
+	return util.SaveCertificate(fabricCert, config.FabricDirectory())
+}
+
 // NewCmdCreateFabric returns a cobra.Command to run the 'create fabric' subcommand.
 func NewCmdCreateFabric() *cobra.Command {
-	return &cobra.Command{
+	opts := &FabricOptions{}
+
+	cmd := &cobra.Command{
 		Use:   "fabric",
 		Short: "Create a fabric",
 		Long:  `Create a fabric`,
 
 		RunE: func(cmd *cobra.Command, args []string) error {
-			fabricCert, err := bootstrap.CreateFabricCertificate()
-			if err != nil {
-				return err
-			}
-
-			// save certificate to file
-			err = os.WriteFile(config.CertificateFileName, fabricCert.RawCert(), 0o600)
-			if err != nil {
-				return err
-			}
-
-			// save private key to file
-			return os.WriteFile(config.PrivateKeyFileName, fabricCert.RawKey(), 0o600)
+			return opts.Run()
 		},
 	}
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
 }
@@ -17,12 +17,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"golang.org/x/net/idna"
 
 	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/config"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/util"
 	"github.com/clusterlink-net/clusterlink/cmd/cl-controlplane/app"
 	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
 	"github.com/clusterlink-net/clusterlink/pkg/bootstrap/platform"
@@ -45,6 +47,11 @@ type PeerOptions struct {
 	// CRDMode indicates whether to run a k8s CRD-based controlplane.
 	// This flag will be removed once the CRD-based controlplane feature is complete and stable.
 	CRDMode bool
+	// Validity is the validity period of the created certificates.
+	Validity time.Duration
+	// Certificate is the path of a peer certificate signed by the fabric admin.
+	// If set, the fabric private key is not required.
+	Certificate string
 }
 
 // AddFlags adds flags to fs and binds them to options.
@@ -59,6 +66,10 @@ func (o *PeerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.ContainerRegistry, "container-registry", "ghcr.io/clusterlink-net",
 		"The container registry to pull the project images. If empty will use local registry.")
 	fs.BoolVar(&o.CRDMode, "crd-mode", false, "Run a CRD-based controlplane.")
+	fs.DurationVar(&o.Validity, "validity", bootstrap.DefaultValidity, "Validity period of the created certificates.")
+	fs.StringVar(&o.Certificate, "certificate", "",
+		"Path of a peer certificate signed by the fabric admin (see 'cl-adm create peer-csr'). "+
+			"If set, the fabric private key is not required.")
 }
 
 // RequiredFlags are the names of flags that must be explicitly specified.
@@ -66,19 +77,8 @@ func (o *PeerOptions) RequiredFlags() []string {
 	return []string{"name"}
 }
 
-func (o *PeerOptions) saveCertificate(cert *bootstrap.Certificate, outDirectory string) error {
-	// save certificate to file
-	err := os.WriteFile(filepath.Join(outDirectory, config.CertificateFileName), cert.RawCert(), 0o600)
-	if err != nil {
-		return err
-	}
-
-	// save private key to file
-	return os.WriteFile(filepath.Join(outDirectory, config.PrivateKeyFileName), cert.RawKey(), 0o600)
-}
-
 func (o *PeerOptions) createControlplane(peerCert *bootstrap.Certificate) (*bootstrap.Certificate, error) {
-	cert, err := bootstrap.CreateControlplaneCertificate(o.Name, peerCert)
+	cert, err := bootstrap.CreateControlplaneCertificate(o.Name, peerCert, o.Validity)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +88,7 @@ func (o *PeerOptions) createControlplane(peerCert *bootstrap.Certificate) (*boot
 		return nil, err
 	}
 
-	if err := o.saveCertificate(cert, outDirectory); err != nil {
+	if err := util.SaveCertificate(cert, outDirectory); err != nil {
 		return nil, err
 	}
 
@@ -96,7 +96,7 @@ func (o *PeerOptions) createControlplane(peerCert *bootstrap.Certificate) (*boot
 }
 
 func (o *PeerOptions) createDataplane(peerCert *bootstrap.Certificate) (*bootstrap.Certificate, error) {
-	cert, err := bootstrap.CreateDataplaneCertificate(o.Name, peerCert)
+	cert, err := bootstrap.CreateDataplaneCertificate(o.Name, peerCert, o.Validity)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +106,7 @@ func (o *PeerOptions) createDataplane(peerCert *bootstrap.Certificate) (*bootstr
 		return nil, err
 	}
 
-	if err := o.saveCertificate(cert, outDirectory); err != nil {
+	if err := util.SaveCertificate(cert, outDirectory); err != nil {
 		return nil, err
 	}
 
@@ -114,7 +114,7 @@ func (o *PeerOptions) createDataplane(peerCert *bootstrap.Certificate) (*bootstr
 }
 
 func (o *PeerOptions) createGWCTL(peerCert *bootstrap.Certificate) (*bootstrap.Certificate, error) {
-	cert, err := bootstrap.CreateGWCTLCertificate(peerCert)
+	cert, err := bootstrap.CreateGWCTLCertificate(peerCert, o.Validity)
 	if err != nil {
 		return nil, err
 	}
@@ -124,59 +124,114 @@ func (o *PeerOptions) createGWCTL(peerCert *bootstrap.Certificate) (*bootstrap.C
 		return nil, err
 	}
 
-	if err := o.saveCertificate(cert, outDirectory); err != nil {
+	if err := util.SaveCertificate(cert, outDirectory); err != nil {
 		return nil, err
 	}
 
 	return cert, nil
 }
 
-// Run the 'create peer' subcommand.
-func (o *PeerOptions) Run() error {
-	if _, err := idna.Lookup.ToASCII(o.Name); err != nil {
-		return fmt.Errorf("peer name is not a valid DNS name: %w", err)
+func (o *PeerOptions) createPeerCertificate(fabricCert *bootstrap.Certificate) (*bootstrap.Certificate, error) {
+	if err := os.Mkdir(config.PeerDirectory(o.Name), 0o755); err != nil {
+		return nil, err
 	}
 
-	if err := verifyNotExists(o.Name); err != nil {
-		return err
+	cert, err := bootstrap.CreatePeerCertificate(o.Name, fabricCert, o.Validity)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := verifyDataplaneType(o.DataplaneType); err != nil {
-		return err
+	if err := util.SaveCertificate(cert, config.PeerDirectory(o.Name)); err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+// loadSignedPeerCertificate loads a peer certificate signed by the fabric admin,
+// along with the private key created by 'cl-adm create peer-csr'.
+func (o *PeerOptions) loadSignedPeerCertificate(
+	fabricCert *bootstrap.Certificate, fabricTrustBundle []byte,
+) (*bootstrap.Certificate, error) {
+	if err := verifyNotExists(config.ControlplaneDirectory(o.Name)); err != nil {
+		return nil, fmt.Errorf("peer already created: %w", err)
 	}
 
-	// read fabric certificate
-	rawFabricCert, err := os.ReadFile(config.CertificateFileName)
+	rawCert, err := os.ReadFile(o.Certificate)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// read fabric key
-	rawFabricKey, err := os.ReadFile(config.PrivateKeyFileName)
+	rawKey, err := os.ReadFile(filepath.Join(config.PeerDirectory(o.Name), config.PrivateKeyFileName))
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("cannot read peer private key (created by 'cl-adm create peer-csr'): %w", err)
 	}
 
-	fabricCert, err := bootstrap.CertificateFromRaw(rawFabricCert, rawFabricKey)
+	cert, err := bootstrap.CertificateFromRaw(rawCert, rawKey)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	peerDirectory := config.PeerDirectory(o.Name)
-	if err := os.Mkdir(peerDirectory, 0o755); err != nil {
-		return err
+	trustedCerts := fabricTrustBundle
+	if len(trustedCerts) == 0 {
+		trustedCerts = fabricCert.RawCert()
 	}
 
-	peerCertificate, err := bootstrap.CreatePeerCertificate(o.Name, fabricCert)
-	if err != nil {
+	if err := bootstrap.VerifyPeerCertificate(cert, o.Name, trustedCerts); err != nil {
+		return nil, err
+	}
+
+	if err := util.SaveCertificate(cert, config.PeerDirectory(o.Name)); err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+// Run the 'create peer' subcommand.
+func (o *PeerOptions) Run() error {
+	if _, err := idna.Lookup.ToASCII(o.Name); err != nil {
+		return fmt.Errorf("peer name is not a valid DNS name: %w", err)
+	}
+
+	if err := verifyDataplaneType(o.DataplaneType); err != nil {
 		return err
 	}
 
-	err = o.saveCertificate(peerCertificate, config.PeerDirectory(o.Name))
+	fabricTrustBundle, err := util.LoadFabricTrustBundle()
 	if err != nil {
 		return err
 	}
 
+	var fabricCert, peerCertificate *bootstrap.Certificate
+	if o.Certificate != "" {
+		fabricCert, err = util.LoadCertificateFile(filepath.Join(config.FabricDirectory(), config.CertificateFileName))
+		if err != nil {
+			return err
+		}
+
+		peerCertificate, err = o.loadSignedPeerCertificate(fabricCert, fabricTrustBundle)
+		if err != nil {
+			return err
+		}
+	} else {
+		if err := verifyNotExists(o.Name); err != nil {
+			return err
+		}
+
+		fabricCert, err = util.LoadCertificate(config.FabricDirectory())
+		if err != nil {
+			return err
+		}
+
+		peerCertificate, err = o.createPeerCertificate(fabricCert)
+		if err != nil {
+			return err
+		}
+	}
+
+	peerDirectory := config.PeerDirectory(o.Name)
+
 	controlplaneCert, err := o.createControlplane(peerCertificate)
 	if err != nil {
 		return err
@@ -196,6 +251,7 @@ func (o *PeerOptions) Run() error {
 	platformCfg := &platform.Config{
 		Peer:                    o.Name,
 		FabricCertificate:       fabricCert,
+		FabricTrustBundle:       fabricTrustBundle,
 		PeerCertificate:         peerCertificate,
 		ControlplaneCertificate: controlplaneCert,
 		DataplaneCertificate:    dataplaneCert,
@@ -218,13 +274,7 @@ func (o *PeerOptions) Run() error {
 	}
 
 	// Create k8s secrets YAML file that contains the components certificates.
-	certConfig, err := platform.K8SCertificateConfig(platformCfg)
-	if err != nil {
-		return err
-	}
-
-	certOutPath := filepath.Join(peerDirectory, config.K8SSecretYAMLFile)
-	if err := os.WriteFile(certOutPath, certConfig, 0o600); err != nil {
+	if err := util.SaveCertificateConfig(platformCfg); err != nil {
 		return err
 	}
 
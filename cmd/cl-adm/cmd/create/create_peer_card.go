@@ -0,0 +1,184 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package create
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/config"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/util"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-controlplane/app"
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	clusterlink "github.com/clusterlink-net/clusterlink/pkg/apis/clusterlink.net/v1alpha1"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
+	"github.com/clusterlink-net/clusterlink/pkg/operator/controller"
+)
+
+// PeerCardOptions contains everything necessary to create and run a 'create peer-card' subcommand.
+type PeerCardOptions struct {
+	// Name of the peer.
+	Name string
+	// Gateways are the external endpoints (host:port) of the peer gateway.
+	// If empty, the endpoint is taken from the ingress status of the deployed peer.
+	Gateways []string
+	// Attributes of the peer.
+	Attributes map[string]string
+	// Namespace where the ClusterLink components are deployed.
+	Namespace string
+	// Operator indicates whether the peer was deployed using the ClusterLink operator.
+	Operator bool
+	// Kubeconfig is the path of the kubeconfig file. If empty, the default kubeconfig is used.
+	Kubeconfig string
+	// KubeContext is the kubeconfig context to use. If empty, the current context is used.
+	KubeContext string
+}
+
+// AddFlags adds flags to fs and binds them to options.
+func (o *PeerCardOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Name, "name", "", "Peer name.")
+	fs.StringSliceVar(&o.Gateways, "gateway", nil,
+		"External endpoint (host:port) of the peer gateway. If unset, uses the ingress endpoint of the deployed peer.")
+	fs.StringToStringVar(&o.Attributes, "attributes", nil, "Peer attributes (e.g. 'env=prod,region=eu').")
+	fs.StringVar(&o.Namespace, "namespace", app.SystemNamespace, "Namespace where the ClusterLink components are deployed.")
+	fs.BoolVar(&o.Operator, "operator", false, "Peer was deployed using the ClusterLink operator.")
+	fs.StringVar(&o.Kubeconfig, "kubeconfig", "", "Path of the kubeconfig file. If empty, uses the default kubeconfig.")
+	fs.StringVar(&o.KubeContext, "kube-context", "", "Kubeconfig context to use. If empty, uses the current context.")
+}
+
+// RequiredFlags are the names of flags that must be explicitly specified.
+func (o *PeerCardOptions) RequiredFlags() []string {
+	return []string{"name"}
+}
+
+// gateways returns the external endpoints of the peer gateway.
+func (o *PeerCardOptions) gateways() ([]api.Endpoint, error) {
+	if len(o.Gateways) > 0 {
+		gateways := make([]api.Endpoint, len(o.Gateways))
+		for i, gateway := range o.Gateways {
+			host, rawPort, err := net.SplitHostPort(gateway)
+			if err != nil {
+				return nil, fmt.Errorf("invalid gateway '%s': %w", gateway, err)
+			}
+
+			port, err := strconv.ParseUint(rawPort, 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid gateway port '%s': %w", rawPort, err)
+			}
+
+			gateways[i] = api.Endpoint{Host: host, Port: uint16(port)}
+		}
+		return gateways, nil
+	}
+
+	c, err := util.NewK8SClient(o.Kubeconfig, o.KubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := util.InstanceStatus(context.Background(), c, &clusterlink.Instance{
+		ObjectMeta: metav1.ObjectMeta{Name: util.InstanceName, Namespace: controller.OperatorNameSpace},
+		Spec: clusterlink.InstanceSpec{
+			Ingress:   clusterlink.IngressSpec{Type: clusterlink.IngressTypeLoadBalancer},
+			Namespace: o.Namespace,
+		},
+	}, o.Operator)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get status of the deployed peer: %w", err)
+	}
+
+	ingress := instance.Status.Ingress
+	if ingress.IP == "" || ingress.IP == "pending" || ingress.Port == 0 {
+		return nil, fmt.Errorf("ingress endpoint of the deployed peer is not available, set it using --gateway")
+	}
+
+	return []api.Endpoint{{Host: ingress.IP, Port: uint16(ingress.Port)}}, nil
+}
+
+// Run the 'create peer-card' subcommand.
+func (o *PeerCardOptions) Run() error {
+	peerCert, err := util.LoadCertificate(config.PeerDirectory(o.Name))
+	if err != nil {
+		return fmt.Errorf("cannot load peer certificate (created by 'cl-adm create peer'): %w", err)
+	}
+
+	gateways, err := o.gateways()
+	if err != nil {
+		return err
+	}
+
+	card, err := bootstrap.CreatePeerCard(&bootstrap.PeerCardContent{
+		Name:       o.Name,
+		Gateways:   gateways,
+		Attributes: o.Attributes,
+		IssuedAt:   time.Now().UTC(),
+	}, peerCert)
+	if err != nil {
+		return err
+	}
+
+	rawCard, err := yaml.Marshal(card)
+	if err != nil {
+		return fmt.Errorf("cannot encode peer card: %w", err)
+	}
+
+	outPath := filepath.Join(config.PeerDirectory(o.Name), config.PeerCardFile)
+	if err := os.WriteFile(outPath, rawCard, 0o600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created peer card %s (certificate fingerprint %s).\n",
+		outPath, bootstrap.CertificateFingerprint(peerCert.X509()))
+	fmt.Printf("Remote peers can add this peer using 'gwctl create peer --card %s --fabric-ca <fabric certificate>'.\n",
+		config.PeerCardFile)
+	return nil
+}
+
+// NewCmdCreatePeerCard returns a cobra.Command to run the 'create peer-card' subcommand.
+func NewCmdCreatePeerCard() *cobra.Command {
+	opts := &PeerCardOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "peer-card",
+		Short: "Create a signed peer card",
+		Long: `Create a peer card, signed by the peer certificate, holding the peer name, gateway endpoints,
+attributes and certificate fingerprint. Remote peers can verify the card against the fabric CA,
+and create the peer in one step.`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Run()
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	for _, flag := range opts.RequiredFlags() {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			fmt.Printf("Error marking required flag '%s': %v\n", flag, err)
+			os.Exit(1)
+		}
+	}
+
+	return cmd
+}
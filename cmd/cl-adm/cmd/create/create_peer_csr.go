@@ -0,0 +1,108 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package create
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/net/idna"
+
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/config"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
+)
+
+// PeerCSROptions contains everything necessary to create and run a 'create peer-csr' subcommand.
+type PeerCSROptions struct {
+	// Name of the peer.
+	Name string
+}
+
+// AddFlags adds flags to fs and binds them to options.
+func (o *PeerCSROptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Name, "name", "", "Peer name.")
+}
+
+// RequiredFlags are the names of flags that must be explicitly specified.
+func (o *PeerCSROptions) RequiredFlags() []string {
+	return []string{"name"}
+}
+
+// Run the 'create peer-csr' subcommand.
+func (o *PeerCSROptions) Run() error {
+	if _, err := idna.Lookup.ToASCII(o.Name); err != nil {
+		return fmt.Errorf("peer name is not a valid DNS name: %w", err)
+	}
+
+	if err := verifyNotExists(o.Name); err != nil {
+		return err
+	}
+
+	request, err := bootstrap.CreatePeerCertificateRequest(o.Name)
+	if err != nil {
+		return err
+	}
+
+	peerDirectory := config.PeerDirectory(o.Name)
+	if err := os.Mkdir(peerDirectory, 0o755); err != nil {
+		return err
+	}
+
+	csrPath := filepath.Join(peerDirectory, config.CertificateRequestFileName)
+	if err := os.WriteFile(csrPath, request.RawRequest(), 0o600); err != nil {
+		return err
+	}
+
+	keyPath := filepath.Join(peerDirectory, config.PrivateKeyFileName)
+	if err := os.WriteFile(keyPath, request.RawKey(), 0o600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created certificate signing request %s.\n", csrPath)
+	fmt.Printf("Send it to the fabric admin for signing ('cl-adm sign peer --name %s --csr %s'), "+
+		"then run 'cl-adm create peer --name %s --certificate <signed certificate>'.\n",
+		o.Name, csrPath, o.Name)
+	return nil
+}
+
+// NewCmdCreatePeerCSR returns a cobra.Command to run the 'create peer-csr' subcommand.
+func NewCmdCreatePeerCSR() *cobra.Command {
+	opts := &PeerCSROptions{}
+
+	cmd := &cobra.Command{
+		Use:   "peer-csr",
+		Short: "Create a peer private key and certificate signing request",
+		Long: `Create a peer private key and certificate signing request.
+The request is signed by the fabric admin, so that the fabric private key
+is not required for creating the peer.`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Run()
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	for _, flag := range opts.RequiredFlags() {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			fmt.Printf("Error marking required flag '%s': %v\n", flag, err)
+			os.Exit(1)
+		}
+	}
+
+	return cmd
+}
@@ -0,0 +1,385 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/config"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/util"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-controlplane/app"
+	clusterlink "github.com/clusterlink-net/clusterlink/pkg/apis/clusterlink.net/v1alpha1"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap/platform"
+	"github.com/clusterlink-net/clusterlink/pkg/operator/controller"
+)
+
+const (
+	// fieldManager is the field manager of objects applied by cl-adm.
+	fieldManager = "cl-adm"
+	// pollInterval is the time between consecutive checks of the deployment status.
+	pollInterval = 2 * time.Second
+)
+
+// PeerOptions contains everything necessary to create and run a 'deploy peer' subcommand.
+type PeerOptions struct {
+	// Name of the peer to deploy, as created by 'cl-adm create peer'.
+	Name string
+	// Namespace where the ClusterLink components are deployed.
+	Namespace string
+	// Dataplanes is the number of dataplanes to deploy.
+	Dataplanes uint16
+	// DataplaneType is the type of dataplane to deploy (envoy or go-based)
+	DataplaneType string
+	// LogLevel is the log level.
+	LogLevel string
+	// ContainerRegistry is the container registry to pull the project images.
+	ContainerRegistry string
+	// CRDMode indicates whether to run a k8s CRD-based controlplane.
+	CRDMode bool
+	// Ingress is the type of the external ingress service (none, LoadBalancer or NodePort).
+	Ingress string
+	// IngressPort is the port of the external ingress service. If 0, the default port is used.
+	IngressPort int32
+	// Operator indicates whether to deploy using a ClusterLink instance, reconciled by an installed operator.
+	Operator bool
+	// Kubeconfig is the path of the kubeconfig file. If empty, the default kubeconfig is used.
+	Kubeconfig string
+	// KubeContext is the kubeconfig context to use. If empty, the current context is used.
+	KubeContext string
+	// Timeout for the deployment to become ready.
+	Timeout time.Duration
+}
+
+// AddFlags adds flags to fs and binds them to options.
+func (o *PeerOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Name, "name", "", "Peer name.")
+	fs.StringVar(&o.Namespace, "namespace", app.SystemNamespace, "Namespace where the ClusterLink components are deployed.")
+	fs.Uint16Var(&o.Dataplanes, "dataplanes", 1, "Number of dataplanes.")
+	fs.StringVar(&o.DataplaneType, "dataplane-type", platform.DataplaneTypeEnvoy,
+		"Type of dataplane, Supported values: \"envoy\" (default), \"go\"")
+	fs.StringVar(&o.LogLevel, "log-level", "info",
+		"The log level. One of fatal, error, warn, info, debug.")
+	fs.StringVar(&o.ContainerRegistry, "container-registry", "ghcr.io/clusterlink-net",
+		"The container registry to pull the project images. If empty will use local registry.")
+	fs.BoolVar(&o.CRDMode, "crd-mode", false, "Run a CRD-based controlplane.")
+	fs.StringVar(&o.Ingress, "ingress", string(clusterlink.IngressTypeLoadBalancer),
+		"Type of the external ingress service. Supported values: \"LoadBalancer\" (default), \"NodePort\", \"none\"")
+	fs.Int32Var(&o.IngressPort, "ingress-port", 0,
+		"Port of the external ingress service. If unset, uses 443, or a k8s-allocated port for NodePort.")
+	fs.BoolVar(&o.Operator, "operator", false,
+		"Deploy a ClusterLink instance, to be reconciled by an already installed ClusterLink operator "+
+			"(the operator itself is not installed).")
+	fs.StringVar(&o.Kubeconfig, "kubeconfig", "", "Path of the kubeconfig file. If empty, uses the default kubeconfig.")
+	fs.StringVar(&o.KubeContext, "kube-context", "", "Kubeconfig context to use. If empty, uses the current context.")
+	fs.DurationVar(&o.Timeout, "timeout", 5*time.Minute, "Time to wait for the deployment to become ready.")
+}
+
+// RequiredFlags are the names of flags that must be explicitly specified.
+func (o *PeerOptions) RequiredFlags() []string {
+	return []string{"name"}
+}
+
+// platformConfig loads the certificates of the peer, as created by 'cl-adm create peer'.
+func (o *PeerOptions) platformConfig() (*platform.Config, error) {
+	if _, err := os.Stat(config.PeerDirectory(o.Name)); err != nil {
+		return nil, fmt.Errorf("cannot find peer '%s' (created by 'cl-adm create peer'): %w", o.Name, err)
+	}
+
+	fabricCert, err := util.LoadCertificateFile(filepath.Join(config.FabricDirectory(), config.CertificateFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	fabricTrustBundle, err := util.LoadFabricTrustBundle()
+	if err != nil {
+		return nil, err
+	}
+
+	peerCert, err := util.LoadCertificate(config.PeerDirectory(o.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	controlplaneCert, err := util.LoadCertificate(config.ControlplaneDirectory(o.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	dataplaneCert, err := util.LoadCertificate(config.DataplaneDirectory(o.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	gwctlCert, err := util.LoadCertificate(config.GWCTLDirectory(o.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	return &platform.Config{
+		Peer:                    o.Name,
+		FabricCertificate:       fabricCert,
+		FabricTrustBundle:       fabricTrustBundle,
+		PeerCertificate:         peerCert,
+		ControlplaneCertificate: controlplaneCert,
+		DataplaneCertificate:    dataplaneCert,
+		GWCTLCertificate:        gwctlCert,
+		Dataplanes:              o.Dataplanes,
+		DataplaneType:           o.DataplaneType,
+		LogLevel:                o.LogLevel,
+		ContainerRegistry:       o.ContainerRegistry,
+		IngressType:             o.Ingress,
+		IngressPort:             o.IngressPort,
+		CRDMode:                 o.CRDMode,
+		Namespace:               o.Namespace,
+	}, nil
+}
+
+// instance returns the ClusterLink instance describing the deployed peer.
+func (o *PeerOptions) instance() *clusterlink.Instance {
+	return &clusterlink.Instance{
+		ObjectMeta: metav1.ObjectMeta{Name: util.InstanceName, Namespace: controller.OperatorNameSpace},
+		Spec: clusterlink.InstanceSpec{
+			Ingress: clusterlink.IngressSpec{
+				Type: clusterlink.IngressType(o.Ingress),
+				Port: o.IngressPort,
+			},
+			Namespace: o.Namespace,
+		},
+	}
+}
+
+// manifests returns the k8s objects to apply, as a multi-document YAML.
+func (o *PeerOptions) manifests(platformCfg *platform.Config) ([]byte, error) {
+	manifests := []byte(fmt.Sprintf("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: %s\n---\n", o.Namespace))
+
+	if o.Operator {
+		certConfig, err := platform.K8SCertificateConfig(platformCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		instanceConfig, err := platform.K8SClusterLinkInstanceConfig(platformCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		manifests = append(manifests, certConfig...)
+		manifests = append(manifests, "\n---\n"...)
+		return append(manifests, instanceConfig...), nil
+	}
+
+	k8sConfig, err := platform.K8SConfig(platformCfg)
+	if err != nil {
+		return nil, err
+	}
+	manifests = append(manifests, k8sConfig...)
+
+	if service := controller.ExternalService(o.instance()); service != nil {
+		service.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Service"}
+		serviceConfig, err := yaml.Marshal(service)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode external ingress service: %w", err)
+		}
+
+		manifests = append(manifests, "\n---\n"...)
+		manifests = append(manifests, serviceConfig...)
+	}
+
+	return manifests, nil
+}
+
+// apply applies the objects of a multi-document YAML using server-side apply.
+func (o *PeerOptions) apply(ctx context.Context, c client.Client, manifests []byte) error {
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifests), len(manifests))
+	for {
+		object := &unstructured.Unstructured{}
+		if err := decoder.Decode(&object.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("cannot decode manifests: %w", err)
+		}
+
+		if len(object.Object) == 0 {
+			continue
+		}
+
+		fmt.Printf("Applying %s '%s'.\n", object.GetKind(), object.GetName())
+		err := c.Patch(ctx, object, client.Apply, client.FieldOwner(fieldManager), client.ForceOwnership)
+		if err != nil {
+			if o.Operator && object.GetKind() == "Instance" {
+				err = fmt.Errorf("%w (is the ClusterLink operator installed?)", err)
+			}
+			return fmt.Errorf("cannot apply %s '%s': %w", object.GetKind(), object.GetName(), err)
+		}
+	}
+}
+
+// ready returns true if the controlplane and dataplane are ready, and the ingress endpoint (if any) is known.
+func ready(instance *clusterlink.Instance) bool {
+	deploymentReady := func(conditions map[string]metav1.Condition) bool {
+		return conditions[string(clusterlink.DeploymentReady)].Status == metav1.ConditionTrue
+	}
+
+	if !deploymentReady(instance.Status.Controlplane.Conditions) || !deploymentReady(instance.Status.Dataplane.Conditions) {
+		return false
+	}
+
+	if instance.Spec.Ingress.Type == clusterlink.IngressTypeNone {
+		return true
+	}
+
+	ingress := instance.Status.Ingress
+	return ingress.IP != "" && ingress.IP != "pending" && ingress.Port != 0
+}
+
+// Run the 'deploy peer' subcommand.
+func (o *PeerOptions) Run() error {
+	if err := verifyIngressType(o.Ingress); err != nil {
+		return err
+	}
+
+	platformCfg, err := o.platformConfig()
+	if err != nil {
+		return err
+	}
+
+	manifests, err := o.manifests(platformCfg)
+	if err != nil {
+		return err
+	}
+
+	c, err := util.NewK8SClient(o.Kubeconfig, o.KubeContext)
+	if err != nil {
+		return err
+	}
+
+	if o.Operator {
+		fmt.Println("Note: the ClusterLink operator is not installed by this command, and must already run in the cluster.")
+	}
+
+	ctx := context.Background()
+	if err := o.apply(ctx, c, manifests); err != nil {
+		return err
+	}
+
+	fmt.Printf("Waiting for peer '%s' to become ready.\n", o.Name)
+
+	var instance *clusterlink.Instance
+	err = wait.PollUntilContextTimeout(ctx, pollInterval, o.Timeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		instance, err = util.InstanceStatus(ctx, c, o.instance(), o.Operator)
+		if err != nil {
+			// the operator may not have reconciled the instance yet
+			return false, client.IgnoreNotFound(err)
+		}
+		return ready(instance), nil
+	})
+	if err != nil {
+		if instance != nil {
+			printConditions(instance)
+		}
+		return fmt.Errorf("peer '%s' is not ready: %w", o.Name, err)
+	}
+
+	fmt.Printf("Peer '%s' is ready.\n", o.Name)
+
+	if instance.Spec.Ingress.Type == clusterlink.IngressTypeNone {
+		fmt.Println("The peer has no external ingress (see --ingress), and is not reachable from remote peers.")
+		return nil
+	}
+
+	ingress := instance.Status.Ingress
+	fmt.Printf("Ingress endpoint: %s:%d\n", ingress.IP, ingress.Port)
+	fmt.Printf("Remote peers can add this peer using: gwctl create peer --name %s --host %s --port %d\n",
+		o.Name, ingress.IP, ingress.Port)
+	return nil
+}
+
+// printConditions prints the status conditions of the components of a deployed peer.
+func printConditions(instance *clusterlink.Instance) {
+	components := []struct {
+		name       string
+		conditions map[string]metav1.Condition
+	}{
+		{"controlplane", instance.Status.Controlplane.Conditions},
+		{"dataplane", instance.Status.Dataplane.Conditions},
+		{"ingress", instance.Status.Ingress.Conditions},
+	}
+
+	for _, component := range components {
+		for _, condition := range component.conditions {
+			fmt.Printf("%s %s: %s (%s)\n", component.name, condition.Type, condition.Status, condition.Message)
+		}
+	}
+}
+
+// verifyIngressType checks if the given ingress type is valid.
+func verifyIngressType(ingressType string) error {
+	switch clusterlink.IngressType(ingressType) {
+	case clusterlink.IngressTypeNone, clusterlink.IngressTypeNodePort, clusterlink.IngressTypeLoadBalancer:
+		return nil
+	default:
+		return fmt.Errorf("undefined ingress type %s", ingressType)
+	}
+}
+
+// NewCmdDeployPeer returns a cobra.Command to run the 'deploy peer' subcommand.
+func NewCmdDeployPeer() *cobra.Command {
+	opts := &PeerOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "peer",
+		Short: "Deploy a peer to a k8s cluster",
+		Long: `Deploy a peer, created by 'cl-adm create peer', to a k8s cluster using server-side apply.
+Waits for the controlplane and dataplane to become ready, and prints the external ingress endpoint
+to be used by remote peers.
+
+With --operator, the peer is deployed as a ClusterLink instance, which requires the ClusterLink operator
+to be installed beforehand (this command does not install it). The operator can be installed from the
+repository manifests using:
+  kubectl apply -f config/operator/crds -f config/operator/manager -f config/operator/rbac`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Run()
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	for _, flag := range opts.RequiredFlags() {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			fmt.Printf("Error marking required flag '%s': %v\n", flag, err)
+			os.Exit(1)
+		}
+	}
+
+	return cmd
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	clusterlink "github.com/clusterlink-net/clusterlink/pkg/apis/clusterlink.net/v1alpha1"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap/platform"
+	"github.com/clusterlink-net/clusterlink/pkg/operator/controller"
+)
+
+// testCertificates returns a platform configuration holding freshly created certificates of a peer.
+func testCertificates(t *testing.T, peer string) *platform.Config {
+	fabricCert, err := bootstrap.CreateFabricCertificate(time.Hour)
+	require.Nil(t, err)
+	peerCert, err := bootstrap.CreatePeerCertificate(peer, fabricCert, time.Hour)
+	require.Nil(t, err)
+	controlplaneCert, err := bootstrap.CreateControlplaneCertificate(peer, peerCert, time.Hour)
+	require.Nil(t, err)
+	dataplaneCert, err := bootstrap.CreateDataplaneCertificate(peer, peerCert, time.Hour)
+	require.Nil(t, err)
+	gwctlCert, err := bootstrap.CreateGWCTLCertificate(peerCert, time.Hour)
+	require.Nil(t, err)
+
+	return &platform.Config{
+		Peer:                    peer,
+		FabricCertificate:       fabricCert,
+		PeerCertificate:         peerCert,
+		ControlplaneCertificate: controlplaneCert,
+		DataplaneCertificate:    dataplaneCert,
+		GWCTLCertificate:        gwctlCert,
+	}
+}
+
+// decodeManifests returns the objects of a multi-document YAML, by their kind and name.
+func decodeManifests(t *testing.T, manifests []byte) map[string]*unstructured.Unstructured {
+	objects := make(map[string]*unstructured.Unstructured)
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifests), len(manifests))
+	for {
+		object := &unstructured.Unstructured{}
+		err := decoder.Decode(&object.Object)
+		if errors.Is(err, io.EOF) {
+			return objects
+		}
+		require.Nil(t, err)
+
+		if len(object.Object) > 0 {
+			objects[object.GetKind()+"/"+object.GetName()] = object
+		}
+	}
+}
+
+func TestManifests(t *testing.T) {
+	certificates := testCertificates(t, "peer1")
+
+	// manifests returns the objects to apply for the given options
+	manifests := func(t *testing.T, operator bool, ingress clusterlink.IngressType) map[string]*unstructured.Unstructured {
+		o := &PeerOptions{
+			Name:          "peer1",
+			Namespace:     "clusterlink-test",
+			Dataplanes:    1,
+			DataplaneType: platform.DataplaneTypeEnvoy,
+			LogLevel:      "info",
+			Ingress:       string(ingress),
+			IngressPort:   30443,
+			Operator:      operator,
+		}
+
+		platformCfg := *certificates
+		platformCfg.Namespace = o.Namespace
+		platformCfg.Dataplanes = o.Dataplanes
+		platformCfg.DataplaneType = o.DataplaneType
+		platformCfg.LogLevel = o.LogLevel
+		platformCfg.IngressType = o.Ingress
+		platformCfg.IngressPort = o.IngressPort
+
+		manifests, err := o.manifests(&platformCfg)
+		require.Nil(t, err)
+		return decodeManifests(t, manifests)
+	}
+
+	t.Run("operator", func(t *testing.T) {
+		objects := manifests(t, true, clusterlink.IngressTypeNodePort)
+		require.Contains(t, objects, "Namespace/clusterlink-test")
+		require.NotContains(t, objects, "Deployment/cl-controlplane")
+		require.NotContains(t, objects, "Service/"+controller.IngressName)
+
+		// the operator creates the components from the instance
+		instance, ok := objects["Instance/cl-instance"]
+		require.True(t, ok)
+		require.Equal(t, controller.OperatorNameSpace, instance.GetNamespace())
+
+		ingressType, _, err := unstructured.NestedString(instance.Object, "spec", "ingress", "type")
+		require.Nil(t, err)
+		require.Equal(t, string(clusterlink.IngressTypeNodePort), ingressType)
+
+		// numbers are decoded as JSON numbers
+		ingressPort, _, err := unstructured.NestedFloat64(instance.Object, "spec", "ingress", "port")
+		require.Nil(t, err)
+		require.Equal(t, float64(30443), ingressPort)
+
+		namespace, _, err := unstructured.NestedString(instance.Object, "spec", "namespace")
+		require.Nil(t, err)
+		require.Equal(t, "clusterlink-test", namespace)
+	})
+
+	t.Run("manifests", func(t *testing.T) {
+		objects := manifests(t, false, clusterlink.IngressTypeLoadBalancer)
+		require.Contains(t, objects, "Namespace/clusterlink-test")
+		require.Contains(t, objects, "Deployment/cl-controlplane")
+		require.Contains(t, objects, "Deployment/cl-dataplane")
+		require.NotContains(t, objects, "Instance/cl-instance")
+
+		service, ok := objects["Service/"+controller.IngressName]
+		require.True(t, ok)
+		require.Equal(t, "clusterlink-test", service.GetNamespace())
+
+		serviceType, _, err := unstructured.NestedString(service.Object, "spec", "type")
+		require.Nil(t, err)
+		require.Equal(t, "LoadBalancer", serviceType)
+
+		ports, _, err := unstructured.NestedSlice(service.Object, "spec", "ports")
+		require.Nil(t, err)
+		require.Len(t, ports, 1)
+		require.Equal(t, float64(30443), ports[0].(map[string]any)["port"])
+	})
+
+	t.Run("no ingress", func(t *testing.T) {
+		objects := manifests(t, false, clusterlink.IngressTypeNone)
+		require.NotContains(t, objects, "Service/"+controller.IngressName)
+	})
+}
+
+func TestReady(t *testing.T) {
+	readyConditions := map[string]metav1.Condition{
+		string(clusterlink.DeploymentReady): {Type: string(clusterlink.DeploymentReady), Status: metav1.ConditionTrue},
+	}
+	notReadyConditions := map[string]metav1.Condition{
+		string(clusterlink.DeploymentReady): {Type: string(clusterlink.DeploymentReady), Status: metav1.ConditionFalse},
+	}
+
+	newInstance := func(
+		controlplane, dataplane map[string]metav1.Condition, ingressType clusterlink.IngressType, ip string, port int32,
+	) *clusterlink.Instance {
+		instance := &clusterlink.Instance{}
+		instance.Spec.Ingress.Type = ingressType
+		instance.Status.Controlplane.Conditions = controlplane
+		instance.Status.Dataplane.Conditions = dataplane
+		instance.Status.Ingress.IP = ip
+		instance.Status.Ingress.Port = port
+		return instance
+	}
+
+	tests := []struct {
+		name     string
+		instance *clusterlink.Instance
+		ready    bool
+	}{
+		{
+			name:     "no status",
+			instance: newInstance(nil, nil, clusterlink.IngressTypeNone, "", 0),
+		},
+		{
+			name:     "controlplane not ready",
+			instance: newInstance(notReadyConditions, readyConditions, clusterlink.IngressTypeNone, "", 0),
+		},
+		{
+			name:     "dataplane not ready",
+			instance: newInstance(readyConditions, notReadyConditions, clusterlink.IngressTypeNone, "", 0),
+		},
+		{
+			name:     "no ingress",
+			instance: newInstance(readyConditions, readyConditions, clusterlink.IngressTypeNone, "", 0),
+			ready:    true,
+		},
+		{
+			name:     "pending ingress",
+			instance: newInstance(readyConditions, readyConditions, clusterlink.IngressTypeLoadBalancer, "pending", 443),
+		},
+		{
+			name:     "ingress without port",
+			instance: newInstance(readyConditions, readyConditions, clusterlink.IngressTypeNodePort, "10.0.0.1", 0),
+		},
+		{
+			name:     "ingress",
+			instance: newInstance(readyConditions, readyConditions, clusterlink.IngressTypeNodePort, "10.0.0.1", 30443),
+			ready:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.ready, ready(tt.instance))
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renew
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/config"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/util"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-controlplane/app"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap/platform"
+)
+
+// PeerOptions contains everything necessary to create and run a 'renew peer' subcommand.
+type PeerOptions struct {
+	// Name of the peer to renew.
+	Name string
+	// Namespace where the ClusterLink components are deployed.
+	Namespace string
+	// CRDMode indicates whether the peer runs a k8s CRD-based controlplane.
+	CRDMode bool
+	// Validity is the validity period of the re-issued certificates.
+	Validity time.Duration
+	// PeerCA indicates whether to re-issue the peer CA certificate as well.
+	PeerCA bool
+}
+
+// AddFlags adds flags to fs and binds them to options.
+func (o *PeerOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Name, "name", "", "Peer name.")
+	fs.StringVar(&o.Namespace, "namespace", app.SystemNamespace, "Namespace where the ClusterLink components are deployed.")
+	fs.BoolVar(&o.CRDMode, "crd-mode", false, "Peer runs a CRD-based controlplane.")
+	fs.DurationVar(&o.Validity, "validity", bootstrap.DefaultValidity, "Validity period of the re-issued certificates.")
+	fs.BoolVar(&o.PeerCA, "peer-ca", false,
+		"Re-issue the peer CA certificate using the current fabric certificate (required after a fabric CA rotation).")
+}
+
+// RequiredFlags are the names of flags that must be explicitly specified.
+func (o *PeerOptions) RequiredFlags() []string {
+	return []string{"name"}
+}
+
+func (o *PeerOptions) renewPeerCA(fabricCert *bootstrap.Certificate) (*bootstrap.Certificate, error) {
+	if !o.PeerCA {
+		return util.LoadCertificate(config.PeerDirectory(o.Name))
+	}
+
+	cert, err := bootstrap.CreatePeerCertificate(o.Name, fabricCert, o.Validity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := util.SaveCertificate(cert, config.PeerDirectory(o.Name)); err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+func (o *PeerOptions) save(cert *bootstrap.Certificate, directory string) error {
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return err
+	}
+
+	if err := util.SaveCertificate(cert, directory); err != nil {
+		return err
+	}
+
+	fmt.Printf("Re-issued %s (expires %s).\n", directory, cert.X509().NotAfter.Format(time.RFC3339))
+	return nil
+}
+
+// Run the 'renew peer' subcommand.
+func (o *PeerOptions) Run() error {
+	if _, err := os.Stat(config.PeerDirectory(o.Name)); err != nil {
+		return fmt.Errorf("cannot find peer '%s': %w", o.Name, err)
+	}
+
+	// the fabric private key is only required for re-issuing the peer CA
+	var fabricCert *bootstrap.Certificate
+	var err error
+	if o.PeerCA {
+		fabricCert, err = util.LoadCertificate(config.FabricDirectory())
+	} else {
+		fabricCert, err = util.LoadCertificateFile(filepath.Join(config.FabricDirectory(), config.CertificateFileName))
+	}
+	if err != nil {
+		return err
+	}
+
+	fabricTrustBundle, err := util.LoadFabricTrustBundle()
+	if err != nil {
+		return err
+	}
+
+	peerCert, err := o.renewPeerCA(fabricCert)
+	if err != nil {
+		return err
+	}
+
+	controlplaneCert, err := bootstrap.CreateControlplaneCertificate(o.Name, peerCert, o.Validity)
+	if err != nil {
+		return err
+	}
+
+	if err := o.save(controlplaneCert, config.ControlplaneDirectory(o.Name)); err != nil {
+		return err
+	}
+
+	dataplaneCert, err := bootstrap.CreateDataplaneCertificate(o.Name, peerCert, o.Validity)
+	if err != nil {
+		return err
+	}
+
+	if err := o.save(dataplaneCert, config.DataplaneDirectory(o.Name)); err != nil {
+		return err
+	}
+
+	gwctlCert, err := bootstrap.CreateGWCTLCertificate(peerCert, o.Validity)
+	if err != nil {
+		return err
+	}
+
+	if err := o.save(gwctlCert, config.GWCTLDirectory(o.Name)); err != nil {
+		return err
+	}
+
+	// re-create k8s secrets YAML
+	err = util.SaveCertificateConfig(&platform.Config{
+		Peer:                    o.Name,
+		Namespace:               o.Namespace,
+		FabricCertificate:       fabricCert,
+		FabricTrustBundle:       fabricTrustBundle,
+		PeerCertificate:         peerCert,
+		ControlplaneCertificate: controlplaneCert,
+		DataplaneCertificate:    dataplaneCert,
+		GWCTLCertificate:        gwctlCert,
+		CRDMode:                 o.CRDMode,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Apply %s to deploy the renewed certificates.\n", config.K8SSecretYAMLFile)
+	return nil
+}
+
+// NewCmdRenewPeer returns a cobra.Command to run the 'renew peer' subcommand.
+func NewCmdRenewPeer() *cobra.Command {
+	opts := &PeerOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "peer",
+		Short: "Re-issue the certificates of a peer",
+		Long: `Re-issue the controlplane, dataplane and gwctl certificates of a peer,
+and re-create the peer k8s secrets YAML.`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Run()
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	for _, flag := range opts.RequiredFlags() {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			fmt.Printf("Error marking required flag '%s': %v\n", flag, err)
+			os.Exit(1)
+		}
+	}
+
+	return cmd
+}
@@ -0,0 +1,30 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotate
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmdRotate returns a cobra.Command to run the rotate command.
+func NewCmdRotate() *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   "rotate",
+		Short: "Rotate CA certificates",
+	}
+
+	cmds.AddCommand(NewCmdRotateFabric())
+
+	return cmds
+}
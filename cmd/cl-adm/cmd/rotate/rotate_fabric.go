@@ -0,0 +1,135 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/config"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/util"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
+)
+
+// FabricOptions contains everything necessary to create and run a 'rotate fabric' subcommand.
+type FabricOptions struct {
+	// Validity is the validity period of the new fabric certificate.
+	Validity time.Duration
+	// Complete indicates whether to complete an in-progress rotation.
+	Complete bool
+}
+
+// AddFlags adds flags to fs and binds them to options.
+func (o *FabricOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&o.Validity, "validity", bootstrap.DefaultValidity, "Validity period of the new fabric certificate.")
+	fs.BoolVar(&o.Complete, "complete", false,
+		"Complete an in-progress rotation, removing the previous fabric certificate from the trust bundle.")
+}
+
+func (o *FabricOptions) start() error {
+	bundlePath := filepath.Join(config.FabricDirectory(), config.TrustBundleFileName)
+	if _, err := os.Stat(bundlePath); err == nil {
+		return fmt.Errorf("fabric CA rotation already in progress, run with --complete to finish it")
+	}
+
+	previousCert, err := util.LoadCertificate(config.FabricDirectory())
+	if err != nil {
+		return err
+	}
+
+	fabricCert, err := bootstrap.CreateFabricCertificate(o.Validity)
+	if err != nil {
+		return err
+	}
+
+	previousPath := filepath.Join(config.FabricDirectory(), config.PreviousCertificateFileName)
+	if err := os.WriteFile(previousPath, previousCert.RawCert(), 0o600); err != nil {
+		return err
+	}
+
+	// peers trust both the previous and the new fabric certificates until the rotation completes
+	var bundle []byte
+	bundle = append(bundle, fabricCert.RawCert()...)
+	bundle = append(bundle, previousCert.RawCert()...)
+	if err := os.WriteFile(bundlePath, bundle, 0o600); err != nil {
+		return err
+	}
+
+	if err := util.SaveCertificate(fabricCert, config.FabricDirectory()); err != nil {
+		return err
+	}
+
+	fmt.Println("Created a new fabric certificate. To complete the rotation:")
+	fmt.Println("  1. Run 'cl-adm renew peer --name <peer>' for every peer, and apply its secrets YAML.")
+	fmt.Println("  2. Run 'cl-adm renew peer --name <peer> --peer-ca' for every peer, and apply its secrets YAML.")
+	fmt.Println("  3. Run 'cl-adm rotate fabric --complete'.")
+	fmt.Println("  4. Run 'cl-adm renew peer --name <peer>' for every peer, and apply its secrets YAML.")
+	return nil
+}
+
+func (o *FabricOptions) complete() error {
+	bundlePath := filepath.Join(config.FabricDirectory(), config.TrustBundleFileName)
+	if _, err := os.Stat(bundlePath); err != nil {
+		return fmt.Errorf("no fabric CA rotation in progress: %w", err)
+	}
+
+	if err := os.Remove(bundlePath); err != nil {
+		return err
+	}
+
+	previousPath := filepath.Join(config.FabricDirectory(), config.PreviousCertificateFileName)
+	if err := os.Remove(previousPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	fmt.Println("Fabric CA rotation completed. " +
+		"Run 'cl-adm renew peer --name <peer>' for every peer to stop trusting the previous fabric certificate.")
+	return nil
+}
+
+// Run the 'rotate fabric' subcommand.
+func (o *FabricOptions) Run() error {
+	if o.Complete {
+		return o.complete()
+	}
+
+	return o.start()
+}
+
+// NewCmdRotateFabric returns a cobra.Command to run the 'rotate fabric' subcommand.
+func NewCmdRotateFabric() *cobra.Command {
+	opts := &FabricOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "fabric",
+		Short: "Rotate the fabric CA certificate",
+		Long: `Rotate the fabric CA certificate.
+A rotation creates a new fabric certificate, and a trust bundle containing both the previous
+and the new fabric certificates. Peers renewed during the rotation trust both certificates,
+allowing them to be gradually moved to the new fabric certificate.`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Run()
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package show
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/config"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
+)
+
+// CertificatesOptions contains everything necessary to create and run a 'show certificates' subcommand.
+type CertificatesOptions struct {
+	// Name of the peer to show. If empty, all peers are shown.
+	Name string
+	// RenewalWindow is the period before expiry in which certificates are marked for renewal.
+	RenewalWindow time.Duration
+}
+
+// AddFlags adds flags to fs and binds them to options.
+func (o *CertificatesOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Name, "name", "", "Peer name. If empty, show the certificates of all peers.")
+	fs.DurationVar(&o.RenewalWindow, "renewal-window", 30*24*time.Hour,
+		"Period before expiry in which certificates are marked for renewal.")
+}
+
+// peers returns the names of the peers created in the fabric directory.
+func (o *CertificatesOptions) peers() ([]string, error) {
+	if o.Name != "" {
+		return []string{o.Name}, nil
+	}
+
+	entries, err := os.ReadDir(config.FabricDirectory())
+	if err != nil {
+		return nil, err
+	}
+
+	var peers []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		certPath := filepath.Join(config.ControlplaneDirectory(entry.Name()), config.CertificateFileName)
+		if _, err := os.Stat(certPath); err == nil {
+			peers = append(peers, entry.Name())
+		}
+	}
+
+	return peers, nil
+}
+
+// certificateFile is a certificate file of a fabric component.
+type certificateFile struct {
+	component string
+	path      string
+}
+
+// printCertificate prints the leaf certificate stored in a given file.
+func printCertificate(w *tabwriter.Writer, component, path string, renewalWindow time.Duration) error {
+	rawCert, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	certs, err := bootstrap.ParseCertificates(rawCert)
+	if err != nil {
+		return fmt.Errorf("cannot parse '%s': %w", path, err)
+	}
+
+	cert := certs[0]
+	names := cert.DNSNames
+	if cert.IsCA {
+		names = cert.PermittedDNSDomains
+	}
+
+	now := time.Now()
+	remaining := bootstrap.RemainingValidity(cert, now).Truncate(time.Hour)
+	expiresIn := fmt.Sprintf("%dd", int(remaining.Hours()/24))
+	switch {
+	case remaining <= 0:
+		expiresIn = "EXPIRED"
+	case bootstrap.NeedsRenewal(cert, now, renewalWindow):
+		expiresIn += " (renew)"
+	}
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+		component, cert.Subject.CommonName, strings.Join(names, ","),
+		cert.NotBefore.Format(time.RFC3339), cert.NotAfter.Format(time.RFC3339), expiresIn)
+	return nil
+}
+
+// Run the 'show certificates' subcommand.
+func (o *CertificatesOptions) Run() error {
+	peers, err := o.peers()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tSUBJECT\tDNS NAMES\tNOT BEFORE\tNOT AFTER\tEXPIRES IN")
+
+	certFiles := []certificateFile{
+		{"fabric", filepath.Join(config.FabricDirectory(), config.CertificateFileName)},
+		{"fabric/previous", filepath.Join(config.FabricDirectory(), config.PreviousCertificateFileName)},
+	}
+
+	for _, peer := range peers {
+		if _, err := os.Stat(config.PeerDirectory(peer)); err != nil {
+			return fmt.Errorf("cannot find peer '%s': %w", peer, err)
+		}
+
+		certFiles = append(certFiles,
+			certificateFile{peer, filepath.Join(config.PeerDirectory(peer), config.CertificateFileName)},
+			certificateFile{
+				peer + "/" + config.ControlplaneDirectoryName,
+				filepath.Join(config.ControlplaneDirectory(peer), config.CertificateFileName),
+			},
+			certificateFile{
+				peer + "/" + config.DataplaneDirectoryName,
+				filepath.Join(config.DataplaneDirectory(peer), config.CertificateFileName),
+			},
+			certificateFile{
+				peer + "/" + config.GWCTLDirectoryName,
+				filepath.Join(config.GWCTLDirectory(peer), config.CertificateFileName),
+			})
+	}
+
+	for _, certFile := range certFiles {
+		if err := printCertificate(w, certFile.component, certFile.path, o.RenewalWindow); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// NewCmdShowCertificates returns a cobra.Command to run the 'show certificates' subcommand.
+func NewCmdShowCertificates() *cobra.Command {
+	opts := &CertificatesOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "certificates",
+		Short: "Show the fabric and peer certificates",
+		Long:  `Show the fabric and peer certificates, including their expiry dates`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Run()
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
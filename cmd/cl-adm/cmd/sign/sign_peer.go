@@ -0,0 +1,122 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sign
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/net/idna"
+
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/config"
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/util"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
+)
+
+// PeerOptions contains everything necessary to create and run a 'sign peer' subcommand.
+type PeerOptions struct {
+	// Name of the peer. The certificate signing request must be for this name.
+	Name string
+	// CSR is the path of the peer certificate signing request.
+	CSR string
+	// Validity is the validity period of the signed certificate.
+	Validity time.Duration
+	// Output is the path of the signed certificate.
+	Output string
+}
+
+// AddFlags adds flags to fs and binds them to options.
+func (o *PeerOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Name, "name", "", "Peer name. The certificate signing request must be for this name.")
+	fs.StringVar(&o.CSR, "csr", "", "Path of the peer certificate signing request.")
+	fs.DurationVar(&o.Validity, "validity", bootstrap.DefaultValidity, "Validity period of the signed certificate.")
+	fs.StringVar(&o.Output, "output", "", "Path of the signed certificate. Defaults to <name>.pem.")
+}
+
+// RequiredFlags are the names of flags that must be explicitly specified.
+func (o *PeerOptions) RequiredFlags() []string {
+	return []string{"name", "csr"}
+}
+
+// Run the 'sign peer' subcommand.
+func (o *PeerOptions) Run() error {
+	if _, err := idna.Lookup.ToASCII(o.Name); err != nil {
+		return fmt.Errorf("peer name is not a valid DNS name: %w", err)
+	}
+
+	if o.Validity <= 0 {
+		return fmt.Errorf("validity must be positive")
+	}
+
+	output := o.Output
+	if output == "" {
+		output = o.Name + ".pem"
+	}
+
+	if _, err := os.Stat(output); err == nil {
+		return fmt.Errorf("output path %s exists", output)
+	}
+
+	fabricCert, err := util.LoadCertificate(config.FabricDirectory())
+	if err != nil {
+		return err
+	}
+
+	rawRequest, err := os.ReadFile(o.CSR)
+	if err != nil {
+		return err
+	}
+
+	cert, err := bootstrap.SignPeerCertificateRequest(rawRequest, o.Name, fabricCert, o.Validity)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(output, cert.RawCert(), 0o600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Signed peer certificate %s (expires %s).\n", output, cert.X509().NotAfter.Format(time.RFC3339))
+	return nil
+}
+
+// NewCmdSignPeer returns a cobra.Command to run the 'sign peer' subcommand.
+func NewCmdSignPeer() *cobra.Command {
+	opts := &PeerOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "peer",
+		Short: "Sign a peer certificate signing request",
+		Long: `Sign a peer certificate signing request using the fabric certificate.
+The resulting certificate is valid only for the given peer name.`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Run()
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	for _, flag := range opts.RequiredFlags() {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			fmt.Printf("Error marking required flag '%s': %v\n", flag, err)
+			os.Exit(1)
+		}
+	}
+
+	return cmd
+}
@@ -22,6 +22,12 @@ const (
 	PrivateKeyFileName = "key.pem"
 	// CertificateFileName is the filename used by certificate files.
 	CertificateFileName = "cert.pem"
+	// CertificateRequestFileName is the filename used by certificate signing request files.
+	CertificateRequestFileName = "cert.csr"
+	// PreviousCertificateFileName is the filename of the replaced fabric certificate during a fabric CA rotation.
+	PreviousCertificateFileName = "cert.previous.pem"
+	// TrustBundleFileName is the filename of the fabric trust bundle used during a fabric CA rotation.
+	TrustBundleFileName = "ca-bundle.pem"
 	// DockerRunFile is the filename of the docker-run script.
 	DockerRunFile = "docker-run.sh"
 	// GWCTLInitFile is the filename of the gwctl-init script.
@@ -32,6 +38,8 @@ const (
 	K8SSecretYAMLFile = "cl-secret.yaml" //nolint:gosec // G101(Potential hardcoded credentials): Enable secret usage in filenames.
 	// K8SClusterLinkInstanceYAMLFile is the filename of the ClusterLink instance CRD file that will use by the operator.
 	K8SClusterLinkInstanceYAMLFile = "cl-instance.yaml"
+	// PeerCardFile is the filename of the signed peer card, shared with remote peers.
+	PeerCardFile = "peer-card.yaml"
 	// PersistencyDirectoryName is the directory name containing container persisted files.
 	PersistencyDirectoryName = "persist"
 
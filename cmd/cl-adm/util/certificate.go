@@ -0,0 +1,94 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/clusterlink-net/clusterlink/cmd/cl-adm/config"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap/platform"
+)
+
+// SaveCertificate saves a certificate and its private key to a directory.
+func SaveCertificate(cert *bootstrap.Certificate, directory string) error {
+	// save certificate to file
+	err := os.WriteFile(filepath.Join(directory, config.CertificateFileName), cert.RawCert(), 0o600)
+	if err != nil {
+		return err
+	}
+
+	// save private key to file
+	return os.WriteFile(filepath.Join(directory, config.PrivateKeyFileName), cert.RawKey(), 0o600)
+}
+
+// LoadCertificate loads a certificate and its private key from a directory.
+func LoadCertificate(directory string) (*bootstrap.Certificate, error) {
+	rawCert, err := os.ReadFile(filepath.Join(directory, config.CertificateFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	rawKey, err := os.ReadFile(filepath.Join(directory, config.PrivateKeyFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := bootstrap.CertificateFromRaw(rawCert, rawKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse certificate in '%s': %w", directory, err)
+	}
+
+	return cert, nil
+}
+
+// LoadCertificateFile loads a certificate without its private key.
+func LoadCertificateFile(path string) (*bootstrap.Certificate, error) {
+	rawCert, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := bootstrap.CertificateFromRaw(rawCert, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse certificate '%s': %w", path, err)
+	}
+
+	return cert, nil
+}
+
+// LoadFabricTrustBundle returns the fabric trust bundle if a fabric CA rotation is in progress.
+// Otherwise, returns nil.
+func LoadFabricTrustBundle() ([]byte, error) {
+	bundle, err := os.ReadFile(filepath.Join(config.FabricDirectory(), config.TrustBundleFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	return bundle, err
+}
+
+// SaveCertificateConfig writes the k8s secrets YAML of a peer.
+func SaveCertificateConfig(platformCfg *platform.Config) error {
+	certConfig, err := platform.K8SCertificateConfig(platformCfg)
+	if err != nil {
+		return err
+	}
+
+	outPath := filepath.Join(config.PeerDirectory(platformCfg.Peer), config.K8SSecretYAMLFile)
+	return os.WriteFile(outPath, certConfig, 0o600)
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterlink "github.com/clusterlink-net/clusterlink/pkg/apis/clusterlink.net/v1alpha1"
+	"github.com/clusterlink-net/clusterlink/pkg/operator/controller"
+)
+
+// InstanceName is the name of the ClusterLink instance deployed for the operator.
+const InstanceName = "cl-instance"
+
+// NewK8SClient returns a k8s client for a cluster, using the given kubeconfig path and context.
+// If empty, the default kubeconfig and its current context are used.
+func NewK8SClient(kubeconfig, kubeContext string) (client.Client, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules, &clientcmd.ConfigOverrides{CurrentContext: kubeContext}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("cannot load kubeconfig: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := clusterlink.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	return client.New(restConfig, client.Options{Scheme: scheme})
+}
+
+// InstanceStatus returns a ClusterLink instance along with the status of its deployed components.
+// If operator is set, the instance is read from the cluster, with the status set by the operator.
+// Otherwise, the status is computed the same way the operator computes it.
+func InstanceStatus(
+	ctx context.Context, c client.Client, instance *clusterlink.Instance, operator bool,
+) (*clusterlink.Instance, error) {
+	if operator {
+		deployed := &clusterlink.Instance{}
+		name := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+		if err := c.Get(ctx, name, deployed); err != nil {
+			return nil, err
+		}
+		return deployed, nil
+	}
+
+	reconciler := &controller.InstanceReconciler{
+		Client: c,
+		Logger: logrus.WithField("component", "cl-adm"),
+	}
+	if _, err := reconciler.UpdateStatus(ctx, instance); err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
@@ -0,0 +1,260 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane"
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+)
+
+const (
+	// LeaseName is the name of the k8s Lease used for electing the leading controlplane replica.
+	LeaseName = "cl-controlplane"
+
+	// leaseDuration is the time followers wait before trying to take over a non-renewed lease.
+	leaseDuration = 8 * time.Second
+	// leaseRenewDeadline is the time the leader retries renewing the lease before giving up leadership.
+	leaseRenewDeadline = 5 * time.Second
+	// leaseRetryPeriod is the time between consecutive attempts to acquire or renew the lease.
+	leaseRetryPeriod = time.Second
+
+	// resyncInterval is the time between consecutive resyncs of a follower with the store.
+	resyncInterval = 2 * time.Second
+)
+
+// leaderElector elects the leading controlplane replica using a k8s Lease.
+// While not leading, the controlplane instance is resynced with the store whenever the store changes,
+// and periodically.
+type leaderElector struct {
+	cp        *controlplane.Instance
+	store     kv.Store
+	elector   *leaderelection.LeaderElector
+	reader    client.Reader
+	namespace string
+	identity  string
+
+	lock   sync.Mutex
+	cancel context.CancelFunc
+	err    error
+
+	logger *logrus.Entry
+}
+
+// Name of the elector.
+func (e *leaderElector) Name() string {
+	return "leader-elector"
+}
+
+// Start participating in leader elections, until stopped.
+func (e *leaderElector) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	e.lock.Lock()
+	e.cancel = cancel
+	e.lock.Unlock()
+
+	go e.follow(ctx)
+
+	// Run returns once leadership is lost, so keep running for the next election
+	for ctx.Err() == nil {
+		e.elector.Run(ctx)
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.err
+}
+
+// Stop participating in leader elections, releasing the lease if held.
+func (e *leaderElector) Stop() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.cancel != nil {
+		e.cancel()
+	}
+	return nil
+}
+
+// GracefulStop stops participating in leader elections.
+func (e *leaderElector) GracefulStop() error {
+	return e.Stop()
+}
+
+// startLeading is called once the lease is acquired.
+func (e *leaderElector) startLeading(context.Context) {
+	if err := e.cp.StartLeading(); err != nil {
+		e.logger.Errorf("Cannot start leading: %v.", err)
+
+		e.lock.Lock()
+		e.err = fmt.Errorf("cannot start leading: %w", err)
+		e.cancel()
+		e.lock.Unlock()
+	}
+}
+
+// stopLeading is called once the elector stops running, whether or not the lease was acquired.
+func (e *leaderElector) stopLeading() {
+	if e.cp.IsLeader() {
+		e.cp.StopLeading()
+	}
+}
+
+// follow resyncs the controlplane instance with the store, and tracks the address
+// of the leader, while the instance is not leading.
+func (e *leaderElector) follow(ctx context.Context) {
+	ticker := time.NewTicker(resyncInterval)
+	defer ticker.Stop()
+
+	events, stopWatch := e.store.Watch(nil)
+	defer func() {
+		stopWatch()
+	}()
+
+	var leader string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case _, ok := <-events:
+			if ok {
+				// a single resync handles all pending changes
+				ok = drain(events)
+			}
+			if !ok {
+				// events may have been missed, which is handled by the resync below
+				events, stopWatch = e.store.Watch(nil)
+			}
+		}
+
+		if e.cp.IsLeader() {
+			leader = ""
+			continue
+		}
+
+		if err := e.cp.Resync(); err != nil {
+			e.logger.Warnf("Cannot resync with store: %v.", err)
+		}
+
+		if current := e.elector.GetLeader(); current != leader || e.cp.LeaderAddress() == "" {
+			leader = current
+			e.cp.SetLeaderAddress(e.leaderAddress(ctx, leader))
+		}
+	}
+}
+
+// drain discards all pending events, and returns false if the channel was closed.
+func drain(events <-chan kv.Event) bool {
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return false
+			}
+		default:
+			return true
+		}
+	}
+}
+
+// leaderAddress returns the address of the controlplane server of the given leader pod.
+func (e *leaderElector) leaderAddress(ctx context.Context, leader string) string {
+	if leader == "" || leader == e.identity {
+		return ""
+	}
+
+	var pod v1.Pod
+	if err := e.reader.Get(ctx, types.NamespacedName{Namespace: e.namespace, Name: leader}, &pod); err != nil {
+		e.logger.Warnf("Cannot get leader pod '%s': %v.", leader, err)
+		return ""
+	}
+
+	if pod.Status.PodIP == "" {
+		return ""
+	}
+
+	e.logger.Infof("Leader is '%s' (%s).", leader, pod.Status.PodIP)
+	return net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(api.ListenPort))
+}
+
+// newLeaderElector returns a new leader elector for the given controlplane instance.
+// The pod name (hostname) is used as the identity of the replica.
+func newLeaderElector(
+	cp *controlplane.Instance,
+	kvStore kv.Store,
+	cfg *rest.Config,
+	reader client.Reader,
+	namespace string,
+) (*leaderElector, error) {
+	identity, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get hostname: %w", err)
+	}
+
+	e := &leaderElector{
+		cp:        cp,
+		store:     kvStore,
+		reader:    reader,
+		namespace: namespace,
+		identity:  identity,
+		logger:    logrus.WithField("component", "leader-elector"),
+	}
+
+	lock, err := resourcelock.NewFromKubeconfig(
+		resourcelock.LeasesResourceLock,
+		namespace,
+		LeaseName,
+		resourcelock.ResourceLockConfig{Identity: identity},
+		cfg,
+		leaseRenewDeadline)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create lease lock: %w", err)
+	}
+
+	e.elector, err = leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   leaseRenewDeadline,
+		RetryPeriod:     leaseRetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            LeaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: e.startLeading,
+			OnStoppedLeading: e.stopLeading,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create leader elector: %w", err)
+	}
+
+	return e, nil
+}
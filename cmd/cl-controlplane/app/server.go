@@ -27,10 +27,11 @@ import (
 	"github.com/clusterlink-net/clusterlink/pkg/apis/clusterlink.net/v1alpha1"
 	"github.com/clusterlink-net/clusterlink/pkg/controlplane"
 	"github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/audit"
 	"github.com/clusterlink-net/clusterlink/pkg/controlplane/server/grpc"
 	"github.com/clusterlink-net/clusterlink/pkg/controlplane/server/http"
+	platformk8s "github.com/clusterlink-net/clusterlink/pkg/platform/k8s"
 	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
-	"github.com/clusterlink-net/clusterlink/pkg/store/kv/bolt"
 	"github.com/clusterlink-net/clusterlink/pkg/util/controller"
 	"github.com/clusterlink-net/clusterlink/pkg/util/log"
 	"github.com/clusterlink-net/clusterlink/pkg/util/runnable"
@@ -57,6 +58,11 @@ const (
 	// grpcServerAddress is the address of the localhost gRPC server.
 	grpcServerAddress = "127.0.0.1:1101"
 
+	// auditFileMaxSize is the default size (in megabytes) of the audit file before it gets rotated.
+	auditFileMaxSize = 100
+	// auditFileMaxBackups is the default number of rotated audit files to keep.
+	auditFileMaxBackups = 5
+
 	// NamespaceEnvVariable is the environment variable
 	// which should hold the clusterlink system namespace name.
 	NamespaceEnvVariable = "CL_NAMESPACE"
@@ -73,6 +79,20 @@ type Options struct {
 	// CRDMode indicates a k8s CRD-based controlplane.
 	// This flag will be removed once the CRD-based controlplane feature is complete and stable.
 	CRDMode bool
+	// Store is the type of store used for persisting the controlplane state.
+	Store string
+	// LeaderElect enables running multiple controlplane replicas, electing a leader using a k8s Lease.
+	LeaderElect bool
+	// AuditFile is the path to a file where audit events will be written.
+	AuditFile string
+	// AuditFileMaxSize is the size (in megabytes) of the audit file before it gets rotated.
+	AuditFileMaxSize int
+	// AuditFileMaxBackups is the number of rotated audit files to keep.
+	AuditFileMaxBackups int
+	// AuditStdout indicates whether to write audit events to the standard output.
+	AuditStdout bool
+	// AuditWebhook is a URL where audit events will be sent to.
+	AuditWebhook string
 }
 
 // AddFlags adds flags to fs and binds them to options.
@@ -82,10 +102,50 @@ func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.LogLevel, "log-level", logLevel,
 		"The log level. One of fatal, error, warn, info, debug.")
 	fs.BoolVar(&o.CRDMode, "crd-mode", false, "Run a CRD-based controlplane.")
+	fs.StringVar(&o.Store, "store", StoreTypeBolt,
+		"The store used for persisting the controlplane state. One of bolt, k8s.")
+	fs.BoolVar(&o.LeaderElect, "leader-elect", false,
+		"Elect a leader among multiple controlplane replicas. Requires the k8s store.")
+	fs.StringVar(&o.AuditFile, "audit-file", "",
+		"Path to a file where audit events will be written (as JSON lines).")
+	fs.IntVar(&o.AuditFileMaxSize, "audit-file-max-size", auditFileMaxSize,
+		"The size (in megabytes) of the audit file before it gets rotated. 0 disables rotation.")
+	fs.IntVar(&o.AuditFileMaxBackups, "audit-file-max-backups", auditFileMaxBackups,
+		"The number of rotated audit files to keep.")
+	fs.BoolVar(&o.AuditStdout, "audit-stdout", false,
+		"Write audit events (as JSON lines) to the standard output.")
+	fs.StringVar(&o.AuditWebhook, "audit-webhook", "",
+		"URL where audit events will be sent to (as JSON POST requests).")
+}
+
+// auditor returns an auditor recording to the configured sinks.
+func (o *Options) auditor() (*audit.Auditor, error) {
+	var sinks []audit.Sink
+	if o.AuditFile != "" {
+		sink, err := audit.NewFileSink(o.AuditFile, int64(o.AuditFileMaxSize)*1024*1024, o.AuditFileMaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	if o.AuditStdout {
+		sinks = append(sinks, audit.NewStdoutSink())
+	}
+
+	if o.AuditWebhook != "" {
+		sinks = append(sinks, audit.NewWebhookSink(o.AuditWebhook))
+	}
+
+	return audit.NewAuditor(sinks...), nil
 }
 
 // Run the various controlplane servers.
 func (o *Options) Run() error {
+	if o.LeaderElect && o.Store != StoreTypeK8s {
+		return fmt.Errorf("leader election requires the '%s' store", StoreTypeK8s)
+	}
+
 	// set log file
 
 	f, err := log.Set(o.LogLevel, o.LogFile)
@@ -146,7 +206,7 @@ func (o *Options) Run() error {
 	}
 
 	// open store
-	kvStore, err := bolt.Open(StoreFile)
+	kvStore, err := openStore(o.Store, config, scheme, namespace)
 	if err != nil {
 		return err
 	}
@@ -159,7 +219,23 @@ func (o *Options) Run() error {
 
 	storeManager := kv.NewManager(kvStore)
 
-	cp, err := controlplane.NewInstance(parsedCertData, storeManager, namespace)
+	auditor, err := o.auditor()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := auditor.Close(); err != nil {
+			logrus.Warnf("Cannot close auditor: %v.", err)
+		}
+	}()
+
+	pp, err := platformk8s.NewPlatform(namespace)
+	if err != nil {
+		return err
+	}
+
+	cp, err := controlplane.NewInstance(parsedCertData, storeManager, pp, auditor)
 	if err != nil {
 		return err
 	}
@@ -172,7 +248,19 @@ func (o *Options) Run() error {
 
 	runnableManager := runnable.NewManager()
 	runnableManager.Add(controller.NewManager(mgr))
-	runnableManager.AddServer(httpServerAddress, http.NewServer(cp, parsedCertData.ServerConfig()))
+	runnableManager.Add(controlplane.NewAutoBinder(cp))
+
+	if o.LeaderElect {
+		elector, err := newLeaderElector(cp, kvStore, config, mgr.GetAPIReader(), namespace)
+		if err != nil {
+			return err
+		}
+		runnableManager.Add(elector)
+	} else if err := cp.StartLeading(); err != nil {
+		return err
+	}
+
+	runnableManager.AddServer(httpServerAddress, http.NewServer(cp, auditor, parsedCertData.ServerConfig()))
 	runnableManager.AddServer(grpcServerAddress, grpc.NewServer(cp, parsedCertData.ServerConfig()))
 	runnableManager.AddServer(controlplaneServerListenAddress, sniProxy)
 
@@ -194,5 +282,7 @@ func NewCLControlplaneCommand() *cobra.Command {
 
 	opts.AddFlags(cmd.Flags())
 
+	cmd.AddCommand(NewMigrateStoreCommand())
+
 	return cmd
 }
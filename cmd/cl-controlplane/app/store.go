@@ -0,0 +1,147 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv/bolt"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv/k8s"
+)
+
+const (
+	// StoreTypeBolt is a store persisted in a bolt file (StoreFile).
+	StoreTypeBolt = "bolt"
+	// StoreTypeK8s is a store persisted in k8s ConfigMaps.
+	StoreTypeK8s = "k8s"
+
+	// K8sStoreName is the name of the k8s store, prefixing the names of its ConfigMaps.
+	K8sStoreName = "cl-controlplane-store"
+)
+
+// openStore opens a store of the given type.
+func openStore(storeType string, cfg *rest.Config, scheme *runtime.Scheme, namespace string) (kv.Store, error) {
+	switch storeType {
+	case StoreTypeBolt:
+		return bolt.Open(StoreFile)
+	case StoreTypeK8s:
+		c, err := client.NewWithWatch(cfg, client.Options{Scheme: scheme})
+		if err != nil {
+			return nil, fmt.Errorf("unable to create k8s client: %w", err)
+		}
+		return k8s.NewStore(c, namespace, K8sStoreName), nil
+	default:
+		return nil, fmt.Errorf("unknown store type '%s'", storeType)
+	}
+}
+
+// MigrateStoreOptions contains everything necessary to migrate a bolt store to a k8s store.
+type MigrateStoreOptions struct {
+	// BoltFile is the path to the bolt store file.
+	BoltFile string
+	// Namespace where the k8s store is persisted.
+	Namespace string
+}
+
+// AddFlags adds flags to fs and binds them to options.
+func (o *MigrateStoreOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.BoltFile, "bolt-file", StoreFile,
+		"Path to the bolt store file.")
+	fs.StringVar(&o.Namespace, "namespace", "",
+		"Namespace where the k8s store is persisted. If not specified, "+
+			"the value of the "+NamespaceEnvVariable+" environment variable is used.")
+}
+
+// Run the migration.
+func (o *MigrateStoreOptions) Run() error {
+	namespace := o.Namespace
+	if namespace == "" {
+		namespace = os.Getenv(NamespaceEnvVariable)
+	}
+	if namespace == "" {
+		namespace = SystemNamespace
+	}
+
+	// bolt.Open creates a missing file, so verify it exists
+	if _, err := os.Stat(o.BoltFile); err != nil {
+		return fmt.Errorf("cannot access bolt file: %w", err)
+	}
+
+	srcStore, err := bolt.Open(o.BoltFile)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := srcStore.Close(); err != nil {
+			logrus.Warnf("Cannot close bolt store: %v.", err)
+		}
+	}()
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("unable to get k8s config: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := v1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("unable to add core v1 objects to scheme: %w", err)
+	}
+
+	dstStore, err := openStore(StoreTypeK8s, cfg, scheme, namespace)
+	if err != nil {
+		return err
+	}
+
+	count, err := kv.Copy(dstStore, srcStore)
+	if err != nil {
+		return fmt.Errorf("migrated %d keys before failing: %w", count, err)
+	}
+
+	fmt.Printf("Migrated %d keys to ConfigMaps in namespace '%s'.\n", count, namespace)
+	return nil
+}
+
+// NewMigrateStoreCommand creates a *cobra.Command for migrating a bolt store to a k8s store.
+func NewMigrateStoreCommand() *cobra.Command {
+	opts := &MigrateStoreOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "migrate-store",
+		Short: "Copy a bolt store to a k8s (ConfigMap) store",
+		Long: `Copy a bolt store to a k8s (ConfigMap) store.
+
+The bolt file is locked while a controlplane is using it, so the controlplane must be stopped first.
+Keys already existing in the k8s store are overwritten.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Run()
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
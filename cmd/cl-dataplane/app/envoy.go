@@ -16,17 +16,24 @@ package app
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"text/template"
 
+	"github.com/sirupsen/logrus"
+
 	cpapi "github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
 	"github.com/clusterlink-net/clusterlink/pkg/dataplane/api"
+	"github.com/clusterlink-net/clusterlink/pkg/dataplane/metrics"
 )
 
 const (
 	envoyPath = "/usr/local/bin/envoy"
+	// envoyAdminPort is the port of the Envoy admin interface, which listens on the loopback interface.
+	envoyAdminPort = 1000
 )
 
 func (o *Options) runEnvoy(peerName, dataplaneID string) error {
@@ -34,6 +41,8 @@ func (o *Options) runEnvoy(peerName, dataplaneID string) error {
 		"peerName":    peerName,
 		"dataplaneID": dataplaneID,
 
+		"adminPort": envoyAdminPort,
+
 		"controlplaneHost": o.ControlplaneHost,
 		"controlplanePort": cpapi.ListenPort,
 
@@ -81,6 +90,12 @@ func (o *Options) runEnvoy(peerName, dataplaneID string) error {
 		args = append(args, "--log-path", o.LogFile)
 	}
 
+	go func() {
+		adminAddress := net.JoinHostPort("127.0.0.1", strconv.Itoa(envoyAdminPort))
+		err := metrics.Serve(metrics.EnvoyConnections(adminAddress))
+		logrus.Errorf("Failed to start metrics server: %v.", err)
+	}()
+
 	cmd := exec.Command(envoyPath, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
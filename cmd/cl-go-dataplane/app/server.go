@@ -14,10 +14,12 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -27,8 +29,10 @@ import (
 	cpapi "github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
 	"github.com/clusterlink-net/clusterlink/pkg/dataplane/api"
 	dpclient "github.com/clusterlink-net/clusterlink/pkg/dataplane/client"
+	"github.com/clusterlink-net/clusterlink/pkg/dataplane/metrics"
 	dpserver "github.com/clusterlink-net/clusterlink/pkg/dataplane/server"
 	"github.com/clusterlink-net/clusterlink/pkg/util/log"
+	"github.com/clusterlink-net/clusterlink/pkg/util/spiffe"
 	"github.com/clusterlink-net/clusterlink/pkg/util/tls"
 )
 
@@ -45,6 +49,11 @@ const (
 
 	// dataplaneServerAddress is the address of the dataplane HTTP server for accepting ingress dataplane connections.
 	dataplaneServerAddress = "127.0.0.1:8443"
+
+	// spiffeAudience is the default audience of JWT-SVIDs presented by client workloads.
+	spiffeAudience = "clusterlink"
+	// spiffeTimeout is the time to wait for the SPIFFE Workload API to provide the SVID and trust bundles.
+	spiffeTimeout = 30 * time.Second
 )
 
 // Options contains everything necessary to create and run a dataplane.
@@ -55,6 +64,10 @@ type Options struct {
 	LogFile string
 	// LogLevel is the log level.
 	LogLevel string
+	// SPIFFESocket is the SPIFFE Workload API endpoint used to authenticate client workloads.
+	SPIFFESocket string
+	// SPIFFEAudience is the audience of JWT-SVIDs presented by client workloads.
+	SPIFFEAudience string
 }
 
 // AddFlags adds flags to fs and binds them to options.
@@ -65,6 +78,11 @@ func (o *Options) AddFlags(fs *pflag.FlagSet) {
 		"Path to a file where logs will be written. If not specified, logs will be printed to stderr.")
 	fs.StringVar(&o.LogLevel, "log-level", logLevel,
 		"The log level. One of fatal, error, warn, info, debug.")
+	fs.StringVar(&o.SPIFFESocket, "spiffe-socket", "",
+		"The SPIFFE Workload API endpoint (e.g. unix:///run/spire/sockets/agent.sock). "+
+			"If specified, client workloads must authenticate using SPIFFE SVIDs.")
+	fs.StringVar(&o.SPIFFEAudience, "spiffe-audience", spiffeAudience,
+		"The audience of JWT-SVIDs presented by client workloads.")
 }
 
 // RequiredFlags are the names of flags that must be explicitly specified.
@@ -79,6 +97,19 @@ func (o *Options) runGoDataplane(peerName, dataplaneID string, parsedCertData *t
 	logrus.Infof("Starting go dataplane, Name: %s, ID: %s", peerName, dataplaneID)
 
 	dataplane := dpserver.NewDataplane(dataplaneID, controlplaneTarget, peerName, parsedCertData)
+	if o.SPIFFESocket != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), spiffeTimeout)
+		source, err := spiffe.NewSource(ctx, o.SPIFFESocket)
+		cancel()
+		if err != nil {
+			return err
+		}
+		defer source.Close()
+
+		logrus.Infof("Authenticating client workloads using SPIFFE, dataplane ID: %s.", source.ID())
+		dataplane.EnableWorkloadIdentity(source, o.SPIFFEAudience)
+	}
+
 	go func() {
 		err := dataplane.StartDataplaneServer(dataplaneServerAddress)
 		logrus.Errorf("Failed to start dataplane server: %v.", err)
@@ -89,6 +120,11 @@ func (o *Options) runGoDataplane(peerName, dataplaneID string, parsedCertData *t
 		logrus.Error("Failed to start dataplane server", err)
 	}()
 
+	go func() {
+		err := metrics.Serve(dataplane.ActiveConnections)
+		logrus.Errorf("Failed to start metrics server: %v.", err)
+	}()
+
 	// Start xDS client, if it fails to start we keep retrying to connect to the controlplane host
 	tlsConfig := parsedCertData.ClientConfig(cpapi.GRPCServerName(peerName))
 	xdsClient := dpclient.NewXDSClient(dataplane, controlplaneTarget, tlsConfig)
@@ -14,67 +14,78 @@
 package config
 
 import (
-	"encoding/json"
-	"errors"
+	"fmt"
+	"net"
 	"os"
-	"os/user"
-	"path"
+	"strconv"
 
-	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
 
 	"github.com/clusterlink-net/clusterlink/pkg/client"
 	"github.com/clusterlink-net/clusterlink/pkg/util/tls"
 )
 
-const (
-	projectFolder = "/.gw/"
-	configFile    = "gwctl"
-)
+// contextName is the context selected using the --context flag.
+var contextName string
+
+// AddFlags registers the flags for selecting the context used by commands.
+func AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&contextName, "context", "",
+		fmt.Sprintf("Name of the gwctl context to use. If empty, uses the current context of the config file "+
+			"(set by the %s environment variable, or %s)", ConfigEnv, "~/.gw/"+defaultConfigFile))
+}
 
 // ClientConfig contain all Client configuration to send requests to the GW.
+// It is the configuration of a context, resolved from the clusters and credentials it refers to.
 type ClientConfig struct {
-	GwIP             string        `json:"gwIp"`
-	GwPort           uint16        `json:"gwPort"`
-	ID               string        `json:"id"`
-	CaFile           string        `json:"caFile"`
-	CertFile         string        `json:"certFile"`
-	KeyFile          string        `json:"keyFile"`
-	Dataplane        string        `json:"dataplane"`
-	PolicyEngineIP   string        `json:"policyEngineIp"`
-	MetricsManagerIP string        `json:"metricsManagerIp"`
-	logger           *logrus.Entry `json:"-"`
-}
-
-// NewClientConfig create config file with all the configuration of the Client.
+	GwIP             string `json:"gwIp"`
+	GwPort           uint16 `json:"gwPort"`
+	ID               string `json:"id"`
+	CaFile           string `json:"caFile"`
+	CertFile         string `json:"certFile"`
+	KeyFile          string `json:"keyFile"`
+	Dataplane        string `json:"dataplane"`
+	PolicyEngineIP   string `json:"policyEngineIp"`
+	MetricsManagerIP string `json:"metricsManagerIp"`
+
+	// serverName is the name used for verifying the server certificate.
+	serverName string
+	// caData, certData and keyData are the inline CA, certificate and key, used instead of the files.
+	caData   []byte
+	certData []byte
+	keyData  []byte
+}
+
+// NewClientConfig adds a context (with a cluster and credentials of the same name) to the config file,
+// and sets it as the current context.
 func NewClientConfig(cfg *ClientConfig) (*ClientConfig, error) {
-	clientCfg := ClientConfig{
-		ID:               cfg.ID,
-		GwIP:             cfg.GwIP,
-		GwPort:           cfg.GwPort,
-		Dataplane:        cfg.Dataplane,
-		CertFile:         cfg.CertFile,
-		KeyFile:          cfg.KeyFile,
-		CaFile:           cfg.CaFile,
-		PolicyEngineIP:   cfg.PolicyEngineIP,
-		MetricsManagerIP: cfg.MetricsManagerIP,
-		logger:           logrus.WithField("component", "gwctl/config"),
-	}
-
-	_, err := clientCfg.createProjectfolder()
+	f, err := Load()
 	if err != nil {
 		return nil, err
 	}
-	err = clientCfg.createConfigFile()
-	if err != nil {
+
+	f.addClientConfig(cfg)
+	f.CurrentContext = cfg.ID
+	if err := f.Save(); err != nil {
 		return nil, err
 	}
-	return &clientCfg, nil
+
+	return f.Resolve(cfg.ID)
 }
 
-// GetConfigFromID return configuration of Client according to the Client ID.
+// GetConfigFromID return configuration of Client according to the Client ID, which is the name of a context.
+// If the ID is empty, the context selected by the --context flag (or the current context) is used.
 func GetConfigFromID(id string) (*ClientConfig, error) {
-	c, err := readConfigFromFile(id)
-	return &c, err
+	f, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		id = contextName
+	}
+
+	return f.Resolve(id)
 }
 
 // GetGwIP return the gw IP that the Client is connected.
@@ -122,91 +133,22 @@ func (c *ClientConfig) GetMetricsManagerIP() string {
 	return c.MetricsManagerIP
 }
 
-/********************************/
-/******** Config functions **********/
-/********************************/
-
-func (c *ClientConfig) createProjectfolder() (string, error) {
-	usr, err := user.Current()
-	if err != nil {
-		return "", err
-	}
-	fol := path.Join(usr.HomeDir, projectFolder)
-	// Create folder
-	err = os.MkdirAll(fol, 0o755)
-	if err != nil {
-		c.logger.Errorln(err)
-		return "", err
-	}
-	return fol, nil
-}
-
-func (c *ClientConfig) createConfigFile() error {
-	jsonC, err := json.MarshalIndent(c, "", "\t")
-	if err != nil {
-		c.logger.Errorln("Client create config File", err)
-		return err
-	}
-	f, err := ClientPath(c.ID)
-	if err != nil {
-		c.logger.Errorln("Client get config file path", err)
-		return err
-	}
-	err = os.WriteFile(f, jsonC, 0o600) // RW by owner only
-	c.logger.Println("Create Client config File:", f)
-	if err != nil {
-		c.logger.Errorln("Creating client config File", err)
-		return err
-	}
-	return c.SetDefaultClient(c.ID)
-}
-
-// SetDefaultClient set the default Client the CLI will use.
-func (c *ClientConfig) SetDefaultClient(id string) error {
-	// Check if the file exist
-	file, err := ClientPath(id)
-	if err != nil {
-		c.logger.Errorf("failed to get client config file path for id %v\n", id)
-		return err
-	}
-	if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
-		c.logger.Errorf("Client config File with id %v does not exist\n", id)
-		return err
-	}
+// parseCertData parses the CA, certificate and key of the client, using the inline data if set, or the files.
+func (c *ClientConfig) parseCertData() (*tls.ParsedCertData, error) {
+	data := [][]byte{c.caData, c.certData, c.keyData}
+	for i, file := range []string{c.CaFile, c.CertFile, c.KeyFile} {
+		if data[i] != nil {
+			continue
+		}
 
-	// Remove if the link exist
-	link, err := ClientPath("")
-	if err != nil {
-		c.logger.Errorf("failed to get client config link path\n")
-		return err
-	}
-	if _, err := os.Lstat(link); err == nil {
-		os.Remove(link)
+		var err error
+		data[i], err = os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read '%s': %w", file, err)
+		}
 	}
-	// Create a link
-	err = os.Symlink(file, link)
-	if err != nil {
-		c.logger.Errorln("Error creating symlink:", err)
-		return err
-	}
-	return nil
-}
 
-func readConfigFromFile(id string) (ClientConfig, error) {
-	file, err := ClientPath(id)
-	if err != nil {
-		return ClientConfig{}, err
-	}
-	data, err := os.ReadFile(file)
-	if err != nil {
-		return ClientConfig{}, err
-	}
-	var s ClientConfig
-	err = json.Unmarshal(data, &s)
-	if err != nil {
-		return ClientConfig{}, err
-	}
-	return s, nil
+	return tls.ParseData(data[0], data[1], data[2])
 }
 
 // GetClientFromID loads Client from file according to the id.
@@ -216,24 +158,25 @@ func GetClientFromID(id string) (*client.Client, error) {
 		return nil, err
 	}
 
-	parsedCertData, err := tls.ParseFiles(c.CaFile, c.CertFile, c.KeyFile)
+	parsedCertData, err := c.parseCertData()
 	if err != nil {
 		return nil, err
 	}
 
-	return client.New(c.GwIP, c.GwPort, parsedCertData.ClientConfig(c.ID)), nil
+	return client.New(c.GwIP, c.GwPort, parsedCertData.ClientConfig(c.serverName)), nil
 }
 
-// ClientPath get CLI config file from id.
-func ClientPath(id string) (string, error) {
-	cfgFile := configFile
-	if id != "" {
-		cfgFile += "_" + id
+// parseServer parses the host and port of a controlplane server address.
+func parseServer(server string) (string, uint16, error) {
+	host, port, err := net.SplitHostPort(server)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid server address '%s': %w", server, err)
 	}
-	// set cfg file in home directory
-	usr, err := user.Current()
+
+	parsedPort, err := strconv.ParseUint(port, 10, 16)
 	if err != nil {
-		return "", err
+		return "", 0, fmt.Errorf("invalid server port '%s': %w", port, err)
 	}
-	return path.Join(usr.HomeDir, projectFolder, cfgFile), nil
+
+	return host, uint16(parsedPort), nil
 }
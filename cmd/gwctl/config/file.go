@@ -0,0 +1,364 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConfigEnv is the environment variable holding the path of the config file.
+	ConfigEnv = "GWCTL_CONFIG"
+
+	projectFolder     = ".gw"
+	defaultConfigFile = "config"
+	// legacyConfigFile is the prefix of the per-ID config files used by older gwctl versions.
+	legacyConfigFile = "gwctl"
+)
+
+// File is the gwctl config file, holding named clusters, credentials and contexts.
+type File struct {
+	// CurrentContext is the name of the context used when no context is specified.
+	CurrentContext string `json:"currentContext"`
+	// Clusters are the controlplanes that gwctl can connect to.
+	Clusters []NamedCluster `json:"clusters"`
+	// Credentials are the client certificates used for connecting to clusters.
+	Credentials []NamedCredential `json:"credentials"`
+	// Contexts are pairs of a cluster and the credentials used for connecting to it.
+	Contexts []NamedContext `json:"contexts"`
+}
+
+// Cluster is a controlplane that gwctl can connect to.
+type Cluster struct {
+	// Server is the controlplane address (host:port).
+	Server string `json:"server"`
+	// ServerName is the name used for verifying the server certificate. If empty, the context name is used.
+	ServerName string `json:"serverName,omitempty"`
+	// CertificateAuthority is the path of the CA file (.pem) for verifying the server certificate.
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+	// CertificateAuthorityData is the PEM-encoded CA, overriding CertificateAuthority.
+	CertificateAuthorityData []byte `json:"certificateAuthorityData,omitempty"`
+	// Dataplane type (mtls or tcp).
+	Dataplane string `json:"dataplane,omitempty"`
+	// PolicyEngineIP is the address of the policy engine.
+	PolicyEngineIP string `json:"policyEngineIp,omitempty"`
+	// MetricsManagerIP is the address of the metrics manager.
+	MetricsManagerIP string `json:"metricsManagerIp,omitempty"`
+}
+
+// Credential is a client certificate used for connecting to clusters.
+type Credential struct {
+	// ClientCertificate is the path of the client certificate file (.pem).
+	ClientCertificate string `json:"clientCertificate,omitempty"`
+	// ClientCertificateData is the PEM-encoded client certificate, overriding ClientCertificate.
+	ClientCertificateData []byte `json:"clientCertificateData,omitempty"`
+	// ClientKey is the path of the client key file (.pem).
+	ClientKey string `json:"clientKey,omitempty"`
+	// ClientKeyData is the PEM-encoded client key, overriding ClientKey.
+	ClientKeyData []byte `json:"clientKeyData,omitempty"`
+}
+
+// Context is a cluster and the credentials used for connecting to it.
+type Context struct {
+	// Cluster name.
+	Cluster string `json:"cluster"`
+	// Credential name.
+	Credential string `json:"credential"`
+}
+
+// NamedCluster is a cluster along with its name.
+type NamedCluster struct {
+	Name    string  `json:"name"`
+	Cluster Cluster `json:"cluster"`
+}
+
+// NamedCredential is a credential along with its name.
+type NamedCredential struct {
+	Name       string     `json:"name"`
+	Credential Credential `json:"credential"`
+}
+
+// NamedContext is a context along with its name.
+type NamedContext struct {
+	Name    string  `json:"name"`
+	Context Context `json:"context"`
+}
+
+// projectPath returns the path of a file in the gwctl folder, under the home directory.
+func projectPath(name string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, projectFolder, name), nil
+}
+
+// FilePath returns the path of the config file, which is set by the GWCTL_CONFIG environment variable,
+// and defaults to ~/.gw/config.
+func FilePath() (string, error) {
+	if path := os.Getenv(ConfigEnv); path != "" {
+		return path, nil
+	}
+	return projectPath(defaultConfigFile)
+}
+
+// Load reads the config file. If the file does not exist, it is created from the config files of older
+// gwctl versions (if there are any).
+func Load() (*File, error) {
+	path, err := FilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return migrateLegacyFiles()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f File
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("unable to parse config file '%s': %w", path, err)
+	}
+
+	return &f, nil
+}
+
+// Save writes the config file.
+func (f *File) Save() error {
+	path, err := FilePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600) // RW by owner only
+}
+
+// Cluster returns the cluster with the given name, or nil if there is no such cluster.
+func (f *File) Cluster(name string) *Cluster {
+	for i := range f.Clusters {
+		if f.Clusters[i].Name == name {
+			return &f.Clusters[i].Cluster
+		}
+	}
+	return nil
+}
+
+// Credential returns the credential with the given name, or nil if there is no such credential.
+func (f *File) Credential(name string) *Credential {
+	for i := range f.Credentials {
+		if f.Credentials[i].Name == name {
+			return &f.Credentials[i].Credential
+		}
+	}
+	return nil
+}
+
+// Context returns the context with the given name, or nil if there is no such context.
+func (f *File) Context(name string) *Context {
+	for i := range f.Contexts {
+		if f.Contexts[i].Name == name {
+			return &f.Contexts[i].Context
+		}
+	}
+	return nil
+}
+
+// SetCluster adds or replaces a cluster.
+func (f *File) SetCluster(name string, cluster *Cluster) {
+	if current := f.Cluster(name); current != nil {
+		*current = *cluster
+		return
+	}
+	f.Clusters = append(f.Clusters, NamedCluster{Name: name, Cluster: *cluster})
+}
+
+// SetCredential adds or replaces a credential.
+func (f *File) SetCredential(name string, credential *Credential) {
+	if current := f.Credential(name); current != nil {
+		*current = *credential
+		return
+	}
+	f.Credentials = append(f.Credentials, NamedCredential{Name: name, Credential: *credential})
+}
+
+// SetContext adds or replaces a context.
+func (f *File) SetContext(name string, context *Context) {
+	if current := f.Context(name); current != nil {
+		*current = *context
+		return
+	}
+	f.Contexts = append(f.Contexts, NamedContext{Name: name, Context: *context})
+}
+
+// DeleteContext deletes a context. Its cluster and credential are kept, as other contexts may refer to them.
+func (f *File) DeleteContext(name string) error {
+	for i := range f.Contexts {
+		if f.Contexts[i].Name == name {
+			f.Contexts = append(f.Contexts[:i], f.Contexts[i+1:]...)
+			if f.CurrentContext == name {
+				f.CurrentContext = ""
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("context '%s' does not exist", name)
+}
+
+// UseContext sets the current context.
+func (f *File) UseContext(name string) error {
+	if f.Context(name) == nil {
+		return fmt.Errorf("context '%s' does not exist", name)
+	}
+
+	f.CurrentContext = name
+	return nil
+}
+
+// Resolve returns the client configuration of a context. If name is empty, the current context is used.
+func (f *File) Resolve(name string) (*ClientConfig, error) {
+	if name == "" {
+		name = f.CurrentContext
+	}
+	if name == "" {
+		return nil, fmt.Errorf("no context is set, use 'gwctl init' or 'gwctl config set-context'")
+	}
+
+	context := f.Context(name)
+	if context == nil {
+		return nil, fmt.Errorf("context '%s' does not exist", name)
+	}
+
+	cluster := f.Cluster(context.Cluster)
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster '%s' of context '%s' does not exist", context.Cluster, name)
+	}
+
+	credential := f.Credential(context.Credential)
+	if credential == nil {
+		return nil, fmt.Errorf("credential '%s' of context '%s' does not exist", context.Credential, name)
+	}
+
+	host, port, err := parseServer(cluster.Server)
+	if err != nil {
+		return nil, fmt.Errorf("cluster '%s': %w", context.Cluster, err)
+	}
+
+	serverName := cluster.ServerName
+	if serverName == "" {
+		serverName = name
+	}
+
+	return &ClientConfig{
+		GwIP:             host,
+		GwPort:           port,
+		ID:               name,
+		CaFile:           cluster.CertificateAuthority,
+		CertFile:         credential.ClientCertificate,
+		KeyFile:          credential.ClientKey,
+		Dataplane:        cluster.Dataplane,
+		PolicyEngineIP:   cluster.PolicyEngineIP,
+		MetricsManagerIP: cluster.MetricsManagerIP,
+		serverName:       serverName,
+		caData:           cluster.CertificateAuthorityData,
+		certData:         credential.ClientCertificateData,
+		keyData:          credential.ClientKeyData,
+	}, nil
+}
+
+// addClientConfig adds a context, along with a cluster and credential of the same name, from a client configuration.
+func (f *File) addClientConfig(cfg *ClientConfig) {
+	f.SetCluster(cfg.ID, &Cluster{
+		Server:               net.JoinHostPort(cfg.GwIP, strconv.Itoa(int(cfg.GwPort))),
+		CertificateAuthority: cfg.CaFile,
+		Dataplane:            cfg.Dataplane,
+		PolicyEngineIP:       cfg.PolicyEngineIP,
+		MetricsManagerIP:     cfg.MetricsManagerIP,
+	})
+	f.SetCredential(cfg.ID, &Credential{
+		ClientCertificate: cfg.CertFile,
+		ClientKey:         cfg.KeyFile,
+	})
+	f.SetContext(cfg.ID, &Context{Cluster: cfg.ID, Credential: cfg.ID})
+}
+
+// migrateLegacyFiles creates the config file from the per-ID config files of older gwctl versions
+// (~/.gw/gwctl_<id>), where ~/.gw/gwctl links to the current one. The legacy files are left intact.
+func migrateLegacyFiles() (*File, error) {
+	f := &File{}
+
+	pattern, err := projectPath(legacyConfigFile + "_*")
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := filepath.Glob(pattern)
+	if err != nil || len(paths) == 0 {
+		return f, err
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var cfg ClientConfig
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("unable to parse legacy config file '%s': %w", path, err)
+		}
+		if cfg.ID == "" {
+			cfg.ID = strings.TrimPrefix(filepath.Base(path), legacyConfigFile+"_")
+		}
+
+		f.addClientConfig(&cfg)
+	}
+
+	if link, err := projectPath(legacyConfigFile); err == nil {
+		if target, err := os.Readlink(link); err == nil {
+			f.CurrentContext = strings.TrimPrefix(filepath.Base(target), legacyConfigFile+"_")
+		}
+	}
+
+	if err := f.Save(); err != nil {
+		return nil, err
+	}
+
+	path, _ := FilePath()
+	logrus.WithField("component", "gwctl/config").Infof(
+		"Migrated %d legacy config files to '%s'.", len(paths), path)
+
+	return f, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/cmd/gwctl/config"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
+)
+
+// setHome points the home directory and the config file at a temporary directory.
+func setHome(t *testing.T) string {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(config.ConfigEnv, filepath.Join(home, "gwctl.yaml"))
+	return home
+}
+
+func TestMigrateLegacyFiles(t *testing.T) {
+	home := setHome(t)
+
+	// no config file and no legacy files
+	f, err := config.Load()
+	require.Nil(t, err)
+	require.Equal(t, &config.File{}, f)
+
+	legacyDir := filepath.Join(home, ".gw")
+	require.Nil(t, os.MkdirAll(legacyDir, 0o755))
+	writeLegacy := func(name string, cfg *config.ClientConfig) {
+		data, err := json.Marshal(cfg)
+		require.Nil(t, err)
+		require.Nil(t, os.WriteFile(filepath.Join(legacyDir, "gwctl_"+name), data, 0o600))
+	}
+
+	writeLegacy("peer1", &config.ClientConfig{
+		GwIP: "10.0.0.1", GwPort: 443, ID: "peer1", CaFile: "ca.pem", CertFile: "cert.pem", KeyFile: "key.pem",
+	})
+	// the ID is taken from the file name if missing
+	writeLegacy("peer2", &config.ClientConfig{GwIP: "10.0.0.2", GwPort: 8443, Dataplane: "mtls"})
+	require.Nil(t, os.Symlink(filepath.Join(legacyDir, "gwctl_peer2"), filepath.Join(legacyDir, "gwctl")))
+
+	f, err = config.Load()
+	require.Nil(t, err)
+	require.Equal(t, "peer2", f.CurrentContext)
+	require.Len(t, f.Contexts, 2)
+
+	current, err := f.Resolve("")
+	require.Nil(t, err)
+	require.Equal(t, "peer2", current.ID)
+	require.Equal(t, "10.0.0.2", current.GwIP)
+	require.Equal(t, uint16(8443), current.GwPort)
+	require.Equal(t, "mtls", current.Dataplane)
+
+	peer1, err := f.Resolve("peer1")
+	require.Nil(t, err)
+	require.Equal(t, "10.0.0.1", peer1.GwIP)
+	require.Equal(t, uint16(443), peer1.GwPort)
+	require.Equal(t, "ca.pem", peer1.CaFile)
+	require.Equal(t, "cert.pem", peer1.CertFile)
+	require.Equal(t, "key.pem", peer1.KeyFile)
+
+	// the migrated config is saved, and the legacy files are left intact
+	_, err = os.Stat(os.Getenv(config.ConfigEnv))
+	require.Nil(t, err)
+	_, err = os.Stat(filepath.Join(legacyDir, "gwctl_peer1"))
+	require.Nil(t, err)
+
+	saved, err := config.Load()
+	require.Nil(t, err)
+	require.Equal(t, f, saved)
+}
+
+func TestResolve(t *testing.T) {
+	f := &config.File{}
+	f.SetCluster("cluster", &config.Cluster{Server: "10.0.0.1:443", ServerName: "peer1"})
+	f.SetCredential("credential", &config.Credential{ClientCertificate: "cert.pem", ClientKey: "key.pem"})
+	f.SetContext("ok", &config.Context{Cluster: "cluster", Credential: "credential"})
+	f.SetContext("no-cluster", &config.Context{Cluster: "missing", Credential: "credential"})
+	f.SetContext("no-credential", &config.Context{Cluster: "cluster", Credential: "missing"})
+
+	_, err := f.Resolve("")
+	require.ErrorContains(t, err, "no context is set")
+
+	_, err = f.Resolve("unknown")
+	require.ErrorContains(t, err, "context 'unknown' does not exist")
+
+	_, err = f.Resolve("no-cluster")
+	require.ErrorContains(t, err, "cluster 'missing' of context 'no-cluster' does not exist")
+
+	_, err = f.Resolve("no-credential")
+	require.ErrorContains(t, err, "credential 'missing' of context 'no-credential' does not exist")
+
+	require.Nil(t, f.UseContext("ok"))
+	cfg, err := f.Resolve("")
+	require.Nil(t, err)
+	require.Equal(t, "ok", cfg.ID)
+	require.Equal(t, "10.0.0.1", cfg.GwIP)
+	require.Equal(t, uint16(443), cfg.GwPort)
+	require.Equal(t, "cert.pem", cfg.CertFile)
+}
+
+func TestInlineCertificateData(t *testing.T) {
+	setHome(t)
+
+	fabric, err := bootstrap.CreateFabricCertificate(time.Hour)
+	require.Nil(t, err)
+	peer, err := bootstrap.CreatePeerCertificate("peer1", fabric, time.Hour)
+	require.Nil(t, err)
+	gwctl, err := bootstrap.CreateGWCTLCertificate(peer, time.Hour)
+	require.Nil(t, err)
+
+	// the files do not exist, so the client can only be created from the inline data
+	missing := filepath.Join(t.TempDir(), "missing.pem")
+	cluster := &config.Cluster{Server: "10.0.0.1:443", CertificateAuthority: missing}
+	credential := &config.Credential{ClientCertificate: missing, ClientKey: missing}
+
+	f := &config.File{}
+	f.SetCluster("peer1", cluster)
+	f.SetCredential("peer1", credential)
+	f.SetContext("peer1", &config.Context{Cluster: "peer1", Credential: "peer1"})
+	require.Nil(t, f.Save())
+
+	_, err = config.GetClientFromID("peer1")
+	require.ErrorContains(t, err, "unable to read")
+
+	cluster.CertificateAuthorityData = fabric.RawCert()
+	credential.ClientCertificateData = gwctl.RawCert()
+	credential.ClientKeyData = gwctl.RawKey()
+	f.SetCluster("peer1", cluster)
+	f.SetCredential("peer1", credential)
+	require.Nil(t, f.Save())
+
+	client, err := config.GetClientFromID("peer1")
+	require.Nil(t, err)
+	require.NotNil(t, client)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/clusterlink-net/clusterlink/cmd/gwctl/config"
 	"github.com/clusterlink-net/clusterlink/cmd/gwctl/subcommand"
 	"github.com/clusterlink-net/clusterlink/pkg/versioninfo"
 )
@@ -32,6 +33,9 @@ func main() {
 		Version: versioninfo.Short(),
 	}
 
+	// Select the context used by all commands
+	config.AddFlags(rootCmd.PersistentFlags())
+
 	// Add all commands
 	rootCmd.AddCommand(subcommand.InitCmd()) // init command of Gwctl
 	rootCmd.AddCommand(createCmd())
@@ -39,6 +43,11 @@ func main() {
 	rootCmd.AddCommand(deleteCmd())
 	rootCmd.AddCommand(updateCmd())
 	rootCmd.AddCommand(subcommand.ConfigCmd())
+	rootCmd.AddCommand(subcommand.BackupCmd())
+	rootCmd.AddCommand(subcommand.RestoreCmd())
+	rootCmd.AddCommand(subcommand.ApplyCmd())
+	rootCmd.AddCommand(diagnoseCmd())
+	rootCmd.AddCommand(fabricCmd())
 
 	logrus.SetLevel(logrus.WarnLevel)
 
@@ -107,6 +116,7 @@ func getCmd() *cobra.Command {
 	getCmd.AddCommand(subcommand.StateGetCmd())
 	getCmd.AddCommand(subcommand.PeerGetCmd())
 	getCmd.AddCommand(subcommand.ExportGetCmd())
+	getCmd.AddCommand(subcommand.RemoteExportGetCmd())
 	getCmd.AddCommand(subcommand.ImportGetCmd())
 	getCmd.AddCommand(subcommand.BindingGetCmd())
 	getCmd.AddCommand(subcommand.PolicyGetCmd())
@@ -114,3 +124,27 @@ func getCmd() *cobra.Command {
 	getCmd.AddCommand(subcommand.AllGetCmd())
 	return getCmd
 }
+
+// diagnoseCmd contains all the diagnose commands of the CLI.
+func diagnoseCmd() *cobra.Command {
+	diagnoseCmd := &cobra.Command{
+		Use:   "diagnose",
+		Short: "Diagnose",
+		Long:  `Diagnose`,
+	}
+	// Add all diagnose commands
+	diagnoseCmd.AddCommand(subcommand.ImportDiagnoseCmd())
+	return diagnoseCmd
+}
+
+// fabricCmd contains all the fabric commands of the CLI.
+func fabricCmd() *cobra.Command {
+	fabricCmd := &cobra.Command{
+		Use:   "fabric",
+		Short: "Fabric",
+		Long:  `Fabric-wide commands, across all the configured contexts`,
+	}
+	// Add all fabric commands
+	fabricCmd.AddCommand(subcommand.FabricStatusCmd())
+	return fabricCmd
+}
@@ -0,0 +1,208 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+// Format of printed objects.
+type Format string
+
+const (
+	// Table prints objects as a table of their main attributes.
+	Table Format = "table"
+	// Wide prints objects as a table of all their attributes.
+	Wide Format = "wide"
+	// JSON prints objects as JSON.
+	JSON Format = "json"
+	// YAML prints objects as YAML.
+	YAML Format = "yaml"
+	// JSONPath prints the result of a JSONPath template applied on the JSON representation of objects.
+	JSONPath Format = "jsonpath"
+)
+
+// noneValue is printed in tables for empty values.
+const noneValue = "<none>"
+
+// Column of a table.
+type Column struct {
+	// Header of the column.
+	Header string
+	// Wide columns are only printed in wide tables.
+	Wide bool
+	// Value of the column for an object.
+	Value func(object any) string
+}
+
+// Printer prints objects in a given format.
+type Printer struct {
+	format   Format
+	jsonPath *jsonpath.JSONPath
+	out      io.Writer
+}
+
+// Formats returns a description of the supported formats, for command line help.
+func Formats() string {
+	return "table, wide, json, yaml or jsonpath=<template>"
+}
+
+// New returns a printer of objects in the given format (see Formats), writing to out.
+func New(format string, out io.Writer) (*Printer, error) {
+	p := &Printer{out: out}
+
+	name, template, hasTemplate := strings.Cut(format, "=")
+	switch Format(name) {
+	case Table, Wide, JSON, YAML:
+		if hasTemplate {
+			return nil, fmt.Errorf("output format '%s' does not accept a template", name)
+		}
+	case JSONPath:
+		if template == "" {
+			return nil, fmt.Errorf("output format '%s' requires a template (e.g. 'jsonpath={.Name}')", name)
+		}
+
+		p.jsonPath = jsonpath.New("output").AllowMissingKeys(true)
+		if err := p.jsonPath.Parse(template); err != nil {
+			return nil, fmt.Errorf("invalid jsonpath template: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown output format '%s', expected one of: %s", format, Formats())
+	}
+
+	p.format = Format(name)
+	return p, nil
+}
+
+// IsTable returns true if objects are printed as a table.
+func (p *Printer) IsTable() bool {
+	return p.format == Table || p.format == Wide
+}
+
+// Print prints a list of objects, given as a slice or a pointer to a slice.
+// Table rows are computed by the column values, which are called with pointers to the slice elements.
+func (p *Printer) Print(objects any, columns []Column) error {
+	list := reflect.Indirect(reflect.ValueOf(objects))
+	if !list.IsValid() {
+		// a nil pointer is printed as an empty list
+		list = reflect.ValueOf([]any(nil))
+	}
+	if list.Kind() != reflect.Slice {
+		return fmt.Errorf("cannot print objects of type %T as a list", objects)
+	}
+
+	if !p.IsTable() {
+		if list.IsNil() {
+			// print an empty list rather than null
+			return p.PrintObject([]any{}, nil)
+		}
+		return p.PrintObject(list.Interface(), nil)
+	}
+
+	rows := make([]any, list.Len())
+	for i := range rows {
+		element := list.Index(i)
+		switch {
+		case element.Kind() == reflect.Interface:
+			rows[i] = element.Interface()
+		case element.CanAddr():
+			rows[i] = element.Addr().Interface()
+		default:
+			rows[i] = element.Interface()
+		}
+	}
+
+	return p.printTable(rows, columns)
+}
+
+// PrintObject prints a single object.
+func (p *Printer) PrintObject(object any, columns []Column) error {
+	switch p.format {
+	case Table, Wide:
+		return p.printTable([]any{object}, columns)
+	case JSON:
+		encoded, err := json.MarshalIndent(object, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(p.out, "%s\n", encoded)
+		return err
+	case YAML:
+		encoded, err := yaml.Marshal(object)
+		if err != nil {
+			return err
+		}
+
+		_, err = p.out.Write(encoded)
+		return err
+	default:
+		// templates are applied on the generic JSON representation, so paths match the JSON field names
+		encoded, err := json.Marshal(object)
+		if err != nil {
+			return err
+		}
+
+		var data any
+		if err := json.Unmarshal(encoded, &data); err != nil {
+			return err
+		}
+
+		if err := p.jsonPath.Execute(p.out, data); err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(p.out)
+		return err
+	}
+}
+
+// printTable prints rows of objects as a table.
+func (p *Printer) printTable(objects []any, columns []Column) error {
+	var visible []Column
+	for _, column := range columns {
+		if !column.Wide || p.format == Wide {
+			visible = append(visible, column)
+		}
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 0, 3, ' ', 0)
+
+	headers := make([]string, len(visible))
+	for i, column := range visible {
+		headers[i] = column.Header
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, object := range objects {
+		values := make([]string, len(visible))
+		for i, column := range visible {
+			values[i] = column.Value(object)
+			if values[i] == "" {
+				values[i] = noneValue
+			}
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package printer_test
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/cmd/gwctl/printer"
+)
+
+type service struct {
+	Name  string `json:"name"`
+	Port  int    `json:"port"`
+	Owner string `json:"owner,omitempty"`
+}
+
+var columns = []printer.Column{
+	{Header: "NAME", Value: func(object any) string { return object.(*service).Name }},
+	{Header: "OWNER", Value: func(object any) string { return object.(*service).Owner }},
+	{Header: "PORT", Wide: true, Value: func(object any) string { return strconv.Itoa(object.(*service).Port) }},
+}
+
+func TestPrint(t *testing.T) {
+	services := []service{
+		{Name: "mysql", Port: 3306, Owner: "db-team"},
+		{Name: "redis", Port: 6379},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		objects  any
+		expected string
+	}{
+		{
+			name:    "table",
+			format:  "table",
+			objects: services,
+			expected: "NAME    OWNER\n" +
+				"mysql   db-team\n" +
+				"redis   <none>\n",
+		},
+		{
+			name:    "wide",
+			format:  "wide",
+			objects: &services,
+			expected: "NAME    OWNER     PORT\n" +
+				"mysql   db-team   3306\n" +
+				"redis   <none>    6379\n",
+		},
+		{
+			name:     "empty table",
+			format:   "table",
+			objects:  []service(nil),
+			expected: "NAME   OWNER\n",
+		},
+		{
+			name:    "json",
+			format:  "json",
+			objects: services,
+			expected: `[
+  {
+    "name": "mysql",
+    "port": 3306,
+    "owner": "db-team"
+  },
+  {
+    "name": "redis",
+    "port": 6379
+  }
+]
+`,
+		},
+		{
+			name:     "empty json",
+			format:   "json",
+			objects:  []service(nil),
+			expected: "[]\n",
+		},
+		{
+			name:    "yaml",
+			format:  "yaml",
+			objects: services,
+			expected: "- name: mysql\n" +
+				"  owner: db-team\n" +
+				"  port: 3306\n" +
+				"- name: redis\n" +
+				"  port: 6379\n",
+		},
+		{
+			name:     "empty yaml",
+			format:   "yaml",
+			objects:  (*[]service)(nil),
+			expected: "[]\n",
+		},
+		{
+			name:     "jsonpath",
+			format:   "jsonpath={[*].name}",
+			objects:  services,
+			expected: "mysql redis\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			p, err := printer.New(tt.format, &out)
+			require.Nil(t, err)
+			require.Nil(t, p.Print(tt.objects, columns))
+			require.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func TestPrintObject(t *testing.T) {
+	var out bytes.Buffer
+	p, err := printer.New("jsonpath={.name}:{.port}", &out)
+	require.Nil(t, err)
+	require.Nil(t, p.PrintObject(&service{Name: "mysql", Port: 3306}, columns))
+	require.Equal(t, "mysql:3306\n", out.String())
+
+	out.Reset()
+	p, err = printer.New("table", &out)
+	require.Nil(t, err)
+	require.Nil(t, p.PrintObject(&service{Name: "mysql"}, columns))
+	require.Equal(t, "NAME    OWNER\nmysql   <none>\n", out.String())
+}
+
+func TestNew(t *testing.T) {
+	for _, format := range []string{"xml", "json={.name}", "jsonpath", "jsonpath={.name"} {
+		_, err := printer.New(format, &bytes.Buffer{})
+		require.NotNil(t, err, format)
+	}
+
+	p, err := printer.New("wide", &bytes.Buffer{})
+	require.Nil(t, err)
+	require.True(t, p.IsTable())
+
+	p, err = printer.New("json", &bytes.Buffer{})
+	require.Nil(t, err)
+	require.False(t, p.IsTable())
+
+	// a non-list cannot be printed as a list
+	require.NotNil(t, p.Print(service{}, columns))
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcommand
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/clusterlink-net/clusterlink/cmd/gwctl/config"
+	"github.com/clusterlink-net/clusterlink/pkg/client/apply"
+)
+
+// applyOptions is the command line options for 'apply'.
+type applyOptions struct {
+	myID     string
+	files    []string
+	dryRun   bool
+	prune    bool
+	selector string
+}
+
+// ApplyCmd - declaratively apply a manifest of objects.
+func ApplyCmd() *cobra.Command {
+	o := applyOptions{}
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply a manifest of objects",
+		Long: "Apply a manifest of peers, exports, imports, bindings, access policies and load-balancing policies, " +
+			"given as (multi-document) YAML or JSON files. Missing objects are created, and objects which differ " +
+			"from the manifest are updated",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+
+	o.addFlags(cmd.Flags())
+
+	return cmd
+}
+
+// addFlags registers flags for the CLI.
+func (o *applyOptions) addFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
+	fs.StringSliceVarP(&o.files, "file", "f", nil, "Manifest files, or directories of manifest files (yaml or json)")
+	fs.BoolVar(&o.dryRun, "dry-run", false, "Show the plan without applying it")
+	fs.BoolVar(&o.prune, "prune", false,
+		"Delete objects which are not in the manifest, of the kinds declared in the manifest")
+	fs.StringVarP(&o.selector, "selector", "l", "",
+		"Prune only objects matching this label selector (bindings, which have no labels, are then never pruned)")
+}
+
+// run performs the execution of the 'apply' subcommand.
+func (o *applyOptions) run() error {
+	if len(o.files) == 0 {
+		return fmt.Errorf("manifest file is required")
+	}
+
+	selector, err := labels.Parse(o.selector)
+	if err != nil {
+		return fmt.Errorf("invalid label selector: %w", err)
+	}
+
+	objects, err := apply.ReadManifests(o.files)
+	if err != nil {
+		return err
+	}
+
+	g, err := config.GetClientFromID(o.myID)
+	if err != nil {
+		return err
+	}
+
+	plan, err := apply.Plan(objects, apply.ClientList(g), o.prune, selector)
+	if err != nil {
+		return err
+	}
+
+	if len(plan) == 0 {
+		fmt.Printf("No changes.\n")
+		return nil
+	}
+
+	failed := 0
+	counts := make(map[apply.Action]int)
+	for _, step := range plan {
+		counts[step.Action]++
+		fmt.Printf("%s '%s': %s\n", step.Kind.Name, step.Name, step.Action)
+		if o.dryRun {
+			printDiff(step.Before, step.After)
+			continue
+		}
+
+		if err := step.Apply(g); err != nil {
+			failed++
+			fmt.Printf("%s '%s': %s failed: %v\n", step.Kind.Name, step.Name, step.Action, err)
+		}
+	}
+
+	if o.dryRun {
+		fmt.Printf("Plan: %d to create, %d to update, %d to delete.\n",
+			counts[apply.Create], counts[apply.Update], counts[apply.Delete])
+		return nil
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed applying %d out of %d changes", failed, len(plan))
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcommand
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	"github.com/clusterlink-net/clusterlink/cmd/gwctl/config"
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+)
+
+// backupOptions is the command line options for 'backup'.
+type backupOptions struct {
+	myID   string
+	output string
+	file   string
+}
+
+// BackupCmd - backup the controlplane configuration.
+func BackupCmd() *cobra.Command {
+	o := backupOptions{}
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Backup the peer configuration",
+		Long: "Backup all peers, exports, imports, bindings and policies of the peer " +
+			"into a single versioned document",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+
+	o.addFlags(cmd.Flags())
+
+	return cmd
+}
+
+// addFlags registers flags for the CLI.
+func (o *backupOptions) addFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
+	fs.StringVarP(&o.output, "output", "o", "yaml", "Output format: yaml or json")
+	fs.StringVarP(&o.file, "file", "f", "", "File to write the backup to (defaults to stdout)")
+}
+
+// run performs the execution of the 'backup' subcommand.
+func (o *backupOptions) run() error {
+	if o.output != "yaml" && o.output != "json" {
+		return fmt.Errorf("unknown output format '%s'", o.output)
+	}
+
+	g, err := config.GetClientFromID(o.myID)
+	if err != nil {
+		return err
+	}
+
+	backup, err := g.Backup()
+	if err != nil {
+		return err
+	}
+
+	var encoded []byte
+	if o.output == "json" {
+		encoded, err = json.MarshalIndent(backup, "", "  ")
+		encoded = append(encoded, '\n')
+	} else {
+		encoded, err = yaml.Marshal(backup)
+	}
+	if err != nil {
+		return err
+	}
+
+	if o.file == "" {
+		_, err = os.Stdout.Write(encoded)
+		return err
+	}
+
+	return os.WriteFile(o.file, encoded, 0o600)
+}
+
+// restoreOptions is the command line options for 'restore'.
+type restoreOptions struct {
+	myID   string
+	file   string
+	dryRun bool
+}
+
+// RestoreCmd - restore the controlplane configuration.
+func RestoreCmd() *cobra.Command {
+	o := restoreOptions{}
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the peer configuration from a backup",
+		Long: "Restore the peer configuration from a backup. Missing objects are created, " +
+			"and objects which differ from the backup are updated",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+
+	o.addFlags(cmd.Flags())
+
+	return cmd
+}
+
+// addFlags registers flags for the CLI.
+func (o *restoreOptions) addFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
+	fs.StringVarP(&o.file, "file", "f", "", "Backup file (yaml or json)")
+	fs.BoolVar(&o.dryRun, "dry-run", false, "Show the changes without applying them")
+}
+
+// run performs the execution of the 'restore' subcommand.
+func (o *restoreOptions) run() error {
+	if o.file == "" {
+		return fmt.Errorf("backup file is required")
+	}
+
+	data, err := os.ReadFile(o.file)
+	if err != nil {
+		return fmt.Errorf("error reading backup file: %w", err)
+	}
+
+	// YAML is a superset of JSON, so this handles both formats
+	var backup api.Backup
+	if err := yaml.Unmarshal(data, &backup); err != nil {
+		return fmt.Errorf("error parsing backup file: %w", err)
+	}
+
+	g, err := config.GetClientFromID(o.myID)
+	if err != nil {
+		return err
+	}
+
+	result, err := g.Restore(&backup, o.dryRun)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for i := range result.Changes {
+		change := &result.Changes[i]
+		if change.Error != "" {
+			failed++
+			fmt.Printf("%s '%s': %s failed: %s\n", change.Kind, change.Name, change.Action, change.Error)
+			continue
+		}
+
+		if change.Action == api.RestoreNone {
+			continue
+		}
+
+		fmt.Printf("%s '%s': %s\n", change.Kind, change.Name, change.Action)
+		if o.dryRun {
+			printDiff(change.Before, change.After)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed restoring %d out of %d objects", failed, len(result.Changes))
+	}
+
+	return nil
+}
+
+// printDiff prints the line differences between the YAML representations of two objects.
+func printDiff(before, after any) {
+	beforeLines := yamlLines(before)
+	afterLines := yamlLines(after)
+
+	// lcs[i][j] is the length of the longest common subsequence of beforeLines[i:] and afterLines[j:]
+	lcs := make([][]int, len(beforeLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(afterLines)+1)
+	}
+	for i := len(beforeLines) - 1; i >= 0; i-- {
+		for j := len(afterLines) - 1; j >= 0; j-- {
+			switch {
+			case beforeLines[i] == afterLines[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(beforeLines) || j < len(afterLines) {
+		switch {
+		case i < len(beforeLines) && j < len(afterLines) && beforeLines[i] == afterLines[j]:
+			fmt.Printf("    %s\n", beforeLines[i])
+			i++
+			j++
+		case j < len(afterLines) && (i == len(beforeLines) || lcs[i][j+1] >= lcs[i+1][j]):
+			fmt.Printf("  + %s\n", afterLines[j])
+			j++
+		default:
+			fmt.Printf("  - %s\n", beforeLines[i])
+			i++
+		}
+	}
+}
+
+// yamlLines returns the lines of the YAML representation of an object.
+func yamlLines(object any) []string {
+	if object == nil {
+		return nil
+	}
+
+	encoded, err := yaml.Marshal(object)
+	if err != nil {
+		return []string{fmt.Sprintf("%v", object)}
+	}
+
+	return strings.Split(strings.TrimSuffix(string(encoded), "\n"), "\n")
+}
@@ -132,8 +132,10 @@ func (o *bindingDeleteOptions) run() error {
 
 // bindingGetOptions is the command line options for 'delete binding'.
 type bindingGetOptions struct {
+	outputOptions
 	myID     string
 	importID string
+	watch    bool
 }
 
 // BindingGetCmd - get a binding of imported service command.
@@ -158,6 +160,8 @@ func BindingGetCmd() *cobra.Command {
 func (o *bindingGetOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
 	fs.StringVar(&o.importID, "import", "", "Imported service name to bind")
+	fs.BoolVar(&o.watch, "watch", false, "Watch for changes to the bindings of the imported service")
+	o.outputOptions.addFlags(fs)
 }
 
 // run performs the execution of the 'get binding' subcommand.
@@ -167,14 +171,25 @@ func (o *bindingGetOptions) run() error {
 		return err
 	}
 
-	bArr, err := g.Bindings.Get(o.importID)
+	if o.watch {
+		return watchObjects(g.Bindings, "binding", func(object any) string {
+			b := object.(*api.Binding)
+			if b.Spec.Import != o.importID {
+				return ""
+			}
+			return fmt.Sprintf("%s -> %s", b.Spec.Import, b.Spec.Peer)
+		})
+	}
+
+	p, err := o.printer()
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Binding of the imported service %s:\n", o.importID)
-	for i, b := range *bArr.(*[]api.Binding) {
-		fmt.Printf("%d. Peer : %s \n", i+1, b.Spec.Peer)
+	bArr, err := g.Bindings.Get(o.importID)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	return p.Print(bArr, bindingColumns)
 }
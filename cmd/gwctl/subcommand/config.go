@@ -14,29 +14,37 @@
 package subcommand
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	"github.com/clusterlink-net/clusterlink/cmd/gwctl/config"
+	"github.com/clusterlink-net/clusterlink/cmd/gwctl/printer"
+	cmdutil "github.com/clusterlink-net/clusterlink/cmd/util"
 )
 
 // ConfigCmd contains all the config commands of the CLI.
 func ConfigCmd() *cobra.Command {
 	configCmd := &cobra.Command{
 		Use:   "config",
-		Short: "config",
-		Long:  `config`,
+		Short: "Manage the gwctl config file",
+		Long: fmt.Sprintf("Manage the gwctl config file of clusters, credentials and contexts. "+
+			"The file is set by the %s environment variable, and defaults to ~/.gw/config", config.ConfigEnv),
 	}
 
 	configCmd.AddCommand(currentContextCmd())
 	configCmd.AddCommand(useContextCmd())
+	configCmd.AddCommand(getContextsCmd())
+	configCmd.AddCommand(setClusterCmd())
+	configCmd.AddCommand(setCredentialsCmd())
+	configCmd.AddCommand(setContextCmd())
+	configCmd.AddCommand(deleteContextCmd())
 	return configCmd
 }
 
-// getContextCmd is the command line options for 'config current-context'.
+// currentContextOptions is the command line options for 'config current-context'.
 type currentContextOptions struct{}
 
 // currentContextCmd - get the last gwctl context command to use.
@@ -56,19 +64,20 @@ func currentContextCmd() *cobra.Command {
 
 // run performs the execution of the 'config current-context' subcommand.
 func (o *currentContextOptions) run() error {
-	s, err := config.GetConfigFromID("")
+	f, err := config.Load()
 	if err != nil {
 		return err
 	}
 
-	sJSON, err := json.MarshalIndent(s, "", " ")
-	if err != nil {
-		fmt.Println("gwctl current state\n", string(sJSON))
+	if f.CurrentContext == "" {
+		return fmt.Errorf("current context is not set")
 	}
-	return err
+
+	fmt.Println(f.CurrentContext)
+	return nil
 }
 
-// useContext is the command line options for 'config use-context'.
+// useContextOptions is the command line options for 'config use-context'.
 type useContextOptions struct {
 	myID string
 }
@@ -86,27 +95,315 @@ func useContextCmd() *cobra.Command {
 	}
 
 	o.addFlags(cmd.Flags())
+	cmdutil.MarkFlagsRequired(cmd, []string{"myid"})
 
 	return cmd
 }
 
 // addFlags registers flags for the CLI.
 func (o *useContextOptions) addFlags(fs *pflag.FlagSet) {
-	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
+	fs.StringVar(&o.myID, "myid", "", "Name of the context to use")
 }
 
-// run performs the execution of the 'config current-context' subcommand.
+// run performs the execution of the 'config use-context' subcommand.
 func (o *useContextOptions) run() error {
-	c, err := config.GetConfigFromID(o.myID)
+	f, err := config.Load()
 	if err != nil {
 		return err
 	}
 
-	err = c.SetDefaultClient(o.myID)
-	if err != nil {
+	if err := f.UseContext(o.myID); err != nil {
+		return err
+	}
+
+	if err := f.Save(); err != nil {
 		return err
 	}
 
 	fmt.Println("gwctl use context ", o.myID)
 	return nil
 }
+
+// getContextsOptions is the command line options for 'config get-contexts'.
+type getContextsOptions struct {
+	outputOptions
+}
+
+// getContextsCmd - list the gwctl contexts.
+func getContextsCmd() *cobra.Command {
+	o := getContextsOptions{}
+	cmd := &cobra.Command{
+		Use:   "get-contexts",
+		Short: "List gwctl contexts.",
+		Long:  `List gwctl contexts.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+
+	o.outputOptions.addFlags(cmd.Flags())
+
+	return cmd
+}
+
+// run performs the execution of the 'config get-contexts' subcommand.
+func (o *getContextsOptions) run() error {
+	p, err := o.printer()
+	if err != nil {
+		return err
+	}
+
+	f, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	return p.Print(f.Contexts, []printer.Column{
+		{Header: "CURRENT", Value: func(object any) string {
+			if object.(*config.NamedContext).Name == f.CurrentContext {
+				return "*"
+			}
+			return " "
+		}},
+		{Header: "NAME", Value: func(object any) string { return object.(*config.NamedContext).Name }},
+		{Header: "CLUSTER", Value: func(object any) string { return object.(*config.NamedContext).Context.Cluster }},
+		{Header: "CREDENTIAL", Value: func(object any) string { return object.(*config.NamedContext).Context.Credential }},
+		{Header: "SERVER", Wide: true, Value: func(object any) string {
+			if cluster := f.Cluster(object.(*config.NamedContext).Context.Cluster); cluster != nil {
+				return cluster.Server
+			}
+			return ""
+		}},
+	})
+}
+
+// readEmbedded returns the content of a file to embed in the config file, or nil if the file is not given.
+func readEmbedded(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read '%s': %w", path, err)
+	}
+	return data, nil
+}
+
+// setClusterOptions is the command line options for 'config set-cluster'.
+type setClusterOptions struct {
+	name       string
+	server     string
+	serverName string
+	certCa     string
+	embedCerts bool
+	dataplane  string
+}
+
+// setClusterCmd - add or replace a cluster in the gwctl config file.
+func setClusterCmd() *cobra.Command {
+	o := setClusterOptions{}
+	cmd := &cobra.Command{
+		Use:   "set-cluster",
+		Short: "Set a cluster in the gwctl config file.",
+		Long:  `Set a cluster (controlplane endpoint and CA) in the gwctl config file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+
+	o.addFlags(cmd.Flags())
+	cmdutil.MarkFlagsRequired(cmd, []string{"name", "server", "certca"})
+
+	return cmd
+}
+
+// addFlags registers flags for the CLI.
+func (o *setClusterOptions) addFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.name, "name", "", "Cluster name")
+	fs.StringVar(&o.server, "server", "", "Controlplane address (host:port)")
+	fs.StringVar(&o.serverName, "server-name", "",
+		"Name for verifying the controlplane certificate. If empty, the name of the context is used")
+	fs.StringVar(&o.certCa, "certca", "", "Path to the Root Certificate Auth File (.pem)")
+	fs.BoolVar(&o.embedCerts, "embed-certs", false, "Embed the CA in the config file, rather than referring to it")
+	fs.StringVar(&o.dataplane, "dataplane", "mtls", "tcp/mtls based dataplane proxies")
+}
+
+// run performs the execution of the 'config set-cluster' subcommand.
+func (o *setClusterOptions) run() error {
+	f, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	cluster := &config.Cluster{
+		Server:               o.server,
+		ServerName:           o.serverName,
+		CertificateAuthority: o.certCa,
+		Dataplane:            o.dataplane,
+	}
+	if o.embedCerts {
+		cluster.CertificateAuthority = ""
+		cluster.CertificateAuthorityData, err = readEmbedded(o.certCa)
+		if err != nil {
+			return err
+		}
+	}
+
+	f.SetCluster(o.name, cluster)
+	return f.Save()
+}
+
+// setCredentialsOptions is the command line options for 'config set-credentials'.
+type setCredentialsOptions struct {
+	name       string
+	cert       string
+	key        string
+	embedCerts bool
+}
+
+// setCredentialsCmd - add or replace a credential in the gwctl config file.
+func setCredentialsCmd() *cobra.Command {
+	o := setCredentialsOptions{}
+	cmd := &cobra.Command{
+		Use:   "set-credentials",
+		Short: "Set a credential in the gwctl config file.",
+		Long:  `Set a credential (client certificate and key) in the gwctl config file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+
+	o.addFlags(cmd.Flags())
+	cmdutil.MarkFlagsRequired(cmd, []string{"name", "cert", "key"})
+
+	return cmd
+}
+
+// addFlags registers flags for the CLI.
+func (o *setCredentialsOptions) addFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.name, "name", "", "Credential name")
+	fs.StringVar(&o.cert, "cert", "", "Path to the Certificate File (.pem)")
+	fs.StringVar(&o.key, "key", "", "Path to the Key File (.pem)")
+	fs.BoolVar(&o.embedCerts, "embed-certs", false,
+		"Embed the certificate and key in the config file, rather than referring to them")
+}
+
+// run performs the execution of the 'config set-credentials' subcommand.
+func (o *setCredentialsOptions) run() error {
+	f, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	credential := &config.Credential{
+		ClientCertificate: o.cert,
+		ClientKey:         o.key,
+	}
+	if o.embedCerts {
+		credential.ClientCertificate = ""
+		credential.ClientKey = ""
+		if credential.ClientCertificateData, err = readEmbedded(o.cert); err != nil {
+			return err
+		}
+		if credential.ClientKeyData, err = readEmbedded(o.key); err != nil {
+			return err
+		}
+	}
+
+	f.SetCredential(o.name, credential)
+	return f.Save()
+}
+
+// setContextOptions is the command line options for 'config set-context'.
+type setContextOptions struct {
+	name       string
+	cluster    string
+	credential string
+	use        bool
+}
+
+// setContextCmd - add or replace a context in the gwctl config file.
+func setContextCmd() *cobra.Command {
+	o := setContextOptions{}
+	cmd := &cobra.Command{
+		Use:   "set-context",
+		Short: "Set a context in the gwctl config file.",
+		Long:  `Set a context (cluster and the credential for connecting to it) in the gwctl config file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+
+	o.addFlags(cmd.Flags())
+	cmdutil.MarkFlagsRequired(cmd, []string{"name", "cluster", "credential"})
+
+	return cmd
+}
+
+// addFlags registers flags for the CLI.
+func (o *setContextOptions) addFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.name, "name", "", "Context name")
+	fs.StringVar(&o.cluster, "cluster", "", "Cluster name")
+	fs.StringVar(&o.credential, "credential", "", "Credential name")
+	fs.BoolVar(&o.use, "use", false, "Set the context as the current context")
+}
+
+// run performs the execution of the 'config set-context' subcommand.
+func (o *setContextOptions) run() error {
+	f, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	if f.Cluster(o.cluster) == nil {
+		return fmt.Errorf("cluster '%s' does not exist", o.cluster)
+	}
+	if f.Credential(o.credential) == nil {
+		return fmt.Errorf("credential '%s' does not exist", o.credential)
+	}
+
+	f.SetContext(o.name, &config.Context{Cluster: o.cluster, Credential: o.credential})
+	if o.use {
+		f.CurrentContext = o.name
+	}
+
+	return f.Save()
+}
+
+// deleteContextOptions is the command line options for 'config delete-context'.
+type deleteContextOptions struct {
+	name string
+}
+
+// deleteContextCmd - delete a context from the gwctl config file.
+func deleteContextCmd() *cobra.Command {
+	o := deleteContextOptions{}
+	cmd := &cobra.Command{
+		Use:   "delete-context",
+		Short: "Delete a context from the gwctl config file.",
+		Long:  `Delete a context from the gwctl config file. Its cluster and credential are kept.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringVar(&o.name, "name", "", "Context name")
+	cmdutil.MarkFlagsRequired(cmd, []string{"name"})
+
+	return cmd
+}
+
+// run performs the execution of the 'config delete-context' subcommand.
+func (o *deleteContextOptions) run() error {
+	f, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	if err := f.DeleteContext(o.name); err != nil {
+		return err
+	}
+
+	return f.Save()
+}
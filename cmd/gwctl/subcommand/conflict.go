@@ -0,0 +1,46 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcommand
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/clusterlink-net/clusterlink/pkg/util/rest"
+)
+
+// addResourceVersionFlag registers the flag for conditioning an update or delete on the resource version of an object.
+func addResourceVersionFlag(fs *pflag.FlagSet, version *uint64) {
+	fs.Uint64Var(version, "resource-version", 0,
+		"Fail if the object was modified since this resource version (as shown by 'get'). If 0, overwrites any changes")
+}
+
+// conflictError adds retry instructions to an error of an update or delete which conflicted with another change.
+func conflictError(err error, client *rest.Client, kind, name string) error {
+	var conflictErr *rest.ConflictError
+	if !errors.As(err, &conflictErr) {
+		return err
+	}
+
+	_, version, getErr := client.GetWithVersion(name)
+	if getErr != nil {
+		return fmt.Errorf("%s '%s' was modified since resource version %d; "+
+			"review the changes, and retry with its current resource version", kind, name, conflictErr.ResourceVersion)
+	}
+
+	return fmt.Errorf("%s '%s' was modified since resource version %d (current resource version is %d); "+
+		"review the changes, and retry with '--resource-version %d'", kind, name, conflictErr.ResourceVersion, version, version)
+}
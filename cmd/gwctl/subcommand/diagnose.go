@@ -0,0 +1,100 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcommand
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/clusterlink-net/clusterlink/cmd/gwctl/config"
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+)
+
+// checkLabels are the printed labels of check statuses.
+var checkLabels = map[api.CheckStatus]string{
+	api.CheckPassed:  "PASS",
+	api.CheckFailed:  "FAIL",
+	api.CheckSkipped: "SKIP",
+}
+
+// importDiagnoseOptions is the command line options for 'diagnose import'.
+type importDiagnoseOptions struct {
+	outputOptions
+	myID string
+	name string
+}
+
+// ImportDiagnoseCmd - diagnose an imported service command.
+func ImportDiagnoseCmd() *cobra.Command {
+	o := importDiagnoseOptions{}
+	cmd := &cobra.Command{
+		Use:   "import <name>",
+		Short: "Diagnose an imported service",
+		Long: "Diagnose an imported service, by checking its listener, k8s service, bindings, bound peers, " +
+			"and the access policies of both the local and the remote peers",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o.name = args[0]
+			return o.run()
+		},
+	}
+
+	o.addFlags(cmd.Flags())
+
+	return cmd
+}
+
+// addFlags registers flags for the CLI.
+func (o *importDiagnoseOptions) addFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
+	o.outputOptions.addFlags(fs)
+}
+
+// run performs the execution of the 'diagnose import' subcommand.
+func (o *importDiagnoseOptions) run() error {
+	p, err := o.printer()
+	if err != nil {
+		return err
+	}
+
+	g, err := config.GetClientFromID(o.myID)
+	if err != nil {
+		return err
+	}
+
+	diagnosis, err := g.DiagnoseImport(o.name)
+	if err != nil {
+		return err
+	}
+
+	if p.IsTable() {
+		fmt.Printf("Diagnosis of import '%s':\n", diagnosis.Import)
+		for _, check := range diagnosis.Checks {
+			fmt.Printf("[%s] %s: %s\n", checkLabels[check.Status], check.Name, check.Message)
+			if check.Hint != "" {
+				fmt.Printf("       Hint: %s\n", check.Hint)
+			}
+		}
+	} else if err := p.PrintObject(diagnosis, nil); err != nil {
+		return err
+	}
+
+	if failed := diagnosis.Failed(); failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(diagnosis.Checks))
+	}
+
+	return nil
+}
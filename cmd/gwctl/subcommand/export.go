@@ -26,11 +26,13 @@ import (
 
 // exportCreateOptions is the command line options for 'create export' or 'update export'.
 type exportCreateOptions struct {
-	myID     string
-	name     string
-	host     string
-	port     uint16
-	external string
+	myID            string
+	name            string
+	host            string
+	port            uint16
+	external        string
+	labels          map[string]string
+	resourceVersion uint64
 }
 
 // ExportCreateCmd - Create an exported service.
@@ -63,6 +65,7 @@ func ExportUpdateCmd() *cobra.Command {
 	}
 
 	o.addFlags(cmd.Flags())
+	addResourceVersionFlag(cmd.Flags(), &o.resourceVersion)
 	cmdutil.MarkFlagsRequired(cmd, []string{"name", "port"})
 	return cmd
 }
@@ -75,6 +78,7 @@ func (o *exportCreateOptions) addFlags(fs *pflag.FlagSet) {
 	fs.Uint16Var(&o.port, "port", 0, "Exported service port")
 	fs.StringVar(&o.external, "external", "",
 		"External endpoint <host>:<port, which the exported service will be connected")
+	fs.StringToStringVar(&o.labels, "labels", nil, "Exported service labels (e.g. 'env=prod,tier=db')")
 }
 
 // run performs the execution of the 'create export' or 'update export' subcommand.
@@ -85,11 +89,14 @@ func (o *exportCreateOptions) run(isUpdate bool) error {
 	}
 	exportOperation := g.Exports.Create
 	if isUpdate {
-		exportOperation = g.Exports.Update
+		exportOperation = func(object any) error {
+			return conflictError(g.Exports.UpdateIfMatch(object, o.resourceVersion), g.Exports, "export", o.name)
+		}
 	}
 
 	err = exportOperation(&api.Export{
-		Name: o.name,
+		Name:   o.name,
+		Labels: o.labels,
 		Spec: api.ExportSpec{
 			Service: api.Endpoint{
 				Host: o.host,
@@ -107,8 +114,9 @@ func (o *exportCreateOptions) run(isUpdate bool) error {
 
 // exportDeleteOptions is the command line options for 'delete export'.
 type exportDeleteOptions struct {
-	myID string
-	name string
+	myID            string
+	name            string
+	resourceVersion uint64
 }
 
 // ExportDeleteCmd - delete an exported service command.
@@ -133,6 +141,7 @@ func ExportDeleteCmd() *cobra.Command {
 func (o *exportDeleteOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
 	fs.StringVar(&o.name, "name", "", "Exported service name")
+	addResourceVersionFlag(fs, &o.resourceVersion)
 }
 
 // run performs the execution of the 'delete export' subcommand.
@@ -142,9 +151,9 @@ func (o *exportDeleteOptions) run() error {
 		return err
 	}
 
-	err = g.Exports.Delete(o.name)
+	err = g.Exports.DeleteIfMatch(o.name, o.resourceVersion)
 	if err != nil {
-		return err
+		return conflictError(err, g.Exports, "export", o.name)
 	}
 
 	return nil
@@ -152,8 +161,11 @@ func (o *exportDeleteOptions) run() error {
 
 // exportGetOptions is the command line options for 'get export'.
 type exportGetOptions struct {
-	myID string
-	name string
+	listOptions
+	outputOptions
+	myID  string
+	name  string
+	watch bool
 }
 
 // ExportGetCmd - get an exported service command.
@@ -177,6 +189,9 @@ func ExportGetCmd() *cobra.Command {
 func (o *exportGetOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
 	fs.StringVar(&o.name, "name", "", "Exported service name. If empty gets all exported services.")
+	fs.BoolVar(&o.watch, "watch", false, "Watch for changes to exported services")
+	o.listOptions.addFlags(fs)
+	o.outputOptions.addFlags(fs)
 }
 
 // run performs the execution of the 'get export' subcommand.
@@ -186,21 +201,39 @@ func (o *exportGetOptions) run() error {
 		return err
 	}
 
+	if o.watch {
+		return watchObjects(exportClient.Exports, "export", func(object any) string {
+			s := object.(*api.Export)
+			if o.name != "" && s.Name != o.name {
+				return ""
+			}
+			return fmt.Sprintf("%s %v", s.Name, s.Spec.Service)
+		})
+	}
+
+	p, err := o.printer()
+	if err != nil {
+		return err
+	}
+
 	if o.name == "" {
-		sArr, err := exportClient.Exports.List()
+		sArr, next, err := o.list(exportClient.Exports)
 		if err != nil {
 			return err
 		}
-		fmt.Printf("Exported services:\n")
-		for i, s := range *sArr.(*[]api.Export) {
-			fmt.Printf("%d. Service Name: %s. Endpoint: %v\n", i+1, s.Name, s.Spec.Service)
+		if err := p.Print(sArr, exportColumns); err != nil {
+			return err
 		}
+		printContinue(next)
 	} else {
-		s, err := exportClient.Exports.Get(o.name)
+		s, version, err := exportClient.Exports.GetWithVersion(o.name)
 		if err != nil {
 			return err
 		}
-		fmt.Printf("Exported service :%+v\n", s.(*api.Export))
+		if err := p.PrintObject(s, exportColumns); err != nil {
+			return err
+		}
+		printVersion(p, version)
 	}
 
 	return nil
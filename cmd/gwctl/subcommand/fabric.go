@@ -0,0 +1,435 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcommand
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/clusterlink-net/clusterlink/cmd/gwctl/config"
+	"github.com/clusterlink-net/clusterlink/cmd/gwctl/printer"
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+)
+
+const (
+	// dotFormat is the output format for rendering the fabric as a Graphviz DOT graph.
+	dotFormat = "dot"
+
+	// mutualYes, mutualNo and mutualUnknown indicate whether the target of a link knows its source.
+	mutualYes     = "yes"
+	mutualNo      = "no"
+	mutualUnknown = "unknown"
+	// stateUnknown is the state of a link whose state is not reported by its source.
+	stateUnknown = "Unknown"
+)
+
+// fabricSite is the configuration and status of a single peer, fetched using a gwctl context.
+type fabricSite struct {
+	// Context used for fetching the site.
+	Context string
+	// Peer is the name of the site peer.
+	Peer string
+	// Error fetching the site, if any.
+	Error string `json:",omitempty"`
+
+	peers    []api.Peer
+	exports  []api.Export
+	imports  []api.Import
+	bindings []api.Binding
+}
+
+// knows returns true if the site has a peer with the given name.
+func (s *fabricSite) knows(name string) bool {
+	for i := range s.peers {
+		if s.peers[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// exportsService returns true if the site has an export with the given name.
+func (s *fabricSite) exportsService(name string) bool {
+	for i := range s.exports {
+		if s.exports[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fabricLink is a peer configured on a site.
+type fabricLink struct {
+	// From is the site peer.
+	From string
+	// To is the configured peer.
+	To string
+	// State of the configured peer, as observed by the site.
+	State string
+	// LastSeen is the last time the state of the configured peer was updated.
+	LastSeen string
+	// Mutual indicates whether the configured peer knows the site peer (yes, no or unknown).
+	Mutual string
+}
+
+// fabricService is an exported service, along with the peers importing it.
+type fabricService struct {
+	// Name of the service.
+	Name string
+	// Peer exporting the service.
+	Peer string
+	// ImportedBy are the peers binding an import to the exported service.
+	ImportedBy []string
+}
+
+// fabricTopology is the topology of the fabric, as seen by all the sites.
+type fabricTopology struct {
+	Sites    []*fabricSite
+	Links    []fabricLink
+	Services []fabricService
+	// Issues are asymmetric or inconsistent configurations found across sites.
+	Issues []string
+}
+
+// fabricStatusOptions is the command line options for 'fabric status'.
+type fabricStatusOptions struct {
+	contexts []string
+	output   string
+}
+
+// FabricStatusCmd - show the status of all the peers of the fabric.
+func FabricStatusCmd() *cobra.Command {
+	o := fabricStatusOptions{}
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the fabric topology",
+		Long: "Show the fabric topology, built from all the configured gwctl contexts: the reachability of peers, " +
+			"the exported services and their importers, and asymmetric configurations across peers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+
+	o.addFlags(cmd.Flags())
+
+	return cmd
+}
+
+// addFlags registers flags for the CLI.
+func (o *fabricStatusOptions) addFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&o.contexts, "contexts", nil, "Contexts of the fabric peers. If empty, uses all contexts")
+	fs.StringVarP(&o.output, "output", "o", string(printer.Table), "Output format: dot, "+printer.Formats())
+}
+
+// run performs the execution of the 'fabric status' subcommand.
+func (o *fabricStatusOptions) run() error {
+	var p *printer.Printer
+	if o.output != dotFormat {
+		var err error
+		if p, err = printer.New(o.output, os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	contexts := o.contexts
+	if len(contexts) == 0 {
+		f, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		for _, context := range f.Contexts {
+			contexts = append(contexts, context.Name)
+		}
+	}
+	if len(contexts) == 0 {
+		return fmt.Errorf("no contexts are configured")
+	}
+
+	topology := buildTopology(fetchSites(contexts))
+
+	switch {
+	case p == nil:
+		printDOT(topology)
+		return nil
+	case !p.IsTable():
+		return p.PrintObject(topology, nil)
+	}
+
+	return printFabricTables(p, topology)
+}
+
+// fetchSites fetches the configuration and status of the sites of the given contexts, in parallel.
+func fetchSites(contexts []string) []*fabricSite {
+	sites := make([]*fabricSite, len(contexts))
+
+	var wg sync.WaitGroup
+	for i, context := range contexts {
+		wg.Add(1)
+		go func(i int, context string) {
+			defer wg.Done()
+
+			sites[i] = &fabricSite{Context: context}
+			if err := sites[i].fetch(); err != nil {
+				sites[i].Error = err.Error()
+			}
+		}(i, context)
+	}
+	wg.Wait()
+
+	return sites
+}
+
+// fetch fetches the configuration and status of a site.
+func (s *fabricSite) fetch() error {
+	g, err := config.GetClientFromID(s.Context)
+	if err != nil {
+		return err
+	}
+
+	status, err := g.Status()
+	if err != nil {
+		return err
+	}
+	s.Peer = status.Peer
+
+	peers, err := g.Peers.List()
+	if err != nil {
+		return err
+	}
+	s.peers = *peers.(*[]api.Peer)
+
+	exports, err := g.Exports.List()
+	if err != nil {
+		return err
+	}
+	s.exports = *exports.(*[]api.Export)
+
+	imports, err := g.Imports.List()
+	if err != nil {
+		return err
+	}
+	s.imports = *imports.(*[]api.Import)
+
+	bindings, err := g.Bindings.List()
+	if err != nil {
+		return err
+	}
+	s.bindings = *bindings.(*[]api.Binding)
+
+	return nil
+}
+
+// buildTopology builds the fabric topology from the fetched sites, finding asymmetric configurations.
+func buildTopology(sites []*fabricSite) *fabricTopology {
+	topology := &fabricTopology{Sites: sites}
+
+	byPeer := make(map[string]*fabricSite)
+	for _, site := range sites {
+		if site.Error != "" {
+			topology.Issues = append(topology.Issues,
+				fmt.Sprintf("context '%s' is unavailable: %s", site.Context, site.Error))
+			continue
+		}
+		byPeer[site.Peer] = site
+	}
+
+	names := make([]string, 0, len(byPeer))
+	for name := range byPeer {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		site := byPeer[name]
+
+		for _, pr := range site.peers {
+			link := fabricLink{From: site.Peer, To: pr.Name, State: stateUnknown, Mutual: mutualUnknown}
+			if pr.Status.State != "" {
+				link.State = pr.Status.State
+				link.LastSeen = pr.Status.LastSeen
+			}
+
+			if target, ok := byPeer[pr.Name]; ok {
+				link.Mutual = mutualNo
+				if target.knows(site.Peer) {
+					link.Mutual = mutualYes
+				} else {
+					topology.Issues = append(topology.Issues,
+						fmt.Sprintf("peer '%s' knows peer '%s', but not vice versa", site.Peer, pr.Name))
+				}
+			}
+
+			topology.Links = append(topology.Links, link)
+		}
+
+		for _, export := range site.exports {
+			service := fabricService{Name: export.Name, Peer: site.Peer}
+			for _, importer := range names {
+				for _, binding := range byPeer[importer].bindings {
+					if binding.Spec.Import == export.Name && binding.Spec.Peer == site.Peer {
+						service.ImportedBy = append(service.ImportedBy, importer)
+						break
+					}
+				}
+			}
+
+			topology.Services = append(topology.Services, service)
+		}
+
+		bound := make(map[string]bool)
+		for _, binding := range site.bindings {
+			bound[binding.Spec.Import] = true
+
+			if !site.knows(binding.Spec.Peer) {
+				topology.Issues = append(topology.Issues,
+					fmt.Sprintf("import '%s' on peer '%s' is bound to peer '%s', which is not configured on '%s'",
+						binding.Spec.Import, site.Peer, binding.Spec.Peer, site.Peer))
+			}
+
+			if target, ok := byPeer[binding.Spec.Peer]; ok && !target.exportsService(binding.Spec.Import) {
+				topology.Issues = append(topology.Issues,
+					fmt.Sprintf("import '%s' on peer '%s' is bound to peer '%s', which has no matching export",
+						binding.Spec.Import, site.Peer, binding.Spec.Peer))
+			}
+		}
+
+		for _, imp := range site.imports {
+			if !bound[imp.Name] {
+				topology.Issues = append(topology.Issues,
+					fmt.Sprintf("import '%s' on peer '%s' is not bound to any peer", imp.Name, site.Peer))
+			}
+		}
+	}
+
+	return topology
+}
+
+// printFabricTables prints the fabric topology as tables.
+func printFabricTables(p *printer.Printer, topology *fabricTopology) error {
+	sections := []struct {
+		title   string
+		objects any
+		columns []printer.Column
+	}{
+		{"Sites", topology.Sites, []printer.Column{
+			{Header: "CONTEXT", Value: func(object any) string { return (*object.(**fabricSite)).Context }},
+			{Header: "PEER", Value: func(object any) string { return (*object.(**fabricSite)).Peer }},
+			{Header: "ERROR", Wide: true, Value: func(object any) string { return (*object.(**fabricSite)).Error }},
+		}},
+		{"Links", topology.Links, []printer.Column{
+			{Header: "FROM", Value: func(object any) string { return object.(*fabricLink).From }},
+			{Header: "TO", Value: func(object any) string { return object.(*fabricLink).To }},
+			{Header: "STATE", Value: func(object any) string { return object.(*fabricLink).State }},
+			{Header: "MUTUAL", Value: func(object any) string { return object.(*fabricLink).Mutual }},
+			{Header: "LAST SEEN", Wide: true, Value: func(object any) string { return object.(*fabricLink).LastSeen }},
+		}},
+		{"Services", topology.Services, []printer.Column{
+			{Header: "SERVICE", Value: func(object any) string { return object.(*fabricService).Name }},
+			{Header: "EXPORTED BY", Value: func(object any) string { return object.(*fabricService).Peer }},
+			{Header: "IMPORTED BY", Value: func(object any) string {
+				return strings.Join(object.(*fabricService).ImportedBy, ",")
+			}},
+		}},
+	}
+
+	for _, section := range sections {
+		fmt.Printf("%s:\n", section.title)
+		if err := p.Print(section.objects, section.columns); err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+
+	if len(topology.Issues) == 0 {
+		fmt.Println("No issues found.")
+		return nil
+	}
+
+	fmt.Println("Issues:")
+	for _, issue := range topology.Issues {
+		fmt.Printf("- %s\n", issue)
+	}
+
+	return nil
+}
+
+// linkColors are the DOT colors of links, by their state.
+var linkColors = map[string]string{
+	api.PeerStateReachable:   "darkgreen",
+	api.PeerStateUnreachable: "red",
+}
+
+// printDOT prints the fabric topology as a Graphviz DOT graph.
+// Solid edges are peer links, colored by their state, and dashed edges are imported services.
+func printDOT(topology *fabricTopology) {
+	fmt.Println("digraph fabric {")
+	fmt.Println("  node [shape=box];")
+
+	nodes := make(map[string]bool)
+	for _, site := range topology.Sites {
+		if site.Error != "" {
+			continue
+		}
+
+		nodes[site.Peer] = true
+		// the label is split into lines using the DOT \n escape
+		fmt.Printf("  %s [label=\"%s\\n(%s)\"];\n", dotQuote(site.Peer), dotEscape(site.Peer), dotEscape(site.Context))
+	}
+
+	// peers which are not part of the fabric sites
+	for _, link := range topology.Links {
+		if !nodes[link.To] {
+			nodes[link.To] = true
+			fmt.Printf("  %s [style=dashed];\n", dotQuote(link.To))
+		}
+	}
+
+	for _, link := range topology.Links {
+		color, ok := linkColors[link.State]
+		if !ok {
+			color = "gray"
+		}
+		fmt.Printf("  %s -> %s [color=%s];\n", dotQuote(link.From), dotQuote(link.To), color)
+	}
+
+	for _, service := range topology.Services {
+		for _, importer := range service.ImportedBy {
+			fmt.Printf("  %s -> %s [style=dashed, color=blue, label=%s];\n",
+				dotQuote(importer), dotQuote(service.Peer), dotQuote(service.Name))
+		}
+	}
+
+	fmt.Println("}")
+}
+
+// dotEscaper escapes the characters which are special in DOT quoted strings.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// dotEscape escapes a string for use inside a DOT quoted string.
+// Unlike Go, DOT only supports escaping quotes and backslashes (other escapes, such as \u, are invalid).
+func dotEscape(value string) string {
+	return dotEscaper.Replace(value)
+}
+
+// dotQuote returns a DOT quoted string.
+func dotQuote(value string) string {
+	return `"` + dotEscape(value) + `"`
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcommand
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+)
+
+func sitePeers(names ...string) []api.Peer {
+	result := make([]api.Peer, len(names))
+	for i, name := range names {
+		result[i] = api.Peer{Name: name, Status: api.PeerStatus{State: api.PeerStateReachable, LastSeen: "now"}}
+	}
+	return result
+}
+
+func siteBindings(imp string, peers ...string) []api.Binding {
+	result := make([]api.Binding, len(peers))
+	for i, peer := range peers {
+		result[i] = api.Binding{Spec: api.BindingSpec{Import: imp, Peer: peer}}
+	}
+	return result
+}
+
+func TestBuildTopology(t *testing.T) {
+	tests := []struct {
+		name     string
+		sites    []*fabricSite
+		links    []fabricLink
+		services []fabricService
+		issues   []string
+	}{
+		{
+			name: "symmetric",
+			sites: []*fabricSite{
+				{
+					Context:  "ctx-a",
+					Peer:     "a",
+					peers:    sitePeers("b"),
+					imports:  []api.Import{{Name: "mysql"}},
+					bindings: siteBindings("mysql", "b"),
+				},
+				{Context: "ctx-b", Peer: "b", peers: sitePeers("a"), exports: []api.Export{{Name: "mysql"}}},
+			},
+			links: []fabricLink{
+				{From: "a", To: "b", State: api.PeerStateReachable, LastSeen: "now", Mutual: mutualYes},
+				{From: "b", To: "a", State: api.PeerStateReachable, LastSeen: "now", Mutual: mutualYes},
+			},
+			services: []fabricService{{Name: "mysql", Peer: "b", ImportedBy: []string{"a"}}},
+		},
+		{
+			name: "asymmetric peers",
+			sites: []*fabricSite{
+				{Context: "ctx-a", Peer: "a", peers: sitePeers("b")},
+				{Context: "ctx-b", Peer: "b"},
+			},
+			links: []fabricLink{
+				{From: "a", To: "b", State: api.PeerStateReachable, LastSeen: "now", Mutual: mutualNo},
+			},
+			issues: []string{"peer 'a' knows peer 'b', but not vice versa"},
+		},
+		{
+			name: "bound to a peer with no matching export",
+			sites: []*fabricSite{
+				{
+					Context:  "ctx-a",
+					Peer:     "a",
+					peers:    sitePeers("b"),
+					imports:  []api.Import{{Name: "redis"}},
+					bindings: siteBindings("redis", "b"),
+				},
+				{Context: "ctx-b", Peer: "b", peers: sitePeers("a"), exports: []api.Export{{Name: "mysql"}}},
+			},
+			links: []fabricLink{
+				{From: "a", To: "b", State: api.PeerStateReachable, LastSeen: "now", Mutual: mutualYes},
+				{From: "b", To: "a", State: api.PeerStateReachable, LastSeen: "now", Mutual: mutualYes},
+			},
+			services: []fabricService{{Name: "mysql", Peer: "b"}},
+			issues:   []string{"import 'redis' on peer 'a' is bound to peer 'b', which has no matching export"},
+		},
+		{
+			name: "unbound import and binding to an unconfigured peer",
+			sites: []*fabricSite{
+				{
+					Context:  "ctx-a",
+					Peer:     "a",
+					imports:  []api.Import{{Name: "mysql"}, {Name: "redis"}},
+					bindings: siteBindings("mysql", "b"),
+				},
+				{Context: "ctx-b", Peer: "b", exports: []api.Export{{Name: "mysql"}}},
+			},
+			services: []fabricService{{Name: "mysql", Peer: "b", ImportedBy: []string{"a"}}},
+			issues: []string{
+				"import 'mysql' on peer 'a' is bound to peer 'b', which is not configured on 'a'",
+				"import 'redis' on peer 'a' is not bound to any peer",
+			},
+		},
+		{
+			name: "unavailable site",
+			sites: []*fabricSite{
+				{Context: "ctx-a", Peer: "a", peers: []api.Peer{{Name: "b"}}},
+				{Context: "ctx-b", Error: "connection refused"},
+			},
+			links: []fabricLink{
+				{From: "a", To: "b", State: stateUnknown, Mutual: mutualUnknown},
+			},
+			issues: []string{"context 'ctx-b' is unavailable: connection refused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topology := buildTopology(tt.sites)
+			require.Equal(t, tt.sites, topology.Sites)
+			require.Equal(t, tt.links, topology.Links)
+			require.Equal(t, tt.services, topology.Services)
+			require.Equal(t, tt.issues, topology.Issues)
+		})
+	}
+}
+
+func TestDOTEscape(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "peer1", expected: "peer1"},
+		{value: `my "peer"`, expected: `my \"peer\"`},
+		{value: `C:\peers`, expected: `C:\\peers`},
+		{value: `\"`, expected: `\\\"`},
+		{value: "line\nbreak", expected: "line\nbreak"},
+		{value: "café", expected: "café"},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, dotEscape(tt.value), tt.value)
+		require.Equal(t, `"`+tt.expected+`"`, dotQuote(tt.value), tt.value)
+	}
+}
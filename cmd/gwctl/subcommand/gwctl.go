@@ -14,14 +14,15 @@
 package subcommand
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	"github.com/clusterlink-net/clusterlink/cmd/gwctl/config"
+	"github.com/clusterlink-net/clusterlink/cmd/gwctl/printer"
 	cmdutil "github.com/clusterlink-net/clusterlink/cmd/util"
+	"github.com/clusterlink-net/clusterlink/pkg/api"
 	"github.com/clusterlink-net/clusterlink/pkg/util/rest"
 	"github.com/clusterlink-net/clusterlink/pkg/util/tls"
 )
@@ -90,6 +91,7 @@ func (o *initOptions) run() error {
 
 // stateGetOptions is the command line options for 'get state'.
 type stateGetOptions struct {
+	outputOptions
 	myID string
 }
 
@@ -112,25 +114,43 @@ func StateGetCmd() *cobra.Command {
 // addFlags registers flags for the CLI.
 func (o *stateGetOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
+	o.outputOptions.addFlags(fs)
 }
 
 // run performs the execution of the 'get state' subcommand.
 func (o *stateGetOptions) run() error {
-	d, err := config.GetConfigFromID(o.myID)
+	p, err := o.printer()
 	if err != nil {
 		return err
 	}
 
-	sJSON, err := json.MarshalIndent(d, "", "  ")
+	d, err := config.GetConfigFromID(o.myID)
 	if err != nil {
-		return fmt.Errorf("error: %w", err)
+		return err
 	}
 
-	fmt.Println(string(sJSON))
-	return nil
+	return p.PrintObject(d, stateColumns)
+}
+
+// stateColumns are the table columns of the gwctl configuration.
+var stateColumns = []printer.Column{
+	{Header: "ID", Value: func(object any) string { return object.(*config.ClientConfig).ID }},
+	{Header: "GATEWAY", Value: func(object any) string {
+		cfg := object.(*config.ClientConfig)
+		return endpointString(api.Endpoint{Host: cfg.GwIP, Port: cfg.GwPort})
+	}},
+	{Header: "DATAPLANE", Value: func(object any) string { return object.(*config.ClientConfig).Dataplane }},
+	{Header: "POLICY ENGINE", Wide: true, Value: func(object any) string {
+		return object.(*config.ClientConfig).PolicyEngineIP
+	}},
+	{Header: "CA", Wide: true, Value: func(object any) string { return object.(*config.ClientConfig).CaFile }},
+	{Header: "CERTIFICATE", Wide: true, Value: func(object any) string { return object.(*config.ClientConfig).CertFile }},
+	{Header: "KEY", Wide: true, Value: func(object any) string { return object.(*config.ClientConfig).KeyFile }},
 }
 
+// allGetOptions is the command line options for 'get all'.
 type allGetOptions struct {
+	outputOptions
 	myID string
 }
 
@@ -153,36 +173,72 @@ func AllGetCmd() *cobra.Command {
 // addFlags registers flags for the CLI.
 func (o *allGetOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
+	o.outputOptions.addFlags(fs)
+}
+
+// allObjects are all the objects of the gateway.
+type allObjects struct {
+	Peers    []api.Peer
+	Exports  []api.Export
+	Imports  []api.Import
+	Bindings []api.Binding
+	Policies []typedPolicy
 }
 
 // run performs the execution of the 'get all' subcommand.
 func (o *allGetOptions) run() error {
-	g, err := config.GetClientFromID(o.myID)
+	p, err := o.printer()
 	if err != nil {
 		return err
 	}
 
-	objects := map[string]*rest.Client{
-		"Peers":    g.Peers,
-		"Exports":  g.Exports,
-		"Imports":  g.Imports,
-		"Bindings": g.Bindings,
+	g, err := config.GetClientFromID(o.myID)
+	if err != nil {
+		return err
 	}
 
-	for name, o := range objects {
-		fmt.Printf("%s:\n", name)
-		d, err := o.List()
+	clients := []*rest.Client{g.Peers, g.Exports, g.Imports, g.Bindings, g.AccessPolicies, g.LBPolicies}
+	lists := make([]any, len(clients))
+	for i, client := range clients {
+		lists[i], err = client.List()
 		if err != nil {
 			return fmt.Errorf("error: %w", err)
 		}
-		sJSON, err := json.Marshal(d)
-		if err != nil {
-			return fmt.Errorf("error: %w", err)
+	}
+
+	all := allObjects{
+		Peers:    *lists[0].(*[]api.Peer),
+		Exports:  *lists[1].(*[]api.Export),
+		Imports:  *lists[2].(*[]api.Import),
+		Bindings: *lists[3].(*[]api.Binding),
+		Policies: typedPolicies(*lists[4].(*[]api.Policy), *lists[5].(*[]api.Policy)),
+	}
+
+	if !p.IsTable() {
+		return p.PrintObject(&all, nil)
+	}
+
+	sections := []struct {
+		title   string
+		objects any
+		columns []printer.Column
+	}{
+		{"Peers", all.Peers, peerColumns},
+		{"Exports", all.Exports, exportColumns},
+		{"Imports", all.Imports, importColumns(all.Bindings)},
+		{"Bindings", all.Bindings, bindingColumns},
+		{"Policies", all.Policies, policyColumns},
+	}
+	for i, section := range sections {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		fmt.Printf("%s:\n", section.title)
+		if err := p.Print(section.objects, section.columns); err != nil {
+			return err
 		}
-		fmt.Println(string(sJSON))
 	}
 
-	// Policy
-	p := policyGetOptions{myID: o.myID}
-	return p.run()
+	return nil
 }
@@ -14,7 +14,6 @@
 package subcommand
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -27,10 +26,14 @@ import (
 
 // importOptions is the command line options for 'create import' or 'update import'.
 type importOptions struct {
-	myID string
-	name string
-	host string
-	port uint16
+	myID            string
+	name            string
+	host            string
+	port            uint16
+	labels          map[string]string
+	autoBind        bool
+	peers           []string
+	resourceVersion uint64
 }
 
 // ImportCreateCmd - create an imported service.
@@ -46,6 +49,8 @@ func ImportCreateCmd() *cobra.Command {
 	}
 
 	o.addFlags(cmd.Flags())
+	cmd.Flags().StringSliceVar(&o.peers, "peers", nil,
+		"Peers to bind the imported service to. The import and its bindings are created atomically")
 	cmdutil.MarkFlagsRequired(cmd, []string{"name", "port"})
 
 	return cmd
@@ -64,6 +69,7 @@ func ImportUpdateCmd() *cobra.Command {
 	}
 
 	o.addFlags(cmd.Flags())
+	addResourceVersionFlag(cmd.Flags(), &o.resourceVersion)
 	cmdutil.MarkFlagsRequired(cmd, []string{"name", "port"})
 
 	return cmd
@@ -75,6 +81,9 @@ func (o *importOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.name, "name", "", "Imported service name")
 	fs.StringVar(&o.host, "host", "", "Imported service endpoint (IP/DNS), if unspecified, uses the service name")
 	fs.Uint16Var(&o.port, "port", 0, "Imported service port")
+	fs.StringToStringVar(&o.labels, "labels", nil, "Imported service labels (e.g. 'env=prod,tier=db')")
+	fs.BoolVar(&o.autoBind, "auto-bind", false,
+		"Automatically bind to every reachable peer exporting a service of the same name, unless explicitly bound")
 }
 
 // run performs the execution of the 'create import' or 'update import' subcommand.
@@ -86,29 +95,35 @@ func (o *importOptions) run(isUpdate bool) error {
 
 	importOperation := g.Imports.Create
 	if isUpdate {
-		importOperation = g.Imports.Update
+		importOperation = func(object any) error {
+			return conflictError(g.Imports.UpdateIfMatch(object, o.resourceVersion), g.Imports, "import", o.name)
+		}
 	}
 
-	err = importOperation(&api.Import{
-		Name: o.name,
+	imp := api.Import{
+		Name:   o.name,
+		Labels: o.labels,
 		Spec: api.ImportSpec{
 			Service: api.Endpoint{
 				Host: o.host,
 				Port: o.port,
 			},
+			AutoBind: o.autoBind,
 		},
-	})
-	if err != nil {
-		return err
 	}
 
-	return nil
+	if len(o.peers) > 0 {
+		return importOperation(&api.ImportWithBindings{Import: imp, Peers: o.peers})
+	}
+
+	return importOperation(&imp)
 }
 
 // importDeleteOptions is the command line options for 'delete import'.
 type importDeleteOptions struct {
-	myID string
-	name string
+	myID            string
+	name            string
+	resourceVersion uint64
 }
 
 // ImportDeleteCmd - delete an imported service command.
@@ -133,6 +148,7 @@ func ImportDeleteCmd() *cobra.Command {
 func (o *importDeleteOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
 	fs.StringVar(&o.name, "name", "", "Imported service name")
+	addResourceVersionFlag(fs, &o.resourceVersion)
 }
 
 // run performs the execution of the 'delete import' subcommand.
@@ -142,9 +158,9 @@ func (o *importDeleteOptions) run() error {
 		return err
 	}
 
-	err = g.Imports.Delete(o.name)
+	err = g.Imports.DeleteIfMatch(o.name, o.resourceVersion)
 	if err != nil {
-		return err
+		return conflictError(err, g.Imports, "import", o.name)
 	}
 
 	return nil
@@ -152,8 +168,11 @@ func (o *importDeleteOptions) run() error {
 
 // importGetOptions is the command line options for 'get import'.
 type importGetOptions struct {
-	myID string
-	name string
+	listOptions
+	outputOptions
+	myID  string
+	name  string
+	watch bool
 }
 
 // ImportGetCmd - get imported service command.
@@ -176,6 +195,9 @@ func ImportGetCmd() *cobra.Command {
 func (o *importGetOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
 	fs.StringVar(&o.name, "name", "", "Imported service name. If empty gets all imported services.")
+	fs.BoolVar(&o.watch, "watch", false, "Watch for changes to imported services")
+	o.listOptions.addFlags(fs)
+	o.outputOptions.addFlags(fs)
 }
 
 // run performs the execution of the 'get import' subcommand.
@@ -185,27 +207,54 @@ func (o *importGetOptions) run() error {
 		return err
 	}
 
+	if o.watch {
+		return watchObjects(importClient.Imports, "import", func(object any) string {
+			s := object.(*api.Import)
+			if o.name != "" && s.Name != o.name {
+				return ""
+			}
+			return fmt.Sprintf("%s %v (listener port %d)", s.Name, s.Spec.Service, s.Status.Listener.Port)
+		})
+	}
+
+	p, err := o.printer()
+	if err != nil {
+		return err
+	}
+
+	// bound peers are only shown in tables
+	var bindings []api.Binding
+	if p.IsTable() {
+		var bArr any
+		if o.name == "" {
+			bArr, err = importClient.Bindings.List()
+		} else {
+			bArr, err = importClient.Bindings.Get(o.name)
+		}
+		if err != nil {
+			return err
+		}
+		bindings = *bArr.(*[]api.Binding)
+	}
+
 	if o.name == "" {
-		sArr, err := importClient.Imports.List()
+		sArr, next, err := o.list(importClient.Imports)
 		if err != nil {
 			return err
 		}
-		fmt.Printf("Imported services:\n")
-		for i, s := range *sArr.(*[]api.Import) {
-			fmt.Printf("%d. Imported Name: %s. Endpoint %v\n", i+1, s.Name, s.Spec.Service)
+		if err := p.Print(sArr, importColumns(bindings)); err != nil {
+			return err
 		}
+		printContinue(next)
 	} else {
-		imp, err := importClient.Imports.Get(o.name)
+		imp, version, err := importClient.Imports.GetWithVersion(o.name)
 		if err != nil {
 			return err
 		}
-
-		impJSON, err := json.MarshalIndent(imp, "", "  ")
-		if err != nil {
+		if err := p.PrintObject(imp, importColumns(bindings)); err != nil {
 			return err
 		}
-
-		fmt.Printf("%s\n", string(impJSON))
+		printVersion(p, version)
 	}
 
 	return nil
@@ -0,0 +1,60 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcommand
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/clusterlink-net/clusterlink/pkg/util/rest"
+)
+
+// listOptions is the command line options for selecting and paginating listed objects.
+type listOptions struct {
+	selector      string
+	fieldSelector string
+	prefix        string
+	limit         int
+	continueToken string
+}
+
+// addFlags registers flags for the CLI.
+func (o *listOptions) addFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.selector, "selector", "l", "", "Label selector (e.g. 'env=prod,tier!=test')")
+	fs.StringVar(&o.fieldSelector, "field-selector", "", "Field selector (e.g. 'spec.service.host=db')")
+	fs.StringVar(&o.prefix, "prefix", "", "List only objects whose name starts with this prefix")
+	fs.IntVar(&o.limit, "limit", 0, "Maximal number of objects to list. If 0, lists all objects")
+	fs.StringVar(&o.continueToken, "continue", "", "Token for listing the next objects, as returned by a previous list")
+}
+
+// list returns the selected objects, and the token for listing the next objects (if any).
+func (o *listOptions) list(client *rest.Client) (any, string, error) {
+	return client.ListWithOptions(&rest.ListOptions{
+		LabelSelector: o.selector,
+		FieldSelector: o.fieldSelector,
+		Prefix:        o.prefix,
+		Limit:         o.limit,
+		Continue:      o.continueToken,
+	})
+}
+
+// printContinue prints how to list the next objects, if there are any.
+// The hint is printed to the standard error, so it does not mix with the printed objects.
+func printContinue(next string) {
+	if next != "" {
+		fmt.Fprintf(os.Stderr, "More objects are available, list them using '--continue %s'.\n", next)
+	}
+}
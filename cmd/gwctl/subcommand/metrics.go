@@ -15,15 +15,21 @@ package subcommand
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	"github.com/clusterlink-net/clusterlink/cmd/gwctl/config"
+	"github.com/clusterlink-net/clusterlink/cmd/gwctl/printer"
+	event "github.com/clusterlink-net/clusterlink/pkg/controlplane/eventmanager"
 )
 
 // MetricsGetOptions is the command line options for 'get metrics'.
 type metricsGetOptions struct {
+	outputOptions
 	myID string
 }
 
@@ -47,10 +53,16 @@ func MetricsGetCmd() *cobra.Command {
 // addFlags registers flags for the CLI.
 func (o *metricsGetOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
+	o.outputOptions.addFlags(fs)
 }
 
-// run performs the execution of the 'delete policy' subcommand.
+// run performs the execution of the 'get metrics' subcommand.
 func (o *metricsGetOptions) run() error {
+	p, err := o.printer()
+	if err != nil {
+		return err
+	}
+
 	m, err := config.GetClientFromID(o.myID)
 	if err != nil {
 		return err
@@ -58,12 +70,61 @@ func (o *metricsGetOptions) run() error {
 
 	metrics, err := m.GetMetrics()
 	if err != nil {
-		fmt.Printf("Unable to get metrics %v\n", err)
-	} else {
-		fmt.Printf("Metrics\n")
-		for i := range metrics {
-			fmt.Printf("%v\n", metrics[i])
-		}
+		return fmt.Errorf("unable to get metrics: %w", err)
 	}
-	return nil
+
+	if !p.IsTable() {
+		return p.PrintObject(metrics, nil)
+	}
+
+	ids := make([]string, 0, len(metrics))
+	for id := range metrics {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	connections := make([]event.ConnectionStatusAttr, len(ids))
+	for i, id := range ids {
+		connections[i] = metrics[id]
+	}
+
+	return p.Print(connections, connectionColumns)
+}
+
+// connectionDirections are the printed names of connection directions.
+var connectionDirections = map[event.Direction]string{
+	event.Incoming: "incoming",
+	event.Outgoing: "outgoing",
+}
+
+// connectionStates are the printed names of connection states.
+var connectionStates = map[event.ConnectionState]string{
+	event.Ongoing:    "ongoing",
+	event.Complete:   "complete",
+	event.Denied:     "denied",
+	event.PeerDenied: "peer-denied",
+}
+
+// connectionColumns are the table columns of connection metrics.
+var connectionColumns = []printer.Column{
+	{Header: "CONNECTION", Value: func(object any) string { return object.(*event.ConnectionStatusAttr).ConnectionID }},
+	{Header: "SOURCE", Value: func(object any) string { return object.(*event.ConnectionStatusAttr).SrcService }},
+	{Header: "DESTINATION", Value: func(object any) string { return object.(*event.ConnectionStatusAttr).DstService }},
+	{Header: "PEER", Value: func(object any) string { return object.(*event.ConnectionStatusAttr).DestinationPeer }},
+	{Header: "DIRECTION", Value: func(object any) string {
+		return connectionDirections[object.(*event.ConnectionStatusAttr).Direction]
+	}},
+	{Header: "STATE", Value: func(object any) string { return connectionStates[object.(*event.ConnectionStatusAttr).State] }},
+	{Header: "INCOMING BYTES", Value: func(object any) string {
+		return strconv.Itoa(object.(*event.ConnectionStatusAttr).IncomingBytes)
+	}},
+	{Header: "OUTGOING BYTES", Value: func(object any) string {
+		return strconv.Itoa(object.(*event.ConnectionStatusAttr).OutgoingBytes)
+	}},
+	{Header: "START", Wide: true, Value: func(object any) string {
+		return object.(*event.ConnectionStatusAttr).StartTstamp.Format(time.RFC3339)
+	}},
+	{Header: "LAST", Wide: true, Value: func(object any) string {
+		return object.(*event.ConnectionStatusAttr).LastTstamp.Format(time.RFC3339)
+	}},
 }
@@ -0,0 +1,167 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcommand
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/clusterlink-net/clusterlink/cmd/gwctl/printer"
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+)
+
+// outputOptions is the command line options for the format of printed objects.
+type outputOptions struct {
+	output string
+}
+
+// addFlags registers flags for the CLI.
+func (o *outputOptions) addFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.output, "output", "o", string(printer.JSON), "Output format: "+printer.Formats())
+}
+
+// printer returns a printer of objects to the standard output, in the requested format.
+func (o *outputOptions) printer() (*printer.Printer, error) {
+	return printer.New(o.output, os.Stdout)
+}
+
+// printVersion prints the resource version of an object, if it is printed as a table.
+func printVersion(p *printer.Printer, version uint64) {
+	if p.IsTable() {
+		fmt.Printf("Resource version: %d\n", version)
+	}
+}
+
+// endpointString returns the host:port representation of an endpoint.
+func endpointString(endpoint api.Endpoint) string {
+	if endpoint.Host == "" && endpoint.Port == 0 {
+		return ""
+	}
+	return net.JoinHostPort(endpoint.Host, strconv.Itoa(int(endpoint.Port)))
+}
+
+// labelsString returns the key=value representation of labels, ordered by keys.
+func labelsString(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + labels[key]
+	}
+	return strings.Join(pairs, ",")
+}
+
+// peerColumns are the table columns of peers.
+var peerColumns = []printer.Column{
+	{Header: "NAME", Value: func(object any) string { return object.(*api.Peer).Name }},
+	{Header: "GATEWAYS", Value: func(object any) string {
+		gateways := object.(*api.Peer).Spec.Gateways
+		endpoints := make([]string, len(gateways))
+		for i, gateway := range gateways {
+			endpoints[i] = endpointString(gateway)
+		}
+		return strings.Join(endpoints, ",")
+	}},
+	{Header: "STATE", Value: func(object any) string { return object.(*api.Peer).Status.State }},
+	{Header: "LAST SEEN", Value: func(object any) string { return object.(*api.Peer).Status.LastSeen }},
+	{Header: "LABELS", Wide: true, Value: func(object any) string { return labelsString(object.(*api.Peer).Labels) }},
+}
+
+// exportColumns are the table columns of exports.
+var exportColumns = []printer.Column{
+	{Header: "NAME", Value: func(object any) string { return object.(*api.Export).Name }},
+	{Header: "TARGET", Value: func(object any) string { return endpointString(object.(*api.Export).Spec.Service) }},
+	{Header: "EXTERNAL SERVICE", Wide: true, Value: func(object any) string {
+		return object.(*api.Export).Spec.ExternalService
+	}},
+	{Header: "LABELS", Wide: true, Value: func(object any) string { return labelsString(object.(*api.Export).Labels) }},
+}
+
+// remoteExportColumns are the table columns of exports of remote peers.
+var remoteExportColumns = []printer.Column{
+	{Header: "PEER", Value: func(object any) string { return object.(*api.RemoteExport).Peer }},
+	{Header: "NAME", Value: func(object any) string { return object.(*api.RemoteExport).Name }},
+	{Header: "LABELS", Wide: true, Value: func(object any) string {
+		return labelsString(object.(*api.RemoteExport).Labels)
+	}},
+}
+
+// importColumns returns the table columns of imports, where bound peers are taken from the given bindings.
+func importColumns(bindings []api.Binding) []printer.Column {
+	peers := make(map[string][]string)
+	for _, binding := range bindings {
+		peers[binding.Spec.Import] = append(peers[binding.Spec.Import], binding.Spec.Peer)
+	}
+
+	return []printer.Column{
+		{Header: "NAME", Value: func(object any) string { return object.(*api.Import).Name }},
+		{Header: "SERVICE", Value: func(object any) string { return endpointString(object.(*api.Import).Spec.Service) }},
+		{Header: "LISTENER PORT", Value: func(object any) string {
+			if port := object.(*api.Import).Status.Listener.Port; port != 0 {
+				return strconv.Itoa(int(port))
+			}
+			return ""
+		}},
+		{Header: "PEERS", Value: func(object any) string {
+			importPeers := peers[object.(*api.Import).Name]
+			if len(importPeers) == 0 && object.(*api.Import).Spec.AutoBind {
+				return "<auto>"
+			}
+			sort.Strings(importPeers)
+			return strings.Join(importPeers, ",")
+		}},
+		{Header: "LABELS", Wide: true, Value: func(object any) string { return labelsString(object.(*api.Import).Labels) }},
+	}
+}
+
+// bindingColumns are the table columns of bindings.
+var bindingColumns = []printer.Column{
+	{Header: "IMPORT", Value: func(object any) string { return object.(*api.Binding).Spec.Import }},
+	{Header: "PEER", Value: func(object any) string { return object.(*api.Binding).Spec.Peer }},
+}
+
+// typedPolicy is a policy along with its type, for printing access and load-balancing policies together.
+type typedPolicy struct {
+	Type string
+	api.Policy
+}
+
+// typedPolicies returns the given access and load-balancing policies, along with their types.
+func typedPolicies(accessPolicies, lbPolicies []api.Policy) []typedPolicy {
+	var policies []typedPolicy
+	for _, policy := range accessPolicies {
+		policies = append(policies, typedPolicy{Type: "access", Policy: policy})
+	}
+	for _, policy := range lbPolicies {
+		policies = append(policies, typedPolicy{Type: "lb", Policy: policy})
+	}
+	return policies
+}
+
+// policyColumns are the table columns of typed policies.
+var policyColumns = []printer.Column{
+	{Header: "NAME", Value: func(object any) string { return object.(*typedPolicy).Name }},
+	{Header: "TYPE", Value: func(object any) string { return object.(*typedPolicy).Type }},
+	{Header: "LABELS", Wide: true, Value: func(object any) string { return labelsString(object.(*typedPolicy).Labels) }},
+}
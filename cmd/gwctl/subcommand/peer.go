@@ -15,21 +15,28 @@ package subcommand
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
 
 	"github.com/clusterlink-net/clusterlink/cmd/gwctl/config"
 	cmdutil "github.com/clusterlink-net/clusterlink/cmd/util"
 	"github.com/clusterlink-net/clusterlink/pkg/api"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
 )
 
 // peerCreateOptions is the command line options for 'create peer' or 'update peer'.
 type peerOptions struct {
-	myID string
-	name string
-	host string
-	port uint16
+	myID            string
+	name            string
+	host            string
+	port            uint16
+	labels          map[string]string
+	card            string
+	fabricCA        string
+	resourceVersion uint64
 }
 
 // PeerCreateCmd - create a peer command.
@@ -45,7 +52,12 @@ func PeerCreateCmd() *cobra.Command {
 	}
 
 	o.addFlags(cmd.Flags())
-	cmdutil.MarkFlagsRequired(cmd, []string{"name", "host", "port"})
+	cmd.Flags().StringVar(&o.card, "card", "", "Peer card file (created by 'cl-adm create peer-card') to create the peer from")
+	cmd.Flags().StringVar(&o.fabricCA, "fabric-ca", "", "Fabric CA certificate file, for verifying the peer card")
+	cmd.MarkFlagsRequiredTogether("name", "host", "port")
+	cmd.MarkFlagsRequiredTogether("card", "fabric-ca")
+	cmd.MarkFlagsOneRequired("name", "card")
+	cmd.MarkFlagsMutuallyExclusive("name", "card")
 
 	return cmd
 }
@@ -63,6 +75,7 @@ func PeerUpdateCmd() *cobra.Command {
 	}
 
 	o.addFlags(cmd.Flags())
+	addResourceVersionFlag(cmd.Flags(), &o.resourceVersion)
 	cmdutil.MarkFlagsRequired(cmd, []string{"name", "host", "port"})
 
 	return cmd
@@ -74,6 +87,7 @@ func (o *peerOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.name, "name", "", "Peer name")
 	fs.StringVar(&o.host, "host", "", "Peer endpoint hostname (IP/DNS)")
 	fs.Uint16Var(&o.port, "port", 0, "Peer endpoint port")
+	fs.StringToStringVar(&o.labels, "labels", nil, "Peer labels (e.g. 'env=prod,region=eu')")
 }
 
 // run performs the execution of the 'create peer' or 'update peer' subcommand.
@@ -85,29 +99,79 @@ func (o *peerOptions) run(isUpdate bool) error {
 
 	peerOperation := g.Peers.Create
 	if isUpdate {
-		peerOperation = g.Peers.Update
+		peerOperation = func(object any) error {
+			return conflictError(g.Peers.UpdateIfMatch(object, o.resourceVersion), g.Peers, "peer", o.name)
+		}
 	}
 
-	err = peerOperation(&api.Peer{
-		Name: o.name,
+	peer := &api.Peer{
+		Name:   o.name,
+		Labels: o.labels,
 		Spec: api.PeerSpec{
 			Gateways: []api.Endpoint{{
 				Host: o.host,
 				Port: o.port,
 			}},
 		},
-	})
+	}
+
+	if o.card != "" {
+		peer, err = o.peerFromCard()
+		if err != nil {
+			return err
+		}
+	}
+
+	return peerOperation(peer)
+}
+
+// peerFromCard returns a peer from a peer card, after verifying the card against the fabric CA.
+func (o *peerOptions) peerFromCard() (*api.Peer, error) {
+	rawCard, err := os.ReadFile(o.card)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	var card bootstrap.PeerCard
+	if err := yaml.Unmarshal(rawCard, &card); err != nil {
+		return nil, fmt.Errorf("cannot decode peer card: %w", err)
+	}
+
+	rawFabricCA, err := os.ReadFile(o.fabricCA)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := bootstrap.VerifyPeerCard(&card, rawFabricCA)
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify peer card: %w", err)
+	}
+
+	fmt.Printf("Verified peer card of '%s' (certificate fingerprint %s).\n", content.Name, content.Fingerprint)
+
+	labels := make(map[string]string)
+	for key, value := range content.Attributes {
+		labels[key] = value
+	}
+	for key, value := range o.labels {
+		labels[key] = value
+	}
+	if len(labels) == 0 {
+		labels = nil
+	}
+
+	return &api.Peer{
+		Name:   content.Name,
+		Labels: labels,
+		Spec:   api.PeerSpec{Gateways: content.Gateways},
+	}, nil
 }
 
 // peerDeleteOptions is the command line options for 'delete peer'.
 type peerDeleteOptions struct {
-	myID string
-	name string
+	myID            string
+	name            string
+	resourceVersion uint64
 }
 
 // PeerDeleteCmd - delete peer command.
@@ -131,6 +195,7 @@ func PeerDeleteCmd() *cobra.Command {
 func (o *peerDeleteOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
 	fs.StringVar(&o.name, "name", "", "Peer name")
+	addResourceVersionFlag(fs, &o.resourceVersion)
 }
 
 // run performs the execution of the 'delete peer' subcommand.
@@ -140,9 +205,9 @@ func (o *peerDeleteOptions) run() error {
 		return err
 	}
 
-	err = g.Peers.Delete(o.name)
+	err = g.Peers.DeleteIfMatch(o.name, o.resourceVersion)
 	if err != nil {
-		return err
+		return conflictError(err, g.Peers, "peer", o.name)
 	}
 
 	return nil
@@ -150,8 +215,11 @@ func (o *peerDeleteOptions) run() error {
 
 // peerGetOptions is the command line options for 'get peer'.
 type peerGetOptions struct {
-	myID string
-	name string
+	listOptions
+	outputOptions
+	myID  string
+	name  string
+	watch bool
 }
 
 // PeerGetCmd - get peer command.
@@ -175,6 +243,9 @@ func PeerGetCmd() *cobra.Command {
 func (o *peerGetOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
 	fs.StringVar(&o.name, "name", "", "Peer name. If empty gets all peers")
+	fs.BoolVar(&o.watch, "watch", false, "Watch for changes to peers")
+	o.listOptions.addFlags(fs)
+	o.outputOptions.addFlags(fs)
 }
 
 // run performs the execution of the 'get peer' subcommand.
@@ -184,21 +255,39 @@ func (o *peerGetOptions) run() error {
 		return err
 	}
 
+	if o.watch {
+		return watchObjects(peerClient.Peers, "peer", func(object any) string {
+			p := object.(*api.Peer)
+			if o.name != "" && p.Name != o.name {
+				return ""
+			}
+			return fmt.Sprintf("%s %v", p.Name, p.Spec.Gateways)
+		})
+	}
+
+	p, err := o.printer()
+	if err != nil {
+		return err
+	}
+
 	if o.name == "" {
-		pArr, err := peerClient.Peers.List()
+		pArr, next, err := o.list(peerClient.Peers)
 		if err != nil {
 			return err
 		}
-		fmt.Printf("Peers:\n")
-		for i, p := range *pArr.(*[]api.Peer) {
-			fmt.Printf("%d. Peer: %v\n", i+1, p)
+		if err := p.Print(pArr, peerColumns); err != nil {
+			return err
 		}
+		printContinue(next)
 	} else {
-		peer, err := peerClient.Peers.Get(o.name)
+		peer, version, err := peerClient.Peers.GetWithVersion(o.name)
 		if err != nil {
 			return err
 		}
-		fmt.Printf("Peer: %+v\n", peer.(*api.Peer))
+		if err := p.PrintObject(peer, peerColumns); err != nil {
+			return err
+		}
+		printVersion(p, version)
 	}
 
 	return nil
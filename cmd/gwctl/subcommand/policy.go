@@ -29,13 +29,15 @@ import (
 
 // PolicyOptions is the command line options for 'create policy' or 'update policy'.
 type policyOptions struct {
-	myID       string
-	pType      string
-	serviceSrc string
-	serviceDst string
-	gwDest     string
-	policy     string
-	policyFile string
+	myID            string
+	pType           string
+	serviceSrc      string
+	serviceDst      string
+	gwDest          string
+	policy          string
+	policyFile      string
+	labels          map[string]string
+	resourceVersion uint64
 }
 
 // PolicyCreateCmd - create a new policy - TODO update this command after integration.
@@ -67,6 +69,7 @@ func PolicyUpdateCmd() *cobra.Command {
 		},
 	}
 	o.addFlags(cmd.Flags())
+	addResourceVersionFlag(cmd.Flags(), &o.resourceVersion)
 	cmdutil.MarkFlagsRequired(cmd, []string{"type"})
 
 	return cmd
@@ -81,6 +84,8 @@ func (o *policyOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.gwDest, "gwDest", "*", "Name of gateway the dest service belongs to (* for wildcard)")
 	fs.StringVar(&o.policy, "policy", "random", "lb policy: random, ecmp, static")
 	fs.StringVar(&o.policyFile, "policyFile", "", "File to load access policy from")
+	fs.StringToStringVar(&o.labels, "labels", nil,
+		"Policy labels (e.g. 'env=prod'). Overrides labels given in the policy file")
 }
 
 // run performs the execution of the 'create policy' or 'update policy' subcommand.
@@ -95,10 +100,14 @@ func (o *policyOptions) run(isUpdate bool) error {
 		if err != nil {
 			return err
 		}
+		policy.Labels = o.labels
 
 		lbOperation := policyClient.LBPolicies.Create
 		if isUpdate {
-			lbOperation = policyClient.LBPolicies.Update
+			lbOperation = func(object any) error {
+				err := policyClient.LBPolicies.UpdateIfMatch(object, o.resourceVersion)
+				return conflictError(err, policyClient.LBPolicies, "policy", policy.Name)
+			}
 		}
 
 		err = lbOperation(policy)
@@ -113,10 +122,16 @@ func (o *policyOptions) run(isUpdate bool) error {
 		if err != nil {
 			return err
 		}
+		if o.labels != nil {
+			policy.Labels = o.labels
+		}
 
 		acOperation := policyClient.AccessPolicies.Create
 		if isUpdate {
-			acOperation = policyClient.AccessPolicies.Update
+			acOperation = func(object any) error {
+				err := policyClient.AccessPolicies.UpdateIfMatch(object, o.resourceVersion)
+				return conflictError(err, policyClient.AccessPolicies, "policy", policy.Name)
+			}
 		}
 
 		err = acOperation(policy)
@@ -162,13 +177,14 @@ func lbPolicyFromParams(serviceSrc, serviceDst, scheme, defaultPeer string) (api
 
 // PolicyDeleteOptions is the command line options for 'delete policy'.
 type policyDeleteOptions struct {
-	myID       string
-	pType      string
-	serviceSrc string
-	serviceDst string
-	gwDest     string
-	policy     string
-	policyFile string
+	myID            string
+	pType           string
+	serviceSrc      string
+	serviceDst      string
+	gwDest          string
+	policy          string
+	policyFile      string
+	resourceVersion uint64
 }
 
 // PolicyDeleteCmd - delete a policy command - TODO change after the policy integration.
@@ -197,6 +213,7 @@ func (o *policyDeleteOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.gwDest, "gwDest", "*", "Name of gateway the dest service belongs to (* for wildcard)")
 	fs.StringVar(&o.policy, "policy", "random", "lb policy: random, ecmp, static")
 	fs.StringVar(&o.policyFile, "policyFile", "", "File to load access policy from")
+	addResourceVersionFlag(fs, &o.resourceVersion)
 }
 
 // run performs the execution of the 'delete policy' subcommand.
@@ -211,9 +228,9 @@ func (o *policyDeleteOptions) run() error {
 		if err != nil {
 			return err
 		}
-		err = policyClient.LBPolicies.Delete(policy.Name)
+		err = policyClient.LBPolicies.DeleteIfMatch(policy.Name, o.resourceVersion)
 		if err != nil {
-			return err
+			return conflictError(err, policyClient.LBPolicies, "policy", policy.Name)
 		}
 
 		return nil
@@ -223,9 +240,9 @@ func (o *policyDeleteOptions) run() error {
 		if err != nil {
 			return err
 		}
-		err = policyClient.AccessPolicies.Delete(policy.Name)
+		err = policyClient.AccessPolicies.DeleteIfMatch(policy.Name, o.resourceVersion)
 		if err != nil {
-			return err
+			return conflictError(err, policyClient.AccessPolicies, "policy", policy.Name)
 		}
 
 		return nil
@@ -236,7 +253,10 @@ func (o *policyDeleteOptions) run() error {
 
 // PolicyGetOptions is the command line options for 'get policy'.
 type policyGetOptions struct {
-	myID string
+	listOptions
+	outputOptions
+	myID  string
+	watch bool
 }
 
 // PolicyGetCmd - get a policy command.
@@ -258,6 +278,9 @@ func PolicyGetCmd() *cobra.Command {
 // addFlags registers flags for the CLI.
 func (o *policyGetOptions) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
+	fs.BoolVar(&o.watch, "watch", false, "Watch for changes to policies")
+	o.listOptions.addFlags(fs)
+	o.outputOptions.addFlags(fs)
 }
 
 // run performs the execution of the 'delete policy' subcommand.
@@ -267,25 +290,35 @@ func (o *policyGetOptions) run() error {
 		return err
 	}
 
-	accessPolicies, err := g.AccessPolicies.List()
+	if o.watch {
+		describe := func(object any) string { return object.(*api.Policy).Name }
+		errs := make(chan error, 2)
+		go func() { errs <- watchObjects(g.AccessPolicies, "access-policy", describe) }()
+		go func() { errs <- watchObjects(g.LBPolicies, "lb-policy", describe) }()
+		return <-errs
+	}
+
+	p, err := o.printer()
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Access policies\n")
-	for d, policy := range *accessPolicies.(*[]api.Policy) {
-		fmt.Printf("Access policy %d: %s\n", d, policy.Name)
+	accessPolicies, accessNext, err := o.list(g.AccessPolicies)
+	if err != nil {
+		return err
 	}
 
-	lbPolicies, err := g.LBPolicies.List()
+	lbPolicies, lbNext, err := o.list(g.LBPolicies)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("\nLoad balancing policies\n")
-	for d, policy := range *lbPolicies.(*[]api.Policy) {
-		fmt.Printf("Load-balancing policy %d: %s\n", d, policy.Name)
+	policies := typedPolicies(*accessPolicies.(*[]api.Policy), *lbPolicies.(*[]api.Policy))
+	if err := p.Print(policies, policyColumns); err != nil {
+		return err
 	}
+	printContinue(accessNext)
+	printContinue(lbNext)
 
 	return nil
 }
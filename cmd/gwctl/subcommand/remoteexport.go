@@ -0,0 +1,76 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcommand
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/clusterlink-net/clusterlink/cmd/gwctl/config"
+	cmdutil "github.com/clusterlink-net/clusterlink/cmd/util"
+)
+
+// remoteExportGetOptions is the command line options for 'get remote-exports'.
+type remoteExportGetOptions struct {
+	outputOptions
+	myID string
+	peer string
+}
+
+// RemoteExportGetCmd - get the exported services of a remote peer command.
+func RemoteExportGetCmd() *cobra.Command {
+	o := remoteExportGetOptions{}
+	cmd := &cobra.Command{
+		Use:     "remote-exports",
+		Aliases: []string{"remote-export"},
+		Short:   "Get the exported services of a remote peer",
+		Long: `Get the exported services which a remote peer exposes to this peer, ` +
+			`filtered by the access policies of the remote peer`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+
+	o.addFlags(cmd.Flags())
+	cmdutil.MarkFlagsRequired(cmd, []string{"peer"})
+
+	return cmd
+}
+
+// addFlags registers flags for the CLI.
+func (o *remoteExportGetOptions) addFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.myID, "myid", "", "gwctl ID")
+	fs.StringVar(&o.peer, "peer", "", "Remote peer name")
+	o.outputOptions.addFlags(fs)
+}
+
+// run performs the execution of the 'get remote-exports' subcommand.
+func (o *remoteExportGetOptions) run() error {
+	g, err := config.GetClientFromID(o.myID)
+	if err != nil {
+		return err
+	}
+
+	p, err := o.printer()
+	if err != nil {
+		return err
+	}
+
+	exports, err := g.RemoteExports(o.peer)
+	if err != nil {
+		return err
+	}
+
+	return p.Print(exports, remoteExportColumns)
+}
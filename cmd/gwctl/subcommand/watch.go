@@ -0,0 +1,54 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcommand
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/clusterlink-net/clusterlink/pkg/util/rest"
+)
+
+// watchRetryInterval is the time to wait before resuming an interrupted watch.
+const watchRetryInterval = time.Second
+
+// watchObjects prints changes to objects until interrupted, resuming the watch whenever the stream ends.
+// describe returns the text printed for an object, or an empty string to skip the object.
+func watchObjects(client *rest.Client, kind string, describe func(object any) string) error {
+	var revision uint64
+	for {
+		events, stop, err := client.Watch(revision)
+		if err != nil {
+			var expiredErr *rest.ExpiredError
+			if !errors.As(err, &expiredErr) {
+				return err
+			}
+
+			// start over, re-sending all current objects
+			revision = 0
+			continue
+		}
+
+		for event := range events {
+			revision = event.Revision
+			if description := describe(event.Object); description != "" {
+				fmt.Printf("%-8s %s %s\n", event.Type, kind, description)
+			}
+		}
+
+		stop()
+		time.Sleep(watchRetryInterval)
+	}
+}
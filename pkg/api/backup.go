@@ -0,0 +1,85 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+const (
+	// BackupVersion is the version of the backup document format.
+	BackupVersion = "v1"
+
+	// BackupPath is the path for getting a backup of the controlplane configuration.
+	BackupPath = "/backup"
+	// RestorePath is the path for restoring a backup of the controlplane configuration.
+	RestorePath = "/restore"
+	// RestoreDryRunParam is the query parameter for computing the changes of a restore, without applying them.
+	RestoreDryRunParam = "dryRun"
+)
+
+// Backup is a snapshot of the configuration of a controlplane.
+type Backup struct {
+	// Version of the backup document format.
+	Version string
+	// Peer is the name of the peer which the backup was taken from.
+	Peer string
+	// Time the backup was taken at (RFC 3339).
+	Time string
+
+	// Peers configured.
+	Peers []Peer
+	// Exports configured.
+	Exports []Export
+	// Imports configured. The import listener port is restored, if available.
+	Imports []Import
+	// Bindings configured.
+	Bindings []Binding
+	// AccessPolicies configured.
+	AccessPolicies []Policy
+	// LBPolicies configured.
+	LBPolicies []Policy
+}
+
+// RestoreAction is the action taken for an object during a restore.
+type RestoreAction string
+
+const (
+	// RestoreCreate creates a missing object.
+	RestoreCreate RestoreAction = "create"
+	// RestoreUpdate updates an object which differs from the backup.
+	RestoreUpdate RestoreAction = "update"
+	// RestoreNone leaves an object which is identical to the backup.
+	RestoreNone RestoreAction = "none"
+)
+
+// RestoreChange is a change of a single object, applied (or to be applied, on a dry-run) by a restore.
+type RestoreChange struct {
+	// Kind of the object (e.g. peer, export).
+	Kind string
+	// Name of the object.
+	Name string
+	// Action taken for the object.
+	Action RestoreAction
+	// Before is the object prior to the restore, if it exists.
+	Before any `json:",omitempty"`
+	// After is the object as restored.
+	After any
+	// Error restoring the object, if failed.
+	Error string `json:",omitempty"`
+}
+
+// RestoreResult is the result of a restore.
+type RestoreResult struct {
+	// DryRun is true if changes were not applied.
+	DryRun bool
+	// Changes of all objects in the backup.
+	Changes []RestoreChange
+}
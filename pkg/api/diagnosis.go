@@ -0,0 +1,60 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+// DiagnoseImportPath is the path for diagnosing an imported service, followed by the import name.
+const DiagnoseImportPath = "/diagnose/imports"
+
+// CheckStatus is the outcome of a diagnosis check.
+type CheckStatus string
+
+const (
+	// CheckPassed indicates a check which found no problem.
+	CheckPassed CheckStatus = "Passed"
+	// CheckFailed indicates a check which found a problem.
+	CheckFailed CheckStatus = "Failed"
+	// CheckSkipped indicates a check which was not performed, as it depends on a failed check.
+	CheckSkipped CheckStatus = "Skipped"
+)
+
+// Check is a single step of a diagnosis.
+type Check struct {
+	// Name of the check.
+	Name string
+	// Status of the check.
+	Status CheckStatus
+	// Message describing the outcome of the check.
+	Message string
+	// Hint for fixing the problem found by a failed check.
+	Hint string `json:",omitempty"`
+}
+
+// Diagnosis is the result of checking the chain of components serving an imported service.
+type Diagnosis struct {
+	// Import is the name of the diagnosed imported service.
+	Import string
+	// Checks performed, in order.
+	Checks []Check
+}
+
+// Failed returns the number of failed checks.
+func (d *Diagnosis) Failed() int {
+	failed := 0
+	for i := range d.Checks {
+		if d.Checks[i].Status == CheckFailed {
+			failed++
+		}
+	}
+	return failed
+}
@@ -0,0 +1,27 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+// RemoteExportsPath is the path for listing the exported services of a remote peer.
+const RemoteExportsPath = "/remoteexports"
+
+// RemoteExport is a service exported by a remote peer, which the local peer is allowed to access.
+type RemoteExport struct {
+	// Peer exporting the service.
+	Peer string
+	// Name of the exported service, to be used when binding imports to the peer.
+	Name string
+	// Labels of the exported service.
+	Labels map[string]string
+}
@@ -0,0 +1,31 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+const (
+	// StatusPath is the path for getting the status of the controlplane.
+	StatusPath = "/status"
+
+	// PeerStateReachable is the state of a peer which responds to heartbeats.
+	PeerStateReachable = "Reachable"
+	// PeerStateUnreachable is the state of a peer which does not respond to heartbeats.
+	PeerStateUnreachable = "Unreachable"
+)
+
+// Status of a controlplane.
+// The status of the remote peers is reported by the peers API (see Peer.Status).
+type Status struct {
+	// Peer is the name of the local peer.
+	Peer string
+}
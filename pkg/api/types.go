@@ -35,6 +35,8 @@ type Endpoint struct {
 type Peer struct {
 	// Name that will be used to identify the Peer in subsequent API calls.
 	Name string
+	// Labels are user-defined key/value pairs, which can be used for selecting objects.
+	Labels map[string]string
 	// Spec represents the attributes of a peer.
 	Spec PeerSpec
 	// Status field contains the peer status observed by the gateway.
@@ -62,6 +64,8 @@ type Export struct {
 	// Name that will be used to identify the exported service in subsequent API calls.
 	// Furthermore, this name will be used by remote peers to identify it as an import source.
 	Name string
+	// Labels are user-defined key/value pairs, which can be used for selecting objects.
+	Labels map[string]string
 	// Spec represents the attributes of the export service.
 	Spec ExportSpec
 }
@@ -85,6 +89,8 @@ type Import struct {
 	// Name of service imported, matches exported name by remote peers providing
 	// the Service.
 	Name string
+	// Labels are user-defined key/value pairs, which can be used for selecting objects.
+	Labels map[string]string
 	// Spec represents the attributes of the import service.
 	Spec ImportSpec
 	// Status field contains the import service status.
@@ -95,6 +101,9 @@ type Import struct {
 type ImportSpec struct {
 	// Service endpoint for the import, as seen by clients in that site.
 	Service Endpoint
+	// AutoBind automatically binds the import to every reachable peer exporting a service of the same name.
+	// Explicit bindings, if any, override automatic bindings.
+	AutoBind bool `json:",omitempty"`
 }
 
 // ImportStatus contains the import service status.
@@ -103,6 +112,15 @@ type ImportStatus struct {
 	Listener Endpoint
 }
 
+// ImportWithBindings is a request for creating an import, together with its bindings to remote peers.
+// The import and its bindings are created atomically: if any of the bindings cannot be created,
+// nothing is created. Only the import is returned when getting it.
+type ImportWithBindings struct {
+	Import
+	// Peers to bind the import to.
+	Peers []string `json:",omitempty"`
+}
+
 // Binding of an imported service to a remotely exposed service from a specific Peer.
 type Binding struct {
 	// Spec represents the attributes of the binding.
@@ -121,6 +139,8 @@ type BindingSpec struct {
 type Policy struct {
 	// Name identifying the Policy instance.
 	Name string
+	// Labels are user-defined key/value pairs, which can be used for selecting objects.
+	Labels map[string]string
 	// Spec represents the attributes of the policy.
 	Spec PolicySpec
 }
@@ -14,7 +14,9 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // StatusConditionType represents the status conditions type for ClusterLink components.
@@ -25,6 +27,9 @@ const (
 	DeploymentReady StatusConditionType = "DeploymentReady"
 	// ServiceReady means the component service is ready to use.
 	ServiceReady StatusConditionType = "ServiceReady"
+	// SpecValid means the component settings in the instance spec are valid.
+	// Components with invalid settings are not deployed or updated.
+	SpecValid StatusConditionType = "SpecValid"
 )
 
 // IngressType represents the ingress type  of the deployed ClusterLink.
@@ -49,15 +54,48 @@ const (
 	DataplaneTypeEnvoy DataplaneType = "envoy"
 )
 
+// StoreType represents the type of store used for persisting the controlplane state.
+type StoreType string
+
+const (
+	// StoreTypeBolt indicates that the controlplane state is persisted in a bolt file, on a persistent volume.
+	StoreTypeBolt StoreType = "bolt"
+	// StoreTypeK8s indicates that the controlplane state is persisted in k8s ConfigMaps.
+	StoreTypeK8s StoreType = "k8s"
+)
+
+// AutoscalingMetric represents the metric used for autoscaling the dataplane.
+type AutoscalingMetric string
+
+const (
+	// AutoscalingMetricCPU indicates autoscaling by the average CPU utilization of the dataplane pods.
+	AutoscalingMetricCPU AutoscalingMetric = "cpu"
+	// AutoscalingMetricConnections indicates autoscaling by the average number of active connections
+	// of the dataplane pods, which the dataplane exports as the clusterlink_dataplane_active_connections
+	// Prometheus metric. The metric must be served by a custom metrics adapter (e.g. the Prometheus adapter).
+	AutoscalingMetricConnections AutoscalingMetric = "connections"
+)
+
 const (
 	// ExternalPort represents the default value for the external ingress service of the LoadBalancer type.
 	ExternalPort = 443
+	// DefaultTargetCPUUtilization is the default target average CPU utilization (percentage of the CPU request)
+	// for autoscaling the dataplane.
+	DefaultTargetCPUUtilization = 80
+	// DefaultTargetActiveConnections is the default target average number of active connections per dataplane pod.
+	DefaultTargetActiveConnections = 1000
 )
 
 // ComponentStatus defines the status of component in ClusterLink.
 type ComponentStatus struct {
 	// Conditions contain the status conditions.
 	Conditions map[string]metav1.Condition `json:"conditions,omitempty"`
+	// Replicas represents the actual number of the component pods.
+	Replicas int32 `json:"replicas,omitempty"`
+	// DesiredReplicas represents the number of component pods requested by the operator or the autoscaler.
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+	// ReadyReplicas represents the number of ready component pods.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
 }
 
 // IngressStatus defines the status of ingress in ClusterLink.
@@ -77,6 +115,79 @@ type InstanceStatus struct {
 	Ingress      IngressStatus   `json:"ingress,omitempty"`
 }
 
+// PodOverrides defines scheduling and resource settings for the pods of a ClusterLink component.
+// These settings are merged into the pod template created by the operator.
+type PodOverrides struct {
+	// Resources represents the compute resources of the component container.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// NodeSelector is a selector which must match a node's labels for the pod to be scheduled on that node.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations represents the pod's tolerations.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Affinity represents the pod's scheduling constraints.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// ImagePullSecrets is a list of secrets in the component namespace, used for pulling the component image.
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// SecurityContext represents the pod-level security attributes.
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+	// ContainerSecurityContext represents the security attributes of the component container.
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+	// +kubebuilder:validation:MaxLength=253
+	// PriorityClassName represents the priority class of the pod.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// ControlPlaneSpec defines the desired state of the controlplane components in ClusterLink.
+type ControlPlaneSpec struct {
+	// +kubebuilder:validation:Enum=bolt;k8s
+	// +kubebuilder:default=bolt
+	// Store represents the type of store used for persisting the controlplane state.
+	// Supports values "bolt" (using a persistent volume) and "k8s" (using ConfigMaps).
+	Store StoreType `json:"store,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	// +kubebuilder:default=1
+	// Replicas represents the number of controlplane replicas.
+	// Multiple replicas elect a leader, and require the "k8s" store.
+	Replicas int `json:"replicas,omitempty"`
+	// Pod represents scheduling and resource settings for the controlplane pods.
+	Pod PodOverrides `json:"pod,omitempty"`
+}
+
+// AutoscalingSpec defines the autoscaling of the dataplane pods.
+type AutoscalingSpec struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// MinReplicas represents the minimal number of dataplane replicas.
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// MaxReplicas represents the maximal number of dataplane replicas.
+	MaxReplicas int32 `json:"maxReplicas"`
+	// +kubebuilder:validation:Enum=cpu;connections
+	// +kubebuilder:default=cpu
+	// Metric represents the metric to scale by. Supports values "cpu" and "connections".
+	// The "cpu" metric requires a CPU request in the dataplane pod resources.
+	// The "connections" metric requires a custom metrics adapter serving the
+	// clusterlink_dataplane_active_connections metric, which the dataplane pods export on port 9090.
+	Metric AutoscalingMetric `json:"metric,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// Target represents the target average value of the metric per dataplane pod.
+	// For "cpu", this is a percentage of the CPU request (default 80).
+	// For "connections", this is the number of active connections (default 1000).
+	Target int32 `json:"target,omitempty"`
+}
+
+// DisruptionBudgetSpec defines the pod disruption budget of the dataplane pods.
+// At most one of MinAvailable and MaxUnavailable may be set.
+type DisruptionBudgetSpec struct {
+	// MinAvailable represents the number (or percentage) of dataplane pods that must remain available.
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// MaxUnavailable represents the number (or percentage) of dataplane pods that may be unavailable.
+	// Defaults to 1 if neither MinAvailable nor MaxUnavailable is set.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
 // DataPlaneSpec defines the desired state of the dataplane components in ClusterLink.
 type DataPlaneSpec struct {
 	// +kubebuilder:validation:Enum=envoy;go
@@ -87,7 +198,15 @@ type DataPlaneSpec struct {
 	// +kubebuilder:validation:Maximum=10
 	// +kubebuilder:default=1
 	// Replicas represents the number of dataplane replicas.
+	// Ignored if autoscaling is set.
 	Replicas int `json:"replicas,omitempty"`
+	// Pod represents scheduling and resource settings for the dataplane pods.
+	Pod PodOverrides `json:"pod,omitempty"`
+	// Autoscaling represents the autoscaling of the dataplane.
+	// If set, the operator manages a HorizontalPodAutoscaler for the dataplane.
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+	// DisruptionBudget represents the pod disruption budget managed by the operator for the dataplane.
+	DisruptionBudget *DisruptionBudgetSpec `json:"disruptionBudget,omitempty"`
 }
 
 // IngressSpec defines the type of the ingress component in ClusterLink.
@@ -107,8 +226,9 @@ type IngressSpec struct {
 
 // InstanceSpec defines the desired state of a ClusterLink instance.
 type InstanceSpec struct {
-	DataPlane DataPlaneSpec `json:"dataplane,omitempty"`
-	Ingress   IngressSpec   `json:"ingress,omitempty"`
+	ControlPlane ControlPlaneSpec `json:"controlplane,omitempty"`
+	DataPlane    DataPlaneSpec    `json:"dataplane,omitempty"`
+	Ingress      IngressSpec      `json:"ingress,omitempty"`
 
 	// +kubebuilder:validation:Enum=trace;debug;info;warning;error;fatal
 	// +kubebuilder:default=info
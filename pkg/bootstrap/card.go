@@ -0,0 +1,124 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bootstrap
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+)
+
+// PeerCardContent is the information a peer shares with remote peers, for adding it as a peer.
+type PeerCardContent struct {
+	// Name of the peer.
+	Name string
+	// Gateways are the external endpoints of the peer gateway.
+	Gateways []api.Endpoint
+	// Attributes of the peer, set as labels of the created peer.
+	Attributes map[string]string `json:",omitempty"`
+	// Fingerprint is the SHA-256 fingerprint of the peer certificate.
+	Fingerprint string
+	// IssuedAt is the time the card was created.
+	IssuedAt time.Time
+}
+
+// PeerCard is a peer card, signed by the private key of the peer certificate.
+type PeerCard struct {
+	// Content is the JSON-encoded PeerCardContent.
+	Content []byte
+	// Certificate is the PEM-encoded peer certificate.
+	Certificate []byte
+	// Signature is the signature of the content.
+	Signature []byte
+}
+
+// CertificateFingerprint returns the SHA-256 fingerprint of a certificate, as colon-separated hex bytes.
+func CertificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// CreatePeerCard creates a peer card, signed using the private key of the peer certificate.
+// The fingerprint of the card content is set to the fingerprint of the peer certificate.
+func CreatePeerCard(content *PeerCardContent, peerCert *Certificate) (*PeerCard, error) {
+	if peerCert.cert.key == nil {
+		return nil, fmt.Errorf("peer private key is not available")
+	}
+
+	content.Fingerprint = CertificateFingerprint(peerCert.X509())
+
+	rawContent, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode peer card: %w", err)
+	}
+
+	digest := sha256.Sum256(rawContent)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, peerCert.cert.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("cannot sign peer card: %w", err)
+	}
+
+	return &PeerCard{
+		Content:     rawContent,
+		Certificate: peerCert.cert.certPEM,
+		Signature:   signature,
+	}, nil
+}
+
+// VerifyPeerCard verifies that a peer card was signed by a peer certificate issued
+// by one of the given (PEM-encoded) fabric certificates, and returns its content.
+func VerifyPeerCard(card *PeerCard, rawFabricCerts []byte) (*PeerCardContent, error) {
+	var content PeerCardContent
+	if err := json.Unmarshal(card.Content, &content); err != nil {
+		return nil, fmt.Errorf("cannot decode peer card: %w", err)
+	}
+
+	peerCert, err := CertificateFromRaw(card.Certificate, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse peer card certificate: %w", err)
+	}
+
+	if err := VerifyPeerCertificate(peerCert, content.Name, rawFabricCerts); err != nil {
+		return nil, err
+	}
+
+	if fingerprint := CertificateFingerprint(peerCert.X509()); content.Fingerprint != fingerprint {
+		return nil, fmt.Errorf("peer card fingerprint does not match its certificate")
+	}
+
+	publicKey, ok := peerCert.X509().PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported peer certificate key type")
+	}
+
+	digest := sha256.Sum256(card.Content)
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], card.Signature); err != nil {
+		return nil, fmt.Errorf("invalid peer card signature: %w", err)
+	}
+
+	return &content, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bootstrap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
+)
+
+func TestPeerCard(t *testing.T) {
+	fabricCert, err := bootstrap.CreateFabricCertificate(0)
+	require.Nil(t, err)
+
+	peerCert, err := bootstrap.CreatePeerCertificate("peer1", fabricCert, 0)
+	require.Nil(t, err)
+
+	content := &bootstrap.PeerCardContent{
+		Name:       "peer1",
+		Gateways:   []api.Endpoint{{Host: "10.0.0.1", Port: 443}},
+		Attributes: map[string]string{"region": "eu"},
+	}
+	card, err := bootstrap.CreatePeerCard(content, peerCert)
+	require.Nil(t, err)
+	require.Equal(t, bootstrap.CertificateFingerprint(peerCert.X509()), content.Fingerprint)
+
+	verified, err := bootstrap.VerifyPeerCard(card, fabricCert.RawCert())
+	require.Nil(t, err)
+	require.Equal(t, content.Name, verified.Name)
+	require.Equal(t, content.Gateways, verified.Gateways)
+	require.Equal(t, content.Attributes, verified.Attributes)
+	require.Equal(t, content.Fingerprint, verified.Fingerprint)
+
+	// a different fabric does not trust the card
+	otherFabricCert, err := bootstrap.CreateFabricCertificate(0)
+	require.Nil(t, err)
+	_, err = bootstrap.VerifyPeerCard(card, otherFabricCert.RawCert())
+	require.NotNil(t, err)
+
+	// tampered content fails the signature check
+	tampered := *card
+	tampered.Content = []byte(string(card.Content[:len(card.Content)-1]) + " }")
+	_, err = bootstrap.VerifyPeerCard(&tampered, fabricCert.RawCert())
+	require.NotNil(t, err)
+
+	// a card signed by another peer certificate, claiming to be peer1
+	otherPeerCert, err := bootstrap.CreatePeerCertificate("peer2", fabricCert, 0)
+	require.Nil(t, err)
+	forged := *card
+	forged.Certificate = otherPeerCert.RawCert()
+	_, err = bootstrap.VerifyPeerCard(&forged, fabricCert.RawCert())
+	require.NotNil(t, err)
+
+	// certificate without a private key cannot sign cards
+	publicCert, err := bootstrap.CertificateFromRaw(peerCert.RawCert(), nil)
+	require.Nil(t, err)
+	_, err = bootstrap.CreatePeerCard(content, publicCert)
+	require.NotNil(t, err)
+}
@@ -14,10 +14,17 @@
 package bootstrap
 
 import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
 	"github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
 	dpapi "github.com/clusterlink-net/clusterlink/pkg/dataplane/api"
 )
 
+// DefaultValidity is the validity period of certificates created without an explicit validity.
+const DefaultValidity = 10 * 365 * 24 * time.Hour
+
 // Certificate represents a clusterlink certificate.
 type Certificate struct {
 	cert *certificate
@@ -37,11 +44,17 @@ func (c *Certificate) RawKey() []byte {
 	return c.cert.keyPEM
 }
 
+// X509 returns the parsed x509 certificate.
+func (c *Certificate) X509() *x509.Certificate {
+	return c.cert.cert
+}
+
 // CreateFabricCertificate creates a clusterlink fabric (root) certificate.
-func CreateFabricCertificate() (*Certificate, error) {
+func CreateFabricCertificate(validity time.Duration) (*Certificate, error) {
 	cert, err := createCertificate(&certificateConfig{
-		Name: "root",
-		IsCA: true,
+		Name:     "root",
+		IsCA:     true,
+		Validity: validity,
 	})
 	if err != nil {
 		return nil, err
@@ -51,12 +64,78 @@ func CreateFabricCertificate() (*Certificate, error) {
 }
 
 // CreatePeerCertificate creates a peer certificate.
-func CreatePeerCertificate(name string, fabricCert *Certificate) (*Certificate, error) {
+func CreatePeerCertificate(name string, fabricCert *Certificate, validity time.Duration) (*Certificate, error) {
 	cert, err := createCertificate(&certificateConfig{
 		Parent:   fabricCert.cert,
 		Name:     name,
 		IsCA:     true,
 		DNSNames: []string{name},
+		Validity: validity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Certificate{cert: cert}, nil
+}
+
+// CertificateRequest represents a certificate signing request, along with its private key.
+type CertificateRequest struct {
+	csrPEM []byte
+	keyPEM []byte
+}
+
+// RawRequest returns the raw certificate signing request bytes.
+func (r *CertificateRequest) RawRequest() []byte {
+	return r.csrPEM
+}
+
+// RawKey returns the raw private key bytes.
+func (r *CertificateRequest) RawKey() []byte {
+	return r.keyPEM
+}
+
+// CreatePeerCertificateRequest creates a certificate signing request for a peer certificate.
+func CreatePeerCertificateRequest(name string) (*CertificateRequest, error) {
+	csrPEM, keyPEM, err := createCertificateRequest(name, []string{name})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CertificateRequest{csrPEM: csrPEM, keyPEM: keyPEM}, nil
+}
+
+// SignPeerCertificateRequest signs a peer certificate signing request, yielding a peer certificate without a private key.
+// The request must be for the given peer name, and may not ask for any other identity.
+func SignPeerCertificateRequest(
+	rawRequest []byte, name string, fabricCert *Certificate, validity time.Duration,
+) (*Certificate, error) {
+	csr, err := parseCertificateRequest(rawRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if csr.Subject.CommonName != name {
+		return nil, fmt.Errorf("certificate request is for '%s', expected '%s'", csr.Subject.CommonName, name)
+	}
+
+	for _, dnsName := range csr.DNSNames {
+		if dnsName != name {
+			return nil, fmt.Errorf("certificate request contains unexpected DNS name '%s'", dnsName)
+		}
+	}
+
+	if len(csr.IPAddresses) > 0 || len(csr.URIs) > 0 || len(csr.EmailAddresses) > 0 {
+		return nil, fmt.Errorf("certificate request contains unexpected subject alternative names")
+	}
+
+	cert, err := createCertificate(&certificateConfig{
+		Parent:    fabricCert.cert,
+		Name:      name,
+		IsCA:      true,
+		DNSNames:  []string{name},
+		Validity:  validity,
+		PublicKey: csr.PublicKey,
 	})
 	if err != nil {
 		return nil, err
@@ -65,14 +144,45 @@ func CreatePeerCertificate(name string, fabricCert *Certificate) (*Certificate,
 	return &Certificate{cert: cert}, nil
 }
 
+// VerifyPeerCertificate verifies that a peer certificate was issued for the given peer name
+// by one of the given (PEM-encoded) fabric certificates.
+func VerifyPeerCertificate(peerCert *Certificate, name string, rawFabricCerts []byte) error {
+	fabricCerts, err := parseCertificates(rawFabricCerts)
+	if err != nil {
+		return err
+	}
+
+	roots := x509.NewCertPool()
+	for _, cert := range fabricCerts {
+		roots.AddCert(cert)
+	}
+
+	cert := peerCert.cert.cert
+	if !cert.IsCA || cert.Subject.CommonName != name ||
+		len(cert.PermittedDNSDomains) != 1 || cert.PermittedDNSDomains[0] != name {
+		return fmt.Errorf("certificate is not a peer certificate for '%s'", name)
+	}
+
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("certificate is not signed by the fabric: %w", err)
+	}
+
+	return nil
+}
+
 // CreatePeerCertificate creates a controlplane certificate.
-func CreateControlplaneCertificate(peer string, peerCert *Certificate) (*Certificate, error) {
+func CreateControlplaneCertificate(peer string, peerCert *Certificate, validity time.Duration) (*Certificate, error) {
 	cert, err := createCertificate(&certificateConfig{
 		Parent:   peerCert.cert,
 		Name:     "cl-controlplane",
 		IsServer: true,
 		IsClient: true,
 		DNSNames: []string{peer, api.GRPCServerName(peer)},
+		Validity: validity,
 	})
 	if err != nil {
 		return nil, err
@@ -82,13 +192,14 @@ func CreateControlplaneCertificate(peer string, peerCert *Certificate) (*Certifi
 }
 
 // CreatePeerCertificate creates a dataplane certificate.
-func CreateDataplaneCertificate(peer string, peerCert *Certificate) (*Certificate, error) {
+func CreateDataplaneCertificate(peer string, peerCert *Certificate, validity time.Duration) (*Certificate, error) {
 	cert, err := createCertificate(&certificateConfig{
 		Parent:   peerCert.cert,
 		Name:     "cl-dataplane",
 		IsServer: true,
 		IsClient: true,
 		DNSNames: []string{dpapi.DataplaneServerName(peer)},
+		Validity: validity,
 	})
 	if err != nil {
 		return nil, err
@@ -98,11 +209,12 @@ func CreateDataplaneCertificate(peer string, peerCert *Certificate) (*Certificat
 }
 
 // CreatePeerCertificate creates a gwctl certificate.
-func CreateGWCTLCertificate(peerCert *Certificate) (*Certificate, error) {
+func CreateGWCTLCertificate(peerCert *Certificate, validity time.Duration) (*Certificate, error) {
 	cert, err := createCertificate(&certificateConfig{
 		Parent:   peerCert.cert,
 		Name:     "gwctl",
 		IsClient: true,
+		Validity: validity,
 	})
 	if err != nil {
 		return nil, err
@@ -112,6 +224,7 @@ func CreateGWCTLCertificate(peerCert *Certificate) (*Certificate, error) {
 }
 
 // CertificateFromRaw initializes a certificate from raw data.
+// rawKey may be nil for a certificate whose private key is not available.
 func CertificateFromRaw(rawCert, rawKey []byte) (*Certificate, error) {
 	cert, err := certificateFromRaw(rawCert, rawKey)
 	if err != nil {
@@ -120,3 +233,24 @@ func CertificateFromRaw(rawCert, rawKey []byte) (*Certificate, error) {
 
 	return &Certificate{cert: cert}, nil
 }
+
+// RemainingValidity returns the time left (relative to now) until the certificate expires.
+// A non-positive value indicates an expired certificate.
+func RemainingValidity(cert *x509.Certificate, now time.Time) time.Duration {
+	return cert.NotAfter.Sub(now)
+}
+
+// NeedsRenewal returns true if the certificate is expired, not yet valid,
+// or expires within the given renewal window (relative to now).
+func NeedsRenewal(cert *x509.Certificate, now time.Time, window time.Duration) bool {
+	if now.Before(cert.NotBefore) {
+		return true
+	}
+
+	return RemainingValidity(cert, now) <= window
+}
+
+// ParseCertificates parses all PEM-encoded certificates in the given data (e.g. a certificate chain or a trust bundle).
+func ParseCertificates(rawCert []byte) ([]*x509.Certificate, error) {
+	return parseCertificates(rawCert)
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bootstrap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
+)
+
+func TestSignPeerCertificateRequest(t *testing.T) {
+	fabricCert, err := bootstrap.CreateFabricCertificate(0)
+	require.Nil(t, err)
+
+	request, err := bootstrap.CreatePeerCertificateRequest("peer1")
+	require.Nil(t, err)
+
+	// request must match the expected peer name
+	_, err = bootstrap.SignPeerCertificateRequest(request.RawRequest(), "peer2", fabricCert, time.Hour)
+	require.NotNil(t, err)
+
+	signedCert, err := bootstrap.SignPeerCertificateRequest(request.RawRequest(), "peer1", fabricCert, time.Hour)
+	require.Nil(t, err)
+	require.Empty(t, signedCert.RawKey())
+	require.WithinDuration(t, time.Now().Add(time.Hour), signedCert.X509().NotAfter, time.Minute)
+
+	// combine signed certificate with the locally generated key
+	peerCert, err := bootstrap.CertificateFromRaw(signedCert.RawCert(), request.RawKey())
+	require.Nil(t, err)
+	require.Nil(t, bootstrap.VerifyPeerCertificate(peerCert, "peer1", fabricCert.RawCert()))
+	require.NotNil(t, bootstrap.VerifyPeerCertificate(peerCert, "peer2", fabricCert.RawCert()))
+
+	// peer certificate can sign component certificates
+	_, err = bootstrap.CreateControlplaneCertificate("peer1", peerCert, 0)
+	require.Nil(t, err)
+
+	// a different fabric does not trust the peer
+	otherFabricCert, err := bootstrap.CreateFabricCertificate(0)
+	require.Nil(t, err)
+	require.NotNil(t, bootstrap.VerifyPeerCertificate(peerCert, "peer1", otherFabricCert.RawCert()))
+
+	// private key must match the certificate
+	_, err = bootstrap.CertificateFromRaw(signedCert.RawCert(), otherFabricCert.RawKey())
+	require.NotNil(t, err)
+}
+
+func TestCertificateValidity(t *testing.T) {
+	// zero validity defaults to DefaultValidity
+	fabricCert, err := bootstrap.CreateFabricCertificate(0)
+	require.Nil(t, err)
+	require.WithinDuration(t, time.Now().Add(bootstrap.DefaultValidity), fabricCert.X509().NotAfter, time.Minute)
+
+	peerCert, err := bootstrap.CreatePeerCertificate("peer1", fabricCert, 48*time.Hour)
+	require.Nil(t, err)
+	require.WithinDuration(t, time.Now().Add(48*time.Hour), peerCert.X509().NotAfter, time.Minute)
+
+	// a certificate cannot outlive its issuer
+	controlplaneCert, err := bootstrap.CreateControlplaneCertificate("peer1", peerCert, 0)
+	require.Nil(t, err)
+	require.Equal(t, peerCert.X509().NotAfter, controlplaneCert.X509().NotAfter)
+
+	cert := peerCert.X509()
+	now := cert.NotBefore
+
+	require.Equal(t, cert.NotAfter.Sub(now), bootstrap.RemainingValidity(cert, now))
+	require.True(t, bootstrap.RemainingValidity(cert, cert.NotAfter.Add(time.Second)) < 0)
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		window time.Duration
+		renew  bool
+	}{
+		{name: "valid outside window", now: now, window: 24 * time.Hour, renew: false},
+		{name: "valid inside window", now: cert.NotAfter.Add(-12 * time.Hour), window: 24 * time.Hour, renew: true},
+		{name: "window boundary", now: cert.NotAfter.Add(-24 * time.Hour), window: 24 * time.Hour, renew: true},
+		{name: "no window", now: cert.NotAfter.Add(-time.Hour), window: 0, renew: false},
+		{name: "expired", now: cert.NotAfter.Add(time.Hour), window: 0, renew: true},
+		{name: "not yet valid", now: now.Add(-time.Hour), window: 0, renew: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.renew, bootstrap.NeedsRenewal(cert, tt.now, tt.window))
+		})
+	}
+}
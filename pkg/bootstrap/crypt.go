@@ -15,6 +15,7 @@ package bootstrap
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -51,6 +52,14 @@ type certificateConfig struct {
 	// For a CA certificate, these are the permitted DNS names.
 	DNSNames []string
 
+	// Validity is the period (starting now) in which the certificate is valid.
+	// If zero, DefaultValidity is used.
+	Validity time.Duration
+
+	// PublicKey is the public key to certify, taken from a certificate signing request.
+	// If nil, a new key pair is generated.
+	PublicKey crypto.PublicKey
+
 	// Parent certificate that will sign the certificate.
 	// If nil, certificate will self-sign.
 	Parent *certificate
@@ -58,21 +67,34 @@ type certificateConfig struct {
 
 // createCertificate creates a signed certificate.
 func createCertificate(config *certificateConfig) (*certificate, error) {
-	// generate key pair
-	key, err := rsa.GenerateKey(rand.Reader, 4096)
-	if err != nil {
-		return nil, err
+	var key *rsa.PrivateKey
+	publicKey := config.PublicKey
+	if publicKey == nil {
+		// generate key pair
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 4096)
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey = &key.PublicKey
 	}
 
 	// RNG for generating certificate serial number.
 	//#nosec G404 -- certificate serial number does not need secure random
 	rng := mathrand.New(mathrand.NewSource(time.Now().UTC().UnixNano()))
 
+	validity := config.Validity
+	if validity == 0 {
+		validity = DefaultValidity
+	}
+
 	// create certificate
+	now := time.Now()
 	cert := &x509.Certificate{
 		SerialNumber: big.NewInt(rng.Int63()),
-		NotBefore:    time.Now(),
-		NotAfter:     time.Now().AddDate(10, 0, 0),
+		NotBefore:    now,
+		NotAfter:     now.Add(validity),
 		IsCA:         config.IsCA,
 		Subject:      pkix.Name{CommonName: config.Name},
 	}
@@ -99,13 +121,22 @@ func createCertificate(config *certificateConfig) (*certificate, error) {
 	if config.Parent != nil {
 		ca = config.Parent.cert
 		caKey = config.Parent.key
+
+		// a certificate cannot outlive its issuer
+		if cert.NotAfter.After(ca.NotAfter) {
+			cert.NotAfter = ca.NotAfter
+		}
 	} else {
 		ca = cert
 		caKey = key
 	}
 
+	if caKey == nil {
+		return nil, fmt.Errorf("missing private key for signing the certificate")
+	}
+
 	// sign certificate
-	certBytes, err := x509.CreateCertificate(rand.Reader, cert, ca, &key.PublicKey, caKey)
+	certBytes, err := x509.CreateCertificate(rand.Reader, cert, ca, publicKey, caKey)
 	if err != nil {
 		return nil, err
 	}
@@ -121,13 +152,12 @@ func createCertificate(config *certificateConfig) (*certificate, error) {
 	}
 
 	// PEM encode private key
-	keyPEM := new(bytes.Buffer)
-	err = pem.Encode(keyPEM, &pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(key),
-	})
-	if err != nil {
-		return nil, err
+	var keyPEM []byte
+	if key != nil {
+		keyPEM, err = encodeKey(key)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	signedCert, err := x509.ParseCertificate(certBytes)
@@ -140,23 +170,72 @@ func createCertificate(config *certificateConfig) (*certificate, error) {
 		cert:    signedCert,
 		key:     key,
 		certPEM: certPEM.Bytes(),
-		keyPEM:  keyPEM.Bytes(),
+		keyPEM:  keyPEM,
 	}, nil
 }
 
-// certificateFromRaw initializes a certificate from raw data.
-func certificateFromRaw(certPEM, keyPEM []byte) (*certificate, error) {
-	block, _ := pem.Decode(keyPEM)
-	if block == nil {
-		return nil, fmt.Errorf("key is not in PEM format")
+// encodeKey PEM-encodes a private key.
+func encodeKey(key *rsa.PrivateKey) ([]byte, error) {
+	keyPEM := new(bytes.Buffer)
+	err := pem.Encode(keyPEM, &pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keyPEM.Bytes(), nil
+}
+
+// createCertificateRequest creates a certificate signing request, along with a new private key.
+func createCertificateRequest(name string, dnsNames []string) (csrPEM, keyPEM []byte, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, 4096)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: name},
+		DNSNames: dnsNames,
+	}
+
+	csrBytes, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM, err = encodeKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrBytes}), keyPEM, nil
+}
+
+// parseCertificateRequest parses and verifies the signature of a PEM-encoded certificate signing request.
+func parseCertificateRequest(csrPEM []byte) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, fmt.Errorf("certificate request is not in PEM format")
 	}
 
-	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
 	if err != nil {
 		return nil, err
 	}
 
-	block, _ = pem.Decode(certPEM)
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid certificate request signature: %w", err)
+	}
+
+	return csr, nil
+}
+
+// certificateFromRaw initializes a certificate from raw data.
+// keyPEM may be nil for a certificate whose private key is not available.
+func certificateFromRaw(certPEM, keyPEM []byte) (*certificate, error) {
+	block, _ := pem.Decode(certPEM)
 	if block == nil {
 		return nil, fmt.Errorf("certificate is not in PEM format")
 	}
@@ -166,6 +245,23 @@ func certificateFromRaw(certPEM, keyPEM []byte) (*certificate, error) {
 		return nil, err
 	}
 
+	var key *rsa.PrivateKey
+	if keyPEM != nil {
+		block, _ = pem.Decode(keyPEM)
+		if block == nil {
+			return nil, fmt.Errorf("key is not in PEM format")
+		}
+
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		if !key.PublicKey.Equal(cert.PublicKey) {
+			return nil, fmt.Errorf("private key does not match certificate")
+		}
+	}
+
 	return &certificate{
 		parent:  nil,
 		cert:    cert,
@@ -174,3 +270,32 @@ func certificateFromRaw(certPEM, keyPEM []byte) (*certificate, error) {
 		keyPEM:  keyPEM,
 	}, nil
 }
+
+// parseCertificates parses all PEM-encoded certificates in the given data.
+func parseCertificates(certPEM []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, certPEM = pem.Decode(certPEM)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM certificates found")
+	}
+
+	return certs, nil
+}
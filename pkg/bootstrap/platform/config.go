@@ -26,6 +26,9 @@ type Config struct {
 
 	// FabricCertificate is the fabric certificate.
 	FabricCertificate *bootstrap.Certificate
+	// FabricTrustBundle holds the trusted fabric CA certificates during a fabric CA rotation.
+	// If empty, only FabricCertificate is trusted.
+	FabricTrustBundle []byte
 	// PeerCertificate is the peer certificate.
 	PeerCertificate *bootstrap.Certificate
 	// ControlplaneCertificate is the controlplane certificate.
@@ -46,6 +49,8 @@ type Config struct {
 	ContainerRegistry string
 	// IngressType is the type of ingress to create.
 	IngressType string
+	// IngressPort is the port of the ingress. If 0, the default port of the ingress type is used.
+	IngressPort int32
 	// CRDMode indicates a CRD-based controlplane.
 	CRDMode bool
 }
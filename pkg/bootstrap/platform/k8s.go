@@ -272,7 +272,7 @@ metadata:
 rules:
 - apiGroups: [""]
   resources: ["services"]
-  verbs: ["create", "delete", "update"]
+  verbs: ["create", "delete", "get", "update"]
 - apiGroups: [""]
   resources: ["pods"]
   verbs: ["get", "list", "watch"]
@@ -305,6 +305,9 @@ spec:
     replicas: {{.dataplanes}}
   ingress:
     type: {{.ingressType}}
+{{- if .ingressPort }}
+    port: {{.ingressPort}}
+{{- end }}
   logLevel: {{.logLevel}}
   containerRegistry: {{.containerRegistry}}
   namespace: {{.namespace}}
@@ -363,8 +366,13 @@ func K8SConfig(config *Config) ([]byte, error) {
 
 // K8SCertificateConfig returns a kubernetes secrets that contains all the certificates.
 func K8SCertificateConfig(config *Config) ([]byte, error) {
+	fabricCA := config.FabricTrustBundle
+	if len(fabricCA) == 0 {
+		fabricCA = config.FabricCertificate.RawCert()
+	}
+
 	args := map[string]interface{}{
-		"fabricCA":         base64.StdEncoding.EncodeToString(config.FabricCertificate.RawCert()),
+		"fabricCA":         base64.StdEncoding.EncodeToString(fabricCA),
 		"peerCA":           base64.StdEncoding.EncodeToString(config.PeerCertificate.RawCert()),
 		"controlplaneCert": base64.StdEncoding.EncodeToString(config.ControlplaneCertificate.RawCert()),
 		"controlplaneKey":  base64.StdEncoding.EncodeToString(config.ControlplaneCertificate.RawKey()),
@@ -398,6 +406,7 @@ func K8SClusterLinkInstanceConfig(config *Config) ([]byte, error) {
 		"containerRegistry": containerRegistry,
 		"namespace":         config.Namespace,
 		"ingressType":       config.IngressType,
+		"ingressPort":       config.IngressPort,
 	}
 
 	var clConfig bytes.Buffer
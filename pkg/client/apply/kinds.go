@@ -0,0 +1,220 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	"github.com/clusterlink-net/clusterlink/pkg/client"
+	"github.com/clusterlink-net/clusterlink/pkg/util/rest"
+)
+
+// Kind describes how to apply a kind of objects declared in a manifest.
+type Kind struct {
+	// Name of the kind, as given in manifests.
+	Name string
+	// client returns the REST client of the kind.
+	client func(g *client.Client) *rest.Client
+	// decode an object from its JSON representation in a manifest.
+	decode func(data []byte) (any, error)
+	// key identifying an object.
+	key func(object any) string
+	// labels of an object.
+	labels func(object any) map[string]string
+	// state returns the declared state of an object, as compared and displayed.
+	state func(object any) any
+	// list returns the listed objects, given the result of rest.Client.List.
+	list func(list any) []any
+	// deleteArg is the argument for deleting an object using rest.Client.Delete.
+	deleteArg func(object any) any
+}
+
+// kinds are the kinds of objects which can be applied, in the order of creation.
+// Objects are pruned in the reverse order.
+var kinds = []*Kind{
+	{
+		Name:   "Peer",
+		client: func(g *client.Client) *rest.Client { return g.Peers },
+		decode: func(data []byte) (any, error) {
+			var pr api.Peer
+			return &pr, json.Unmarshal(data, &pr)
+		},
+		key:    func(object any) string { return object.(*api.Peer).Name },
+		labels: func(object any) map[string]string { return object.(*api.Peer).Labels },
+		state: func(object any) any {
+			pr := object.(*api.Peer)
+			return labeledState(pr.Labels, pr.Spec)
+		},
+		list: func(list any) []any {
+			var objects []any
+			for i := range *list.(*[]api.Peer) {
+				objects = append(objects, &(*list.(*[]api.Peer))[i])
+			}
+			return objects
+		},
+		deleteArg: func(object any) any { return object.(*api.Peer).Name },
+	},
+	{
+		Name:   "Export",
+		client: func(g *client.Client) *rest.Client { return g.Exports },
+		decode: func(data []byte) (any, error) {
+			var export api.Export
+			return &export, json.Unmarshal(data, &export)
+		},
+		key:    func(object any) string { return object.(*api.Export).Name },
+		labels: func(object any) map[string]string { return object.(*api.Export).Labels },
+		state: func(object any) any {
+			export := object.(*api.Export)
+			return labeledState(export.Labels, export.Spec)
+		},
+		list: func(list any) []any {
+			var objects []any
+			for i := range *list.(*[]api.Export) {
+				objects = append(objects, &(*list.(*[]api.Export))[i])
+			}
+			return objects
+		},
+		deleteArg: func(object any) any { return object.(*api.Export).Name },
+	},
+	{
+		Name:   "Import",
+		client: func(g *client.Client) *rest.Client { return g.Imports },
+		decode: func(data []byte) (any, error) {
+			var imp api.Import
+			return &imp, json.Unmarshal(data, &imp)
+		},
+		key:    func(object any) string { return object.(*api.Import).Name },
+		labels: func(object any) map[string]string { return object.(*api.Import).Labels },
+		state: func(object any) any {
+			imp := object.(*api.Import)
+			return labeledState(imp.Labels, imp.Spec)
+		},
+		list: func(list any) []any {
+			var objects []any
+			for i := range *list.(*[]api.Import) {
+				objects = append(objects, &(*list.(*[]api.Import))[i])
+			}
+			return objects
+		},
+		deleteArg: func(object any) any { return object.(*api.Import).Name },
+	},
+	{
+		Name:      "AccessPolicy",
+		client:    func(g *client.Client) *rest.Client { return g.AccessPolicies },
+		decode:    decodeManifestPolicy,
+		key:       func(object any) string { return object.(*api.Policy).Name },
+		labels:    func(object any) map[string]string { return object.(*api.Policy).Labels },
+		state:     policyState,
+		list:      listPolicies,
+		deleteArg: func(object any) any { return object.(*api.Policy).Name },
+	},
+	{
+		Name:      "LBPolicy",
+		client:    func(g *client.Client) *rest.Client { return g.LBPolicies },
+		decode:    decodeManifestPolicy,
+		key:       func(object any) string { return object.(*api.Policy).Name },
+		labels:    func(object any) map[string]string { return object.(*api.Policy).Labels },
+		state:     policyState,
+		list:      listPolicies,
+		deleteArg: func(object any) any { return object.(*api.Policy).Name },
+	},
+	{
+		// bindings are created last, as they refer to peers and imports
+		Name:   "Binding",
+		client: func(g *client.Client) *rest.Client { return g.Bindings },
+		decode: func(data []byte) (any, error) {
+			var binding api.Binding
+			return &binding, json.Unmarshal(data, &binding)
+		},
+		key: func(object any) string {
+			binding := object.(*api.Binding)
+			return binding.Spec.Import + "/" + binding.Spec.Peer
+		},
+		labels: func(any) map[string]string { return nil },
+		state:  func(object any) any { return object.(*api.Binding).Spec },
+		list: func(list any) []any {
+			var objects []any
+			for i := range *list.(*[]api.Binding) {
+				objects = append(objects, &(*list.(*[]api.Binding))[i])
+			}
+			return objects
+		},
+		deleteArg: func(object any) any { return object },
+	},
+}
+
+// labeledState returns the state of an object with labels, ignoring empty labels.
+func labeledState(objectLabels map[string]string, spec any) any {
+	if len(objectLabels) == 0 {
+		objectLabels = nil
+	}
+
+	return struct {
+		Labels map[string]string `json:",omitempty"`
+		Spec   any
+	}{objectLabels, spec}
+}
+
+// policyState returns the state of a policy, where its JSON blob is shown as is rather than base64-encoded.
+func policyState(object any) any {
+	policy := object.(*api.Policy)
+
+	var blob any
+	if err := json.Unmarshal(policy.Spec.Blob, &blob); err != nil {
+		blob = policy.Spec.Blob
+	}
+
+	return labeledState(policy.Labels, blob)
+}
+
+// listPolicies returns the listed policies.
+func listPolicies(list any) []any {
+	var objects []any
+	for i := range *list.(*[]api.Policy) {
+		objects = append(objects, &(*list.(*[]api.Policy))[i])
+	}
+	return objects
+}
+
+// decodeManifestPolicy decodes a policy, whose content is given as an object in 'spec.policy',
+// or base64-encoded in 'spec.blob' (as in backups).
+func decodeManifestPolicy(data []byte) (any, error) {
+	var manifest struct {
+		Name   string
+		Labels map[string]string
+		Spec   struct {
+			Policy json.RawMessage
+			Blob   []byte
+		}
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	blob := manifest.Spec.Blob
+	if len(manifest.Spec.Policy) > 0 {
+		blob = manifest.Spec.Policy
+	}
+	if len(blob) == 0 {
+		return nil, fmt.Errorf("missing spec.policy")
+	}
+
+	return &api.Policy{
+		Name:   manifest.Name,
+		Labels: manifest.Labels,
+		Spec:   api.PolicySpec{Blob: blob},
+	}, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Object is an object declared in a manifest.
+type Object struct {
+	kind   *Kind
+	object any
+	// source of the object, for error messages.
+	source string
+}
+
+// documentSeparator separates the documents of a multi-document YAML.
+var documentSeparator = regexp.MustCompile(`(?m)^---[ \t]*$`)
+
+// ReadManifests reads the objects declared in the given files, or in the YAML and JSON files of the given directories.
+func ReadManifests(paths []string) ([]*Object, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			switch filepath.Ext(entry.Name()) {
+			case ".yaml", ".yml", ".json":
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+	}
+
+	var objects []*Object
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, document := range documentSeparator.Split(string(data), -1) {
+			if strings.TrimSpace(document) == "" {
+				continue
+			}
+
+			source := fmt.Sprintf("%s (document %d)", file, i+1)
+			object, err := DecodeObject([]byte(document))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", source, err)
+			}
+
+			object.source = source
+			objects = append(objects, object)
+		}
+	}
+
+	return objects, nil
+}
+
+// DecodeObject decodes a single manifest document.
+func DecodeObject(document []byte) (*Object, error) {
+	// YAML is a superset of JSON, so this handles both formats
+	data, err := yaml.YAMLToJSON(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var header struct {
+		Kind string
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	for _, kind := range kinds {
+		if !strings.EqualFold(kind.Name, header.Kind) {
+			continue
+		}
+
+		object, err := kind.decode(data)
+		if err != nil {
+			return nil, err
+		}
+
+		// binding keys are of the form import/peer
+		key := kind.key(object)
+		if key == "" || strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/") {
+			return nil, fmt.Errorf("%s is missing a name", kind.Name)
+		}
+
+		return &Object{kind: kind, object: object}, nil
+	}
+
+	if header.Kind == "" {
+		return nil, fmt.Errorf("missing kind")
+	}
+
+	return nil, fmt.Errorf("unknown kind '%s'", header.Kind)
+}
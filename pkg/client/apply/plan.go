@@ -0,0 +1,153 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/clusterlink-net/clusterlink/pkg/client"
+	"github.com/clusterlink-net/clusterlink/pkg/util/rest"
+)
+
+// Action is an action of an apply plan.
+type Action string
+
+// Actions of an apply plan.
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// Step is a single step of an apply plan.
+type Step struct {
+	// Kind of the object.
+	Kind *Kind
+	// Action of the step.
+	Action Action
+	// Name of the object.
+	Name string
+	// Before and After are the states of the object before and after the step.
+	Before any
+	After  any
+
+	// object to create, update or delete.
+	object any
+}
+
+// Apply performs the step using the given client.
+func (s *Step) Apply(g *client.Client) error {
+	restClient := s.Kind.client(g)
+	switch s.Action {
+	case Create:
+		return restClient.Create(s.object)
+	case Update:
+		return restClient.Update(s.object)
+	case Delete:
+		return restClient.Delete(s.Kind.deleteArg(s.object))
+	}
+
+	return fmt.Errorf("unknown action '%s'", s.Action)
+}
+
+// ListFunc returns the current objects of a kind, in the form returned by rest.Client.List.
+type ListFunc func(kind *Kind) (any, error)
+
+// ClientList returns a ListFunc which lists objects using the given client.
+func ClientList(g *client.Client) ListFunc {
+	return func(kind *Kind) (any, error) {
+		return kind.client(g).List()
+	}
+}
+
+// Plan returns the steps for applying the manifest objects: creates and updates in dependency order,
+// followed by deletes (if pruning) in reverse order.
+// Only objects matching the selector are pruned, and objects without labels (bindings) are not pruned
+// if the selector is not empty.
+func Plan(objects []*Object, list ListFunc, prune bool, selector labels.Selector) ([]*Step, error) {
+	declared := make(map[*Kind]map[string]*Object)
+	for _, object := range objects {
+		if declared[object.kind] == nil {
+			declared[object.kind] = make(map[string]*Object)
+		}
+
+		key := object.kind.key(object.object)
+		if previous, ok := declared[object.kind][key]; ok {
+			return nil, fmt.Errorf("%s '%s' is declared twice: in %s and in %s",
+				object.kind.Name, key, previous.source, object.source)
+		}
+		declared[object.kind][key] = object
+	}
+
+	var applySteps, pruneSteps []*Step
+	for _, kind := range kinds {
+		if declared[kind] == nil {
+			continue
+		}
+
+		listed, err := list(kind)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list %s objects: %w", kind.Name, err)
+		}
+
+		current := make(map[string]any)
+		for _, object := range kind.list(listed) {
+			current[kind.key(object)] = object
+		}
+
+		for _, key := range rest.SortedKeys(declared[kind]) {
+			object := declared[kind][key].object
+			after := kind.state(object)
+
+			existing, ok := current[key]
+			if !ok {
+				applySteps = append(applySteps,
+					&Step{Kind: kind, Action: Create, Name: key, object: object, After: after})
+				continue
+			}
+
+			before := kind.state(existing)
+			if !rest.SameJSON(before, after) {
+				applySteps = append(applySteps,
+					&Step{Kind: kind, Action: Update, Name: key, object: object, Before: before, After: after})
+			}
+		}
+
+		if !prune {
+			continue
+		}
+
+		var kindPruneSteps []*Step
+		for _, key := range rest.SortedKeys(current) {
+			object := current[key]
+			if _, ok := declared[kind][key]; ok {
+				continue
+			}
+
+			if !selector.Empty() && (kind.labels(object) == nil || !selector.Matches(labels.Set(kind.labels(object)))) {
+				continue
+			}
+
+			kindPruneSteps = append(kindPruneSteps,
+				&Step{Kind: kind, Action: Delete, Name: key, object: object, Before: kind.state(object)})
+		}
+
+		// prune in the reverse order of creation
+		pruneSteps = append(kindPruneSteps, pruneSteps...)
+	}
+
+	return append(applySteps, pruneSteps...), nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	"github.com/clusterlink-net/clusterlink/pkg/client/apply"
+)
+
+const manifest = `
+kind: Binding
+spec:
+  import: imp1
+  peer: peer1
+---
+kind: Import
+name: imp1
+labels:
+  app: demo
+spec:
+  service:
+    host: imp1
+    port: 80
+---
+kind: Peer
+name: peer1
+labels:
+  app: demo
+spec:
+  gateways:
+    - host: peer1.example.com
+      port: 443
+`
+
+// current returns a ListFunc listing the given objects.
+func current(peers []api.Peer, imports []api.Import, bindings []api.Binding) apply.ListFunc {
+	return func(kind *apply.Kind) (any, error) {
+		switch kind.Name {
+		case "Peer":
+			return &peers, nil
+		case "Import":
+			return &imports, nil
+		case "Binding":
+			return &bindings, nil
+		}
+		return nil, fmt.Errorf("unexpected kind %s", kind.Name)
+	}
+}
+
+// readManifest returns the objects of the test manifest.
+func readManifest(t *testing.T) []*apply.Object {
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	require.Nil(t, os.WriteFile(path, []byte(manifest), 0o600))
+
+	objects, err := apply.ReadManifests([]string{path})
+	require.Nil(t, err)
+	require.Len(t, objects, 3)
+	return objects
+}
+
+// summary returns the kind, name and action of each step.
+func summary(steps []*apply.Step) [][3]string {
+	var result [][3]string
+	for _, step := range steps {
+		result = append(result, [3]string{step.Kind.Name, step.Name, string(step.Action)})
+	}
+	return result
+}
+
+func TestPlanOrder(t *testing.T) {
+	objects := readManifest(t)
+
+	// objects are created in dependency order, regardless of their order in the manifest
+	steps, err := apply.Plan(objects, current(nil, nil, nil), true, labels.Everything())
+	require.Nil(t, err)
+	require.Equal(t, [][3]string{
+		{"Peer", "peer1", "create"},
+		{"Import", "imp1", "create"},
+		{"Binding", "imp1/peer1", "create"},
+	}, summary(steps))
+
+	// an up-to-date object is not changed, and stale objects are pruned in the reverse order of creation
+	peers := []api.Peer{
+		{
+			Name:   "peer1",
+			Labels: map[string]string{"app": "demo"},
+			Spec:   api.PeerSpec{Gateways: []api.Endpoint{{Host: "peer1.example.com", Port: 443}}},
+		},
+		{Name: "peer2"},
+	}
+	imports := []api.Import{
+		{Name: "imp1", Spec: api.ImportSpec{Service: api.Endpoint{Host: "imp1", Port: 8080}}},
+		{Name: "imp2"},
+	}
+	bindings := []api.Binding{{Spec: api.BindingSpec{Import: "imp2", Peer: "peer2"}}}
+
+	steps, err = apply.Plan(objects, current(peers, imports, bindings), true, labels.Everything())
+	require.Nil(t, err)
+	require.Equal(t, [][3]string{
+		{"Import", "imp1", "update"},
+		{"Binding", "imp1/peer1", "create"},
+		{"Binding", "imp2/peer2", "delete"},
+		{"Import", "imp2", "delete"},
+		{"Peer", "peer2", "delete"},
+	}, summary(steps))
+
+	// nothing is pruned unless requested
+	steps, err = apply.Plan(objects, current(peers, imports, bindings), false, labels.Everything())
+	require.Nil(t, err)
+	require.Equal(t, [][3]string{
+		{"Import", "imp1", "update"},
+		{"Binding", "imp1/peer1", "create"},
+	}, summary(steps))
+}
+
+func TestPlanPruneSelector(t *testing.T) {
+	objects := readManifest(t)
+
+	peers := []api.Peer{
+		{Name: "peer2", Labels: map[string]string{"app": "demo"}},
+		{Name: "peer3", Labels: map[string]string{"app": "other"}},
+		{Name: "peer4"},
+	}
+	imports := []api.Import{
+		{Name: "imp2", Labels: map[string]string{"app": "demo"}},
+		{Name: "imp3"},
+	}
+	bindings := []api.Binding{{Spec: api.BindingSpec{Import: "imp2", Peer: "peer2"}}}
+
+	selector, err := labels.Parse("app=demo")
+	require.Nil(t, err)
+
+	// only matching objects are pruned, and bindings (which have no labels) are kept
+	steps, err := apply.Plan(objects, current(peers, imports, bindings), true, selector)
+	require.Nil(t, err)
+	require.Equal(t, [][3]string{
+		{"Peer", "peer1", "create"},
+		{"Import", "imp1", "create"},
+		{"Binding", "imp1/peer1", "create"},
+		{"Import", "imp2", "delete"},
+		{"Peer", "peer2", "delete"},
+	}, summary(steps))
+
+	// without a selector, all objects of the declared kinds are pruned
+	steps, err = apply.Plan(objects, current(peers, imports, bindings), true, labels.Everything())
+	require.Nil(t, err)
+	require.Equal(t, [][3]string{
+		{"Peer", "peer1", "create"},
+		{"Import", "imp1", "create"},
+		{"Binding", "imp1/peer1", "create"},
+		{"Binding", "imp2/peer2", "delete"},
+		{"Import", "imp2", "delete"},
+		{"Import", "imp3", "delete"},
+		{"Peer", "peer2", "delete"},
+		{"Peer", "peer3", "delete"},
+		{"Peer", "peer4", "delete"},
+	}, summary(steps))
+}
+
+func TestPlanDuplicate(t *testing.T) {
+	objects := readManifest(t)
+	_, err := apply.Plan(append(objects, objects[0]), current(nil, nil, nil), false, labels.Everything())
+	require.ErrorContains(t, err, "declared twice")
+}
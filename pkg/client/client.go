@@ -16,6 +16,9 @@ package client
 import (
 	"crypto/tls"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
 
 	"github.com/clusterlink-net/clusterlink/pkg/api"
 	event "github.com/clusterlink-net/clusterlink/pkg/controlplane/eventmanager"
@@ -98,3 +101,114 @@ func (c *Client) GetMetrics() (map[string]event.ConnectionStatusAttr, error) {
 	}
 	return connections, nil
 }
+
+// Backup returns a backup of the controlplane configuration.
+func (c *Client) Backup() (*api.Backup, error) {
+	resp, err := c.client.Get(api.BackupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Status != http.StatusOK {
+		return nil, fmt.Errorf("unable to get backup (%d), server returned: %s",
+			resp.Status, resp.Body)
+	}
+
+	var backup api.Backup
+	if err := json.Unmarshal(resp.Body, &backup); err != nil {
+		return nil, err
+	}
+
+	return &backup, nil
+}
+
+// Status returns the status of the controlplane.
+func (c *Client) Status() (*api.Status, error) {
+	resp, err := c.client.Get(api.StatusPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Status != http.StatusOK {
+		return nil, fmt.Errorf("unable to get status (%d), server returned: %s",
+			resp.Status, resp.Body)
+	}
+
+	var status api.Status
+	if err := json.Unmarshal(resp.Body, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// RemoteExports returns the exported services which a remote peer exposes to the local peer.
+func (c *Client) RemoteExports(peer string) ([]api.RemoteExport, error) {
+	resp, err := c.client.Get(api.RemoteExportsPath + "/" + url.PathEscape(peer))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Status != http.StatusOK {
+		return nil, fmt.Errorf("unable to get remote exports (%d), server returned: %s",
+			resp.Status, resp.Body)
+	}
+
+	var exports []api.RemoteExport
+	if err := json.Unmarshal(resp.Body, &exports); err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
+
+// DiagnoseImport checks the chain of components serving an imported service.
+func (c *Client) DiagnoseImport(name string) (*api.Diagnosis, error) {
+	resp, err := c.client.Get(api.DiagnoseImportPath + "/" + url.PathEscape(name))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Status != http.StatusOK {
+		return nil, fmt.Errorf("unable to diagnose import (%d), server returned: %s",
+			resp.Status, resp.Body)
+	}
+
+	var diagnosis api.Diagnosis
+	if err := json.Unmarshal(resp.Body, &diagnosis); err != nil {
+		return nil, err
+	}
+
+	return &diagnosis, nil
+}
+
+// Restore restores a backup of the controlplane configuration.
+// If dryRun is set, the returned result lists the required changes without applying them.
+func (c *Client) Restore(backup *api.Backup, dryRun bool) (*api.RestoreResult, error) {
+	encoded, err := json.Marshal(backup)
+	if err != nil {
+		return nil, err
+	}
+
+	path := api.RestorePath
+	if dryRun {
+		path += "?" + api.RestoreDryRunParam + "=true"
+	}
+
+	resp, err := c.client.Post(path, encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Status != http.StatusOK {
+		return nil, fmt.Errorf("unable to restore backup (%d), server returned: %s",
+			resp.Status, resp.Body)
+	}
+
+	var result api.RestoreResult
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -16,6 +16,8 @@ package api
 const (
 	// RemotePeerAuthorizationPath is the path remote peers use to send an authorization request.
 	RemotePeerAuthorizationPath = "/authz"
+	// RemotePeerExportsPath is the path remote peers use to list the exported services they may access.
+	RemotePeerExportsPath = "/peer/exports"
 	// DataplaneEgressAuthorizationPath is the path the dataplane uses to authorize an egress connection.
 	DataplaneEgressAuthorizationPath = "/authz/egress/"
 	// DataplaneIngressAuthorizationPath is the path the dataplane uses to authorize an ingress connection.
@@ -27,6 +29,8 @@ const (
 	ImportNamespaceHeader = "x-import-namespace"
 	// ClientIPHeader holds the IP address of the source client.
 	ClientIPHeader = "x-client-ip"
+	// ClientSPIFFEIDHeader holds the SPIFFE ID of the source client, as authenticated by the dataplane.
+	ClientSPIFFEIDHeader = "x-client-spiffe-id"
 
 	// AuthorizationHeader holds a signed token allowing ingress connections to access the dataplane.
 	AuthorizationHeader = "authorization"
@@ -53,3 +57,23 @@ type AuthorizationResponse struct {
 	// AccessToken holds an access token which can be used to access the requested exported service.
 	AccessToken string
 }
+
+// ExportsRequest represents a request for listing the exported services accessible to the requesting peer.
+type ExportsRequest struct {
+	// ServiceNamespace is the namespace the exported services are to be imported to.
+	ServiceNamespace string
+}
+
+// ExportsResponse lists the exported services accessible to the requesting peer.
+type ExportsResponse struct {
+	// Exports are the exported services which ingress policies allow the requesting peer to access.
+	Exports []RemoteExport
+}
+
+// RemoteExport represents an exported service, as exposed to remote peers.
+type RemoteExport struct {
+	// Name of the exported service.
+	Name string
+	// Labels of the exported service.
+	Labels map[string]string
+}
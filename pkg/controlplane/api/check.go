@@ -0,0 +1,42 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+// RemotePeerCheckPath is the path remote peers use to check access to an exported service, for diagnosis.
+const RemotePeerCheckPath = "/check"
+
+// CheckRequest represents a request for checking access to an exported service, without accessing it.
+type CheckRequest struct {
+	// ServiceName is the name of the requested exported service.
+	ServiceName string
+	// ServiceNamespace is the namespace of the requested exported service.
+	ServiceNamespace string
+}
+
+// CheckResponse represents the outcome of a CheckRequest.
+// If the requesting peer is not allowed to access the service, all fields but Allowed are empty.
+type CheckResponse struct {
+	// ServiceExists is true if the requested service is exported.
+	ServiceExists bool
+	// Allowed is true if access policies allow the requesting peer to access the service.
+	Allowed bool
+	// MatchedBy is the name of the policy which took the access decision.
+	MatchedBy string
+	// Target is the host the exported service is forwarded to.
+	Target string
+	// TargetResolvable is true if the target host can be resolved.
+	TargetResolvable bool
+	// TargetError describes why the target host cannot be resolved.
+	TargetError string
+}
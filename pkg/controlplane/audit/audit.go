@@ -0,0 +1,174 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Action is a management action on an object.
+type Action string
+
+const (
+	// ActionCreate is the creation of an object.
+	ActionCreate Action = "create"
+	// ActionUpdate is the update of an existing object.
+	ActionUpdate Action = "update"
+	// ActionDelete is the deletion of an object.
+	ActionDelete Action = "delete"
+)
+
+// Direction of an authorized connection.
+type Direction string
+
+const (
+	// Egress is a connection from a local client to an imported service.
+	Egress Direction = "egress"
+	// Ingress is a connection from a remote peer to an exported service.
+	Ingress Direction = "ingress"
+)
+
+// Mutation records a management action on an object.
+type Mutation struct {
+	// Caller is the identity of the client that requested the action.
+	Caller string `json:"caller"`
+	// Action performed.
+	Action Action `json:"action"`
+	// Kind of the object (e.g. peer, export).
+	Kind string `json:"kind"`
+	// Name of the object.
+	Name string `json:"name"`
+	// Before is the object before the action.
+	Before any `json:"before,omitempty"`
+	// After is the object after the action.
+	After any `json:"after,omitempty"`
+	// Error is the reason for a failed action.
+	Error string `json:"error,omitempty"`
+}
+
+// Authorization records an authorization decision on a connection.
+type Authorization struct {
+	// Direction of the connection.
+	Direction Direction `json:"direction"`
+	// Client is the identity of the connection source (IP address or SPIFFE ID for egress, peer name for ingress).
+	Client string `json:"client,omitempty"`
+	// Source holds the attributes of the connection source.
+	Source map[string]string `json:"source,omitempty"`
+	// Service is the (namespaced) name of the destination service.
+	Service string `json:"service"`
+	// Peer is the remote peer the connection is routed to, or coming from.
+	Peer string `json:"peer,omitempty"`
+	// Allowed is true if the connection is allowed.
+	Allowed bool `json:"allowed"`
+	// Policy is the name of the policy that took the decision.
+	Policy string `json:"policy,omitempty"`
+	// Error is the reason for a failed authorization.
+	Error string `json:"error,omitempty"`
+}
+
+// Event is a single audit record.
+type Event struct {
+	// Time of the event.
+	Time time.Time `json:"time"`
+	// Mutation is set for management actions.
+	Mutation *Mutation `json:"mutation,omitempty"`
+	// Authorization is set for authorization decisions.
+	Authorization *Authorization `json:"authorization,omitempty"`
+}
+
+// Sink is a destination for audit events.
+type Sink interface {
+	// Write an event.
+	Write(event *Event) error
+	// Close the sink.
+	Close() error
+}
+
+// Auditor records audit events to a set of sinks.
+type Auditor struct {
+	sinks []Sink
+
+	logger *logrus.Entry
+}
+
+// Enabled returns true if events are recorded to at least one sink.
+func (a *Auditor) Enabled() bool {
+	return len(a.sinks) > 0
+}
+
+// Record an event to all sinks.
+func (a *Auditor) Record(event *Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	for _, sink := range a.sinks {
+		if err := sink.Write(event); err != nil {
+			a.logger.Errorf("Cannot write audit event: %v.", err)
+		}
+	}
+}
+
+// RecordMutation records a management action.
+func (a *Auditor) RecordMutation(mutation *Mutation) {
+	if a.Enabled() {
+		a.Record(&Event{Mutation: mutation})
+	}
+}
+
+// RecordAuthorization records an authorization decision.
+func (a *Auditor) RecordAuthorization(authorization *Authorization) {
+	if a.Enabled() {
+		a.Record(&Event{Authorization: authorization})
+	}
+}
+
+// Close all sinks.
+func (a *Auditor) Close() error {
+	var firstErr error
+	for _, sink := range a.sinks {
+		if err := sink.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// NewAuditor returns an auditor recording events to the given sinks.
+// An auditor with no sinks discards all events.
+func NewAuditor(sinks ...Sink) *Auditor {
+	return &Auditor{
+		sinks:  sinks,
+		logger: logrus.WithField("component", "controlplane.audit"),
+	}
+}
+
+type callerKey struct{}
+
+// WithCaller returns a copy of ctx holding the identity of the caller.
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the identity of the caller held by ctx.
+func CallerFromContext(ctx context.Context) string {
+	caller, ok := ctx.Value(callerKey{}).(string)
+	if !ok {
+		return ""
+	}
+	return caller
+}
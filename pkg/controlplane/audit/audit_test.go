@@ -0,0 +1,200 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/audit"
+)
+
+// readEvents reads the events written to a file.
+func readEvents(t *testing.T, path string) []*audit.Event {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.Nil(t, err)
+	defer file.Close()
+
+	var events []*audit.Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event audit.Event
+		require.Nil(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, &event)
+	}
+	require.Nil(t, scanner.Err())
+
+	return events
+}
+
+func TestAuditor(t *testing.T) {
+	// disabled auditor
+	auditor := audit.NewAuditor()
+	require.False(t, auditor.Enabled())
+	auditor.RecordMutation(&audit.Mutation{Action: audit.ActionCreate, Kind: "peer", Name: "peer1"})
+	require.Nil(t, auditor.Close())
+
+	// writer sink
+	var buf bytes.Buffer
+	auditor = audit.NewAuditor(audit.NewWriterSink(&buf))
+	require.True(t, auditor.Enabled())
+
+	auditor.RecordMutation(&audit.Mutation{
+		Caller: "gwctl",
+		Action: audit.ActionCreate,
+		Kind:   "peer",
+		Name:   "peer1",
+		After:  map[string]string{"name": "peer1"},
+	})
+	auditor.RecordAuthorization(&audit.Authorization{
+		Direction: audit.Egress,
+		Client:    "10.0.0.1",
+		Service:   "default/svc",
+		Peer:      "peer1",
+		Allowed:   true,
+		Policy:    "allow-all",
+	})
+	require.Nil(t, auditor.Close())
+
+	var mutation, authorization audit.Event
+	decoder := json.NewDecoder(&buf)
+	require.Nil(t, decoder.Decode(&mutation))
+	require.Nil(t, decoder.Decode(&authorization))
+
+	require.False(t, mutation.Time.IsZero())
+	require.NotNil(t, mutation.Mutation)
+	require.Nil(t, mutation.Authorization)
+	require.Equal(t, "gwctl", mutation.Mutation.Caller)
+	require.Equal(t, audit.ActionCreate, mutation.Mutation.Action)
+	require.Nil(t, mutation.Mutation.Before)
+	require.NotNil(t, mutation.Mutation.After)
+
+	require.NotNil(t, authorization.Authorization)
+	require.Nil(t, authorization.Mutation)
+	require.Equal(t, audit.Egress, authorization.Authorization.Direction)
+	require.Equal(t, "allow-all", authorization.Authorization.Policy)
+	require.True(t, authorization.Authorization.Allowed)
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	// each event is about 100 bytes, so rotate every few events
+	sink, err := audit.NewFileSink(path, 300, 2)
+	require.Nil(t, err)
+
+	auditor := audit.NewAuditor(sink)
+	for i := 0; i < 20; i++ {
+		auditor.RecordMutation(&audit.Mutation{Action: audit.ActionDelete, Kind: "export", Name: "export"})
+	}
+	require.Nil(t, auditor.Close())
+
+	// current file and 2 backups
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(p)
+		require.Nil(t, err)
+		require.LessOrEqual(t, info.Size(), int64(300))
+		require.NotEmpty(t, readEvents(t, p))
+	}
+
+	_, err = os.Stat(path + ".3")
+	require.True(t, os.IsNotExist(err))
+
+	// re-opening appends to the existing file
+	sink, err = audit.NewFileSink(path, 0, 0)
+	require.Nil(t, err)
+	count := len(readEvents(t, path))
+	require.Nil(t, sink.Write(&audit.Event{Mutation: &audit.Mutation{Name: "appended"}}))
+	require.Nil(t, sink.Close())
+
+	events := readEvents(t, path)
+	require.Len(t, events, count+1)
+	require.Equal(t, "appended", events[count].Mutation.Name)
+}
+
+func TestFileSinkRotationFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	// a non-empty directory in place of the backup file fails rotation
+	require.Nil(t, os.MkdirAll(filepath.Join(path+".1", "blocker"), 0o755))
+
+	sink, err := audit.NewFileSink(path, 300, 1)
+	require.Nil(t, err)
+
+	failures := 0
+	for i := 0; i < 10; i++ {
+		if sink.Write(&audit.Event{Mutation: &audit.Mutation{Name: "export"}}) != nil {
+			failures++
+		}
+	}
+	require.NotZero(t, failures)
+
+	// events are still written to the current file
+	require.Len(t, readEvents(t, path), 10)
+
+	// rotation succeeds once the backup path is available
+	require.Nil(t, os.RemoveAll(path+".1"))
+	require.Nil(t, sink.Write(&audit.Event{Mutation: &audit.Mutation{Name: "rotated"}}))
+	require.Nil(t, sink.Close())
+
+	require.Len(t, readEvents(t, path+".1"), 10)
+	events := readEvents(t, path)
+	require.Len(t, events, 1)
+	require.Equal(t, "rotated", events[0].Mutation.Name)
+}
+
+func TestWebhookSink(t *testing.T) {
+	var lock sync.Mutex
+	var received []*audit.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event audit.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		received = append(received, &event)
+	}))
+	defer server.Close()
+
+	auditor := audit.NewAuditor(audit.NewWebhookSink(server.URL))
+	auditor.RecordAuthorization(&audit.Authorization{Direction: audit.Ingress, Service: "svc", Peer: "peer1"})
+	auditor.RecordAuthorization(&audit.Authorization{Direction: audit.Ingress, Service: "svc", Peer: "peer2"})
+
+	// closing flushes all queued events
+	require.Nil(t, auditor.Close())
+
+	// events recorded after closing are dropped
+	auditor.RecordAuthorization(&audit.Authorization{Direction: audit.Ingress, Service: "svc", Peer: "peer3"})
+	require.Nil(t, auditor.Close())
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, received, 2)
+	require.Equal(t, "peer1", received[0].Authorization.Peer)
+	require.Equal(t, "peer2", received[1].Authorization.Peer)
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileSink writes events to a file, as JSON lines.
+// Once the file reaches its maximum size, it is rotated: <path> is renamed to <path>.1,
+// <path>.1 is renamed to <path>.2, and so on, keeping up to a maximum number of backups.
+type FileSink struct {
+	lock sync.Mutex
+
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+}
+
+// Write an event.
+func (s *FileSink) Write(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.file == nil {
+		// a previous rotation failed to re-open the file
+		if err := s.open(); err != nil {
+			return fmt.Errorf("cannot open audit file: %w", err)
+		}
+	}
+
+	var rotateErr error
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(data)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			rotateErr = fmt.Errorf("cannot rotate audit file: %w", err)
+		}
+	}
+
+	if s.file == nil {
+		return rotateErr
+	}
+
+	n, err := s.file.Write(data)
+	s.size += int64(n)
+	return errors.Join(rotateErr, err)
+}
+
+// Close the file.
+func (s *FileSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+// rotate the file. The file is re-opened even if rotation fails, so that later events are still written.
+func (s *FileSink) rotate() error {
+	err := s.file.Close()
+	s.file = nil
+	if err == nil {
+		err = s.shiftBackups()
+	}
+
+	if openErr := s.open(); openErr != nil {
+		return errors.Join(err, openErr)
+	}
+
+	return err
+}
+
+// shiftBackups renames the (closed) file and its backups.
+func (s *FileSink) shiftBackups() error {
+	if s.maxBackups > 0 {
+		// shift existing backups, dropping the oldest one
+		for i := s.maxBackups - 1; i > 0; i-- {
+			err := os.Rename(s.backupPath(i), s.backupPath(i+1))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+
+		if err := os.Rename(s.path, s.backupPath(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(s.path); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *FileSink) backupPath(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
+
+func (s *FileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+// NewFileSink returns a sink appending events to the given file.
+// The file is rotated once reaching maxSize bytes (0 disables rotation), keeping up to maxBackups rotated files.
+func NewFileSink(path string, maxSize int64, maxBackups int) (*FileSink, error) {
+	s := &FileSink{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+
+	if err := s.open(); err != nil {
+		return nil, fmt.Errorf("cannot open audit file: %w", err)
+	}
+
+	return s, nil
+}
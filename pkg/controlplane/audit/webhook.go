@@ -0,0 +1,125 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// webhookQueueSize is the number of events buffered for sending to a webhook.
+	webhookQueueSize = 1024
+	// webhookTimeout is the timeout for sending a single event to a webhook.
+	webhookTimeout = 10 * time.Second
+)
+
+// WebhookSink sends events to an HTTP endpoint, each event as a JSON POST request.
+// Events are sent asynchronously, so that a slow endpoint does not delay the controlplane.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+
+	events chan *Event
+	wg     sync.WaitGroup
+
+	// lock guards closed, so that events are not queued on a closed channel.
+	lock   sync.Mutex
+	closed bool
+
+	logger *logrus.Entry
+}
+
+// Write queues an event for sending. Events written after the sink is closed are dropped.
+func (s *WebhookSink) Write(event *Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		s.logger.Debugf("Sink is closed, dropping event.")
+		return nil
+	}
+
+	select {
+	case s.events <- event:
+		return nil
+	default:
+		return fmt.Errorf("webhook queue is full, dropping event")
+	}
+}
+
+// Close sends all queued events, and stops the sink.
+func (s *WebhookSink) Close() error {
+	s.lock.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.events)
+	}
+	s.lock.Unlock()
+
+	s.wg.Wait()
+	return nil
+}
+
+func (s *WebhookSink) send(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (s *WebhookSink) run() {
+	defer s.wg.Done()
+	for event := range s.events {
+		if err := s.send(event); err != nil {
+			s.logger.Errorf("Cannot send audit event: %v.", err)
+		}
+	}
+}
+
+// NewWebhookSink returns a sink sending events to the given URL.
+func NewWebhookSink(url string) *WebhookSink {
+	s := &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+		events: make(chan *Event, webhookQueueSize),
+		logger: logrus.WithFields(logrus.Fields{
+			"component": "controlplane.audit.webhook",
+			"url":       url,
+		}),
+	}
+
+	s.wg.Add(1)
+	go s.run()
+
+	return s
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// WriterSink writes events to an io.Writer, as JSON lines.
+type WriterSink struct {
+	lock    sync.Mutex
+	encoder *json.Encoder
+}
+
+// Write an event.
+func (s *WriterSink) Write(event *Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.encoder.Encode(event)
+}
+
+// Close the sink. The underlying writer is not closed.
+func (s *WriterSink) Close() error {
+	return nil
+}
+
+// NewWriterSink returns a sink writing events to w.
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{encoder: json.NewEncoder(w)}
+}
+
+// NewStdoutSink returns a sink writing events to the standard output.
+func NewStdoutSink() *WriterSink {
+	return NewWriterSink(os.Stdout)
+}
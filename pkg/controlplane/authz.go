@@ -19,10 +19,13 @@ import (
 
 	"github.com/lestrrat-go/jwx/jwa"
 	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 
 	"github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/audit"
 	"github.com/clusterlink-net/clusterlink/pkg/policyengine"
 	"github.com/clusterlink-net/clusterlink/pkg/policyengine/policytypes"
+	"github.com/clusterlink-net/clusterlink/pkg/util/spiffe"
 )
 
 const (
@@ -39,6 +42,8 @@ type EgressAuthorizationRequest struct {
 	ImportNamespace string
 	// IP address of the client connecting to the service.
 	IP string
+	// SPIFFEID of the client connecting to the service, if authenticated by the dataplane.
+	SPIFFEID string
 }
 
 // EgressAuthorizationResponse (to local dataplane) represents a response for an EgressAuthorizationRequest.
@@ -76,13 +81,42 @@ type IngressAuthorizationResponse struct {
 func (cp *Instance) AuthorizeEgress(req *EgressAuthorizationRequest) (*EgressAuthorizationResponse, error) {
 	cp.logger.Infof("Received egress authorization request: %v.", req)
 
-	if imp := cp.GetImport(req.ImportName); imp == nil {
+	authz := &audit.Authorization{
+		Direction: audit.Egress,
+		Client:    req.IP,
+		Service:   serviceName(req.ImportNamespace, req.ImportName),
+	}
+	if req.SPIFFEID != "" {
+		authz.Client = req.SPIFFEID
+	}
+
+	resp, err := cp.authorizeEgress(req, authz)
+	if err != nil {
+		authz.Error = err.Error()
+	} else {
+		authz.Allowed = resp.Allowed
+	}
+	cp.auditor.RecordAuthorization(authz)
+
+	return resp, err
+}
+
+func (cp *Instance) authorizeEgress(req *EgressAuthorizationRequest, authz *audit.Authorization) (
+	*EgressAuthorizationResponse, error,
+) {
+	imp := cp.GetImport(req.ImportName)
+	if imp == nil {
 		return nil, fmt.Errorf("import '%s' not found", req.ImportName)
 	}
 
-	bindings := cp.GetBindings(req.ImportName)
-	if len(bindings) == 0 {
-		return nil, fmt.Errorf("no bindings found for import '%s'", req.ImportName)
+	if len(cp.GetBindings(req.ImportName)) == 0 {
+		if !imp.AutoBind {
+			return nil, fmt.Errorf("no bindings found for import '%s'", req.ImportName)
+		}
+
+		if len(cp.GetAutoBoundPeers(req.ImportName)) == 0 {
+			return nil, fmt.Errorf("no reachable peers export service '%s'", req.ImportName)
+		}
 	}
 
 	connReq := policytypes.ConnectionRequest{
@@ -90,22 +124,34 @@ func (cp *Instance) AuthorizeEgress(req *EgressAuthorizationRequest) (*EgressAut
 		DstSvcNamespace: req.ImportNamespace,
 		Direction:       policytypes.Outgoing,
 	}
-	srcLabels := cp.platform.GetLabelsFromIP(req.IP)
-	if src, ok := srcLabels["app"]; ok { // TODO: Add support for labels other than just the "app" key.
-		cp.logger.Infof("Received egress authorization srcLabels[app]: %v.", srcLabels["app"])
-		connReq.SrcWorkloadAttrs = policytypes.WorkloadAttrs{policyengine.ServiceNameLabel: src}
+	if req.SPIFFEID != "" {
+		// client identity was authenticated by the dataplane, no need to trust its IP address
+		srcAttrs, err := spiffeWorkloadAttrs(req.SPIFFEID)
+		if err != nil {
+			return nil, err
+		}
+		connReq.SrcWorkloadAttrs = srcAttrs
+	} else {
+		srcLabels := cp.platform.GetLabelsFromIP(req.IP)
+		if src, ok := srcLabels["app"]; ok { // TODO: Add support for labels other than just the "app" key.
+			cp.logger.Infof("Received egress authorization srcLabels[app]: %v.", srcLabels["app"])
+			connReq.SrcWorkloadAttrs = policytypes.WorkloadAttrs{policyengine.ServiceNameLabel: src}
+		}
 	}
 
+	authz.Source = connReq.SrcWorkloadAttrs
 	authResp, err := cp.policyDecider.AuthorizeAndRouteConnection(&connReq)
 	if err != nil {
 		return nil, err
 	}
 
+	authz.Policy = authResp.MatchedBy
 	if authResp.Action != policytypes.ActionAllow {
 		return &EgressAuthorizationResponse{Allowed: false}, nil
 	}
 
 	target := authResp.DstPeer
+	authz.Peer = target
 	peer := cp.GetPeer(target)
 	if peer == nil {
 		return nil, fmt.Errorf("peer '%s' does not exist", target)
@@ -140,10 +186,55 @@ func (cp *Instance) AuthorizeEgress(req *EgressAuthorizationRequest) (*EgressAut
 	return resp, nil
 }
 
+// serviceName returns the namespaced name of a service.
+func serviceName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+// spiffeWorkloadAttrs returns the policy attributes of a client workload identified by a SPIFFE ID.
+func spiffeWorkloadAttrs(spiffeID string) (policytypes.WorkloadAttrs, error) {
+	id, err := spiffeid.FromString(spiffeID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client SPIFFE ID: %w", err)
+	}
+
+	attrs := policytypes.WorkloadAttrs{policyengine.SpiffeTrustDomainLabel: id.TrustDomain().String()}
+	if namespace, serviceAccount, ok := spiffe.KubernetesIdentity(id); ok {
+		attrs[policyengine.SpiffeNamespaceLabel] = namespace
+		attrs[policyengine.SpiffeServiceAccountLabel] = serviceAccount
+	}
+
+	return attrs, nil
+}
+
 // AuthorizeIngress authorizes a request for accessing an exported service.
 func (cp *Instance) AuthorizeIngress(req *IngressAuthorizationRequest, peer string) (*IngressAuthorizationResponse, error) {
 	cp.logger.Infof("Received ingress authorization request: %v.", req)
 
+	authz := &audit.Authorization{
+		Direction: audit.Ingress,
+		Client:    peer,
+		Service:   serviceName(req.ServiceNamespace, req.ServiceName),
+		Peer:      peer,
+	}
+
+	resp, err := cp.authorizeIngress(req, peer, authz)
+	if err != nil {
+		authz.Error = err.Error()
+	} else {
+		authz.Allowed = resp.Allowed
+	}
+	cp.auditor.RecordAuthorization(authz)
+
+	return resp, err
+}
+
+func (cp *Instance) authorizeIngress(req *IngressAuthorizationRequest, peer string, authz *audit.Authorization) (
+	*IngressAuthorizationResponse, error,
+) {
 	resp := &IngressAuthorizationResponse{}
 
 	export := cp.GetExport(req.ServiceName)
@@ -159,10 +250,12 @@ func (cp *Instance) AuthorizeIngress(req *IngressAuthorizationRequest, peer stri
 		Direction:        policytypes.Incoming,
 		SrcWorkloadAttrs: policytypes.WorkloadAttrs{policyengine.GatewayNameLabel: peer},
 	}
+	authz.Source = connReq.SrcWorkloadAttrs
 	authResp, err := cp.policyDecider.AuthorizeAndRouteConnection(&connReq)
 	if err != nil {
 		return nil, err
 	}
+	authz.Policy = authResp.MatchedBy
 	if authResp.Action != policytypes.ActionAllow {
 		resp.Allowed = false
 		return resp, nil
@@ -181,7 +274,12 @@ func (cp *Instance) AuthorizeIngress(req *IngressAuthorizationRequest, peer stri
 	}
 
 	// sign access token
-	signed, err := jwt.Sign(token, jwtSignatureAlgorithm, cp.jwkSignKey)
+	signKey, _, err := cp.getJWK()
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := jwt.Sign(token, jwtSignatureAlgorithm, signKey)
 	if err != nil {
 		return nil, fmt.Errorf("unable to sign access token: %w", err)
 	}
@@ -195,8 +293,13 @@ func (cp *Instance) AuthorizeIngress(req *IngressAuthorizationRequest, peer stri
 func (cp *Instance) ParseAuthorizationHeader(token string) (string, error) {
 	cp.logger.Debug("Parsing access token.")
 
+	_, verifyKey, err := cp.getJWK()
+	if err != nil {
+		return "", err
+	}
+
 	parsedToken, err := jwt.ParseString(
-		token, jwt.WithVerify(jwtSignatureAlgorithm, cp.jwkVerifyKey), jwt.WithValidate(true))
+		token, jwt.WithVerify(jwtSignatureAlgorithm, verifyKey), jwt.WithValidate(true))
 	if err != nil {
 		return "", err
 	}
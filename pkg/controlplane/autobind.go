@@ -0,0 +1,260 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	cpapi "github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+	cpstore "github.com/clusterlink-net/clusterlink/pkg/controlplane/store"
+	"github.com/clusterlink-net/clusterlink/pkg/policyengine/policytypes"
+)
+
+// autoBindInterval is the time between consecutive refreshes of automatic bindings,
+// catching remote peers which export or unexport services.
+const autoBindInterval = 30 * time.Second
+
+// autoBindings holds the peers automatically bound to imports.
+type autoBindings struct {
+	lock sync.RWMutex
+	// peers maps import names to the names of their automatically bound peers.
+	peers map[string]map[string]bool
+}
+
+// GetAutoBoundPeers returns the sorted names of the peers automatically bound to an import.
+func (cp *Instance) GetAutoBoundPeers(imp string) []string {
+	cp.autoBindings.lock.RLock()
+	defer cp.autoBindings.lock.RUnlock()
+
+	peers := make([]string, 0, len(cp.autoBindings.peers[imp]))
+	for pr := range cp.autoBindings.peers[imp] {
+		peers = append(peers, pr)
+	}
+	sort.Strings(peers)
+
+	return peers
+}
+
+// ExportsLister lists the exported services of a remote peer which the local peer is allowed to access.
+type ExportsLister interface {
+	Exports(req *cpapi.ExportsRequest) (*cpapi.ExportsResponse, error)
+}
+
+// AutoBindPeers returns the imports in auto-bind mode, mapped to the peers exporting a service of the same name.
+// Imports with explicit bindings are not included, as explicit bindings override automatic ones.
+// Peers which cannot list their exports are considered unreachable, and are not bound.
+func AutoBindPeers(
+	imports []*cpstore.Import,
+	bindings []*cpstore.Binding,
+	peers map[string]ExportsLister,
+) map[string]map[string]bool {
+	bound := make(map[string]bool)
+	for _, binding := range bindings {
+		bound[binding.Import] = true
+	}
+
+	desired := make(map[string]map[string]bool)
+	for _, imp := range imports {
+		if imp.AutoBind && !bound[imp.Name] {
+			desired[imp.Name] = make(map[string]bool)
+		}
+	}
+
+	if len(desired) == 0 {
+		return desired
+	}
+
+	for pr, client := range peers {
+		// exports are listed on behalf of the egress listeners of imports
+		resp, err := client.Exports(&cpapi.ExportsRequest{ServiceNamespace: importNamespace})
+		if err != nil {
+			continue
+		}
+
+		for _, export := range resp.Exports {
+			if peers, ok := desired[export.Name]; ok {
+				peers[pr] = true
+			}
+		}
+	}
+
+	return desired
+}
+
+// syncAutoBindings binds every import in auto-bind mode to the reachable peers exporting a service of the same name,
+// and unbinds it from peers which no longer do.
+// Imports and bindings are read from the store, as watched changes may not yet be applied to the in-memory state.
+func (cp *Instance) syncAutoBindings() {
+	imports, bindings, err := cp.storedImportsAndBindings()
+	if err != nil {
+		cp.logger.Errorf("Cannot get imports for automatic bindings: %v.", err)
+		return
+	}
+
+	cp.peerLock.RLock()
+	clients := make(map[string]ExportsLister, len(cp.peerClient))
+	for name, client := range cp.peerClient {
+		clients[name] = client
+	}
+	cp.peerLock.RUnlock()
+
+	desired := AutoBindPeers(imports, bindings, clients)
+
+	cp.autoBindings.lock.Lock()
+	defer cp.autoBindings.lock.Unlock()
+
+	for imp, peers := range cp.autoBindings.peers {
+		for pr := range peers {
+			if desired[imp][pr] {
+				continue
+			}
+
+			cp.logger.Infof("Removing automatic binding '%s'->'%s'.", imp, pr)
+			delete(peers, pr)
+
+			// an explicit binding to the same peer keeps its load-balancing target
+			if !cp.hasBinding(imp, pr) {
+				cp.policyDecider.DeleteBinding(&api.Binding{Spec: api.BindingSpec{Import: imp, Peer: pr}})
+			}
+		}
+
+		if len(peers) == 0 {
+			delete(cp.autoBindings.peers, imp)
+		}
+	}
+
+	for imp, peers := range desired {
+		for pr := range peers {
+			if cp.autoBindings.peers[imp][pr] {
+				continue
+			}
+
+			binding := &api.Binding{Spec: api.BindingSpec{Import: imp, Peer: pr}}
+			if action := cp.policyDecider.AddBinding(binding); action != policytypes.ActionAllow {
+				cp.logger.Warnf("Access policies deny automatic binding '%s'->'%s'.", imp, pr)
+				continue
+			}
+
+			cp.logger.Infof("Adding automatic binding '%s'->'%s'.", imp, pr)
+			if cp.autoBindings.peers[imp] == nil {
+				cp.autoBindings.peers[imp] = make(map[string]bool)
+			}
+			cp.autoBindings.peers[imp][pr] = true
+		}
+	}
+}
+
+// storedImportsAndBindings returns the imports and bindings in the store.
+func (cp *Instance) storedImportsAndBindings() ([]*cpstore.Import, []*cpstore.Binding, error) {
+	storedImports, err := cp.imports.ObjectStore().GetAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	imports := make([]*cpstore.Import, len(storedImports))
+	for i, object := range storedImports {
+		imports[i] = object.(*cpstore.Import)
+	}
+
+	storedBindings, err := cp.bindings.ObjectStore().GetAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bindings := make([]*cpstore.Binding, len(storedBindings))
+	for i, object := range storedBindings {
+		bindings[i] = object.(*cpstore.Binding)
+	}
+
+	return imports, bindings, nil
+}
+
+// hasBinding returns true if an import is explicitly bound to a peer.
+func (cp *Instance) hasBinding(imp, pr string) bool {
+	for _, binding := range cp.bindings.Get(imp) {
+		if binding.Peer == pr {
+			return true
+		}
+	}
+	return false
+}
+
+// AutoBinder keeps the automatic bindings of imports up-to-date,
+// on every change to the stored imports, bindings or peer status, and periodically.
+type AutoBinder struct {
+	cp *Instance
+
+	lock   sync.Mutex
+	cancel context.CancelFunc
+}
+
+// Name of the auto-binder.
+func (b *AutoBinder) Name() string {
+	return "auto-binder"
+}
+
+// Start syncing automatic bindings, until stopped.
+func (b *AutoBinder) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b.lock.Lock()
+	b.cancel = cancel
+	b.lock.Unlock()
+
+	// automatic bindings depend on the imports, their explicit bindings, and the status of peers
+	changes, stopWatching := watchStores(
+		b.cp.imports.ObjectStore(),
+		b.cp.bindings.ObjectStore(),
+		b.cp.state.PeerStatusStore())
+	defer stopWatching()
+
+	ticker := time.NewTicker(autoBindInterval)
+	defer ticker.Stop()
+
+	for {
+		b.cp.syncAutoBindings()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changes:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop syncing automatic bindings.
+func (b *AutoBinder) Stop() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.cancel != nil {
+		b.cancel()
+	}
+	return nil
+}
+
+// GracefulStop stops syncing automatic bindings.
+func (b *AutoBinder) GracefulStop() error {
+	return b.Stop()
+}
+
+// NewAutoBinder returns a new auto-binder for the imports of a controlplane instance.
+func NewAutoBinder(cp *Instance) *AutoBinder {
+	return &AutoBinder{cp: cp}
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane"
+	cpapi "github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+	cpstore "github.com/clusterlink-net/clusterlink/pkg/controlplane/store"
+)
+
+// fakePeerClient is a peer client returning a fixed list of exports, or an error if the peer is unreachable.
+type fakePeerClient struct {
+	exports     []string
+	unreachable bool
+	// namespaces are the service namespaces of the received requests.
+	namespaces []string
+}
+
+func (c *fakePeerClient) Exports(req *cpapi.ExportsRequest) (*cpapi.ExportsResponse, error) {
+	c.namespaces = append(c.namespaces, req.ServiceNamespace)
+	if c.unreachable {
+		return nil, fmt.Errorf("peer is unreachable")
+	}
+
+	resp := &cpapi.ExportsResponse{}
+	for _, name := range c.exports {
+		resp.Exports = append(resp.Exports, cpapi.RemoteExport{Name: name})
+	}
+	return resp, nil
+}
+
+func autoBindImport(name string) *cpstore.Import {
+	return &cpstore.Import{Name: name, ImportSpec: api.ImportSpec{AutoBind: true}}
+}
+
+func TestAutoBindPeers(t *testing.T) {
+	tests := []struct {
+		name     string
+		imports  []*cpstore.Import
+		bindings []*cpstore.Binding
+		peers    map[string]*fakePeerClient
+		expected map[string]map[string]bool
+	}{
+		{
+			name:    "add peers exporting the service",
+			imports: []*cpstore.Import{autoBindImport("svc")},
+			peers: map[string]*fakePeerClient{
+				"peer1": {exports: []string{"svc", "other"}},
+				"peer2": {exports: []string{"svc"}},
+				"peer3": {exports: []string{"other"}},
+			},
+			expected: map[string]map[string]bool{"svc": {"peer1": true, "peer2": true}},
+		},
+		{
+			name:    "remove peers which no longer export the service or are unreachable",
+			imports: []*cpstore.Import{autoBindImport("svc")},
+			peers: map[string]*fakePeerClient{
+				"peer1": {},
+				"peer2": {exports: []string{"svc"}, unreachable: true},
+			},
+			expected: map[string]map[string]bool{"svc": {}},
+		},
+		{
+			name:     "explicit bindings override automatic bindings",
+			imports:  []*cpstore.Import{autoBindImport("svc"), autoBindImport("other")},
+			bindings: []*cpstore.Binding{{BindingSpec: api.BindingSpec{Import: "svc", Peer: "peer2"}}},
+			peers: map[string]*fakePeerClient{
+				"peer1": {exports: []string{"svc", "other"}},
+			},
+			expected: map[string]map[string]bool{"other": {"peer1": true}},
+		},
+		{
+			name:    "imports not in auto-bind mode",
+			imports: []*cpstore.Import{{Name: "svc"}},
+			peers: map[string]*fakePeerClient{
+				"peer1": {exports: []string{"svc"}},
+			},
+			expected: map[string]map[string]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peers := make(map[string]controlplane.ExportsLister, len(tt.peers))
+			for name, client := range tt.peers {
+				peers[name] = client
+			}
+
+			require.Equal(t, tt.expected, controlplane.AutoBindPeers(tt.imports, tt.bindings, peers))
+
+			// exports are listed in the namespace of the egress listeners of imports
+			for _, client := range tt.peers {
+				for _, namespace := range client.namespaces {
+					require.Equal(t, "default", namespace)
+				}
+			}
+		})
+	}
+}
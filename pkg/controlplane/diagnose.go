@@ -0,0 +1,308 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	cpapi "github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+	"github.com/clusterlink-net/clusterlink/pkg/policyengine"
+	"github.com/clusterlink-net/clusterlink/pkg/policyengine/policytypes"
+)
+
+// resolveTimeout is the time limit for resolving the target of an exported service.
+const resolveTimeout = 2 * time.Second
+
+// diagnosis accumulates the checks of a diagnosis.
+type diagnosis struct {
+	api.Diagnosis
+}
+
+// pass records a passed check.
+func (d *diagnosis) pass(name, message string) {
+	d.Checks = append(d.Checks, api.Check{Name: name, Status: api.CheckPassed, Message: message})
+}
+
+// fail records a failed check, along with a hint for fixing it.
+func (d *diagnosis) fail(name, message, hint string) {
+	d.Checks = append(d.Checks, api.Check{Name: name, Status: api.CheckFailed, Message: message, Hint: hint})
+}
+
+// skip records a check which was not performed.
+func (d *diagnosis) skip(name, message string) {
+	d.Checks = append(d.Checks, api.Check{Name: name, Status: api.CheckSkipped, Message: message})
+}
+
+// DiagnoseImport checks the chain of components serving an imported service: the import and its listener,
+// its k8s service, its bindings, the bound peers, and the egress and remote ingress access decisions.
+func (cp *Instance) DiagnoseImport(name string) *api.Diagnosis {
+	cp.logger.Infof("Diagnosing import '%s'.", name)
+
+	d := &diagnosis{Diagnosis: api.Diagnosis{Import: name}}
+
+	imp := cp.GetImport(name)
+	if imp == nil {
+		d.fail("Import exists", fmt.Sprintf("import '%s' does not exist", name),
+			"create the import using 'gwctl create import'")
+		return &d.Diagnosis
+	}
+	d.pass("Import exists", fmt.Sprintf("import '%s' exists", name))
+
+	if imp.Port == 0 {
+		d.fail("Import listener", "no listener port is allocated",
+			"re-create the import, and check the controlplane logs for port allocation errors")
+	} else {
+		d.pass("Import listener", fmt.Sprintf("dataplane listens on port %d", imp.Port))
+	}
+
+	cp.diagnoseService(d, imp.Service.Host, imp.Service.Port)
+
+	bindings := cp.GetBindings(name)
+	peers := make([]string, len(bindings))
+	for i, binding := range bindings {
+		peers[i] = binding.Peer
+	}
+
+	switch {
+	case len(peers) > 0:
+		d.pass("Bindings", fmt.Sprintf("the import is bound to peers %v", peers))
+	case imp.AutoBind:
+		peers = cp.GetAutoBoundPeers(name)
+		if len(peers) == 0 {
+			d.fail("Bindings", "no reachable peer exports the service",
+				fmt.Sprintf("export the service on a peer using 'gwctl create export --name %s'", name))
+			d.skip("Peers", "no bound peers")
+			return &d.Diagnosis
+		}
+		d.pass("Bindings", fmt.Sprintf("the import is automatically bound to peers %v", peers))
+	default:
+		d.fail("Bindings", "the import is not bound to any peer",
+			fmt.Sprintf("bind the import using 'gwctl create binding --import %s --peer <peer>', "+
+				"or enable automatic bindings using 'gwctl update import --auto-bind'", name))
+		d.skip("Peers", "no bound peers")
+		return &d.Diagnosis
+	}
+
+	reachable := make(map[string]bool)
+	for _, pr := range peers {
+		reachable[pr] = cp.diagnosePeer(d, pr)
+	}
+
+	cp.diagnoseEgress(d, name)
+
+	for _, pr := range peers {
+		cp.diagnoseRemoteIngress(d, name, pr, reachable[pr])
+	}
+
+	return &d.Diagnosis
+}
+
+// diagnoseService checks the k8s service of an import.
+func (cp *Instance) diagnoseService(d *diagnosis, host string, port uint16) {
+	const check = "Kubernetes service"
+
+	service, err := cp.platform.GetService(host)
+	switch {
+	case err != nil:
+		d.fail(check, fmt.Sprintf("cannot get service '%s': %v", host, err),
+			"verify that the controlplane is allowed to get services")
+		return
+	case service == nil:
+		d.fail(check, fmt.Sprintf("service '%s' does not exist", host),
+			"services are created asynchronously; check the controlplane logs for errors creating it")
+		return
+	}
+
+	for _, servicePort := range service.Spec.Ports {
+		if servicePort.Port == int32(port) {
+			d.pass(check, fmt.Sprintf("service '%s' exposes port %d", host, port))
+			return
+		}
+	}
+
+	d.fail(check, fmt.Sprintf("service '%s' does not expose port %d", host, port),
+		"the service may be owned by another application; use a different host for the import")
+}
+
+// diagnosePeer checks that a bound peer exists and responds to heartbeats.
+func (cp *Instance) diagnosePeer(d *diagnosis, name string) bool {
+	check := fmt.Sprintf("Peer '%s' reachable", name)
+
+	if cp.GetPeer(name) == nil {
+		d.fail(check, fmt.Sprintf("peer '%s' does not exist", name),
+			fmt.Sprintf("create the peer using 'gwctl create peer --name %s'", name))
+		return false
+	}
+
+	cp.peerLock.RLock()
+	client, ok := cp.peerClient[name]
+	cp.peerLock.RUnlock()
+
+	switch {
+	case !ok:
+		d.fail(check, "no client for the peer", "check the controlplane logs for errors adding the peer")
+		return false
+	case !client.IsActive():
+		message := "the peer does not respond to heartbeats"
+		if lastSeen := client.LastSeen(); !lastSeen.IsZero() {
+			message += fmt.Sprintf(" (since %s)", lastSeen.UTC().Format(time.RFC3339))
+		}
+		d.fail(check, message,
+			"verify the peer gateway address, that it is reachable from this gateway, and that both peers "+
+				"share the same fabric CA")
+		return false
+	}
+
+	d.pass(check, "the peer responds to heartbeats")
+	return true
+}
+
+// diagnoseEgress checks the local access policies for connecting to an imported service.
+func (cp *Instance) diagnoseEgress(d *diagnosis, name string) {
+	const check = "Egress policy"
+
+	resp, err := cp.policyDecider.AuthorizeAndRouteConnection(&policytypes.ConnectionRequest{
+		DstSvcName:      name,
+		DstSvcNamespace: importNamespace,
+		Direction:       policytypes.Outgoing,
+	})
+	switch {
+	case err != nil:
+		d.fail(check, fmt.Sprintf("cannot decide: %v", err), "check the local access policies")
+	case resp.Action != policytypes.ActionAllow:
+		message := "connections are denied"
+		if resp.MatchedBy != "" {
+			message += fmt.Sprintf(" by policy '%s'", resp.MatchedBy)
+		}
+		d.fail(check, message+", or no bound peer is reachable",
+			"create an access policy allowing egress connections to the import (see 'gwctl create policy')")
+	default:
+		d.pass(check, fmt.Sprintf("connections are allowed by policy '%s', and routed to peer '%s'",
+			resp.MatchedBy, resp.DstPeer))
+	}
+}
+
+// diagnoseRemoteIngress checks with a bound peer that its exported service is allowed and resolvable.
+func (cp *Instance) diagnoseRemoteIngress(d *diagnosis, name, pr string, reachable bool) {
+	exportCheck := fmt.Sprintf("Peer '%s' exports '%s'", pr, name)
+	ingressCheck := fmt.Sprintf("Peer '%s' ingress policy", pr)
+	targetCheck := fmt.Sprintf("Peer '%s' export target", pr)
+
+	skipAll := func(message string) {
+		d.skip(exportCheck, message)
+		d.skip(ingressCheck, message)
+		d.skip(targetCheck, message)
+	}
+
+	if !reachable {
+		skipAll("the peer is not reachable")
+		return
+	}
+
+	cp.peerLock.RLock()
+	client, ok := cp.peerClient[pr]
+	cp.peerLock.RUnlock()
+	if !ok {
+		skipAll("no client for the peer")
+		return
+	}
+
+	resp, err := client.Check(&cpapi.CheckRequest{ServiceName: name, ServiceNamespace: importNamespace})
+	if err != nil {
+		d.fail(exportCheck, fmt.Sprintf("cannot check with the peer: %v", err),
+			"upgrade the peer to a version supporting diagnosis, or check it manually")
+		d.skip(ingressCheck, "cannot check with the peer")
+		d.skip(targetCheck, "cannot check with the peer")
+		return
+	}
+
+	if !resp.Allowed {
+		d.skip(exportCheck, "the peer does not disclose its exports to denied peers")
+		d.fail(ingressCheck, "connections are denied",
+			fmt.Sprintf("create an access policy on peer '%s' allowing ingress connections from peer '%s'",
+				pr, cp.PeerName()))
+		d.skip(targetCheck, "connections are denied")
+		return
+	}
+
+	if !resp.ServiceExists {
+		d.fail(exportCheck, "the service is not exported",
+			fmt.Sprintf("export the service on peer '%s' using 'gwctl create export --name %s'", pr, name))
+		d.pass(ingressCheck, fmt.Sprintf("connections are allowed by policy '%s'", resp.MatchedBy))
+		d.skip(targetCheck, "the service is not exported")
+		return
+	}
+	d.pass(exportCheck, "the service is exported")
+	d.pass(ingressCheck, fmt.Sprintf("connections are allowed by policy '%s'", resp.MatchedBy))
+
+	if resp.TargetResolvable {
+		d.pass(targetCheck, fmt.Sprintf("target '%s' is resolvable", resp.Target))
+	} else {
+		d.fail(targetCheck, fmt.Sprintf("target '%s' is not resolvable: %s", resp.Target, resp.TargetError),
+			fmt.Sprintf("verify that the exported service '%s' exists on peer '%s'", resp.Target, pr))
+	}
+}
+
+// CheckIngress checks a remote peer request for accessing an exported service, without authorizing it.
+// It is used by remote peers for diagnosing their imports.
+// If access policies deny the peer, nothing but the denial is returned, so that the existence of exports
+// is not disclosed to peers which are not allowed to access them.
+func (cp *Instance) CheckIngress(req *cpapi.CheckRequest, peer string) (*cpapi.CheckResponse, error) {
+	cp.logger.Infof("Received ingress check request from peer '%s': %v.", peer, req)
+
+	authResp, err := cp.policyDecider.AuthorizeAndRouteConnection(&policytypes.ConnectionRequest{
+		DstSvcName:       req.ServiceName,
+		DstSvcNamespace:  req.ServiceNamespace,
+		Direction:        policytypes.Incoming,
+		SrcWorkloadAttrs: policytypes.WorkloadAttrs{policyengine.GatewayNameLabel: peer},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &cpapi.CheckResponse{}
+	if authResp.Action != policytypes.ActionAllow {
+		return resp, nil
+	}
+
+	resp.Allowed = true
+	resp.MatchedBy = authResp.MatchedBy
+
+	export := cp.GetExport(req.ServiceName)
+	if export == nil {
+		return resp, nil
+	}
+
+	resp.ServiceExists = true
+
+	resp.Target = export.Service.Host
+	if export.ExternalService != "" {
+		resp.Target = export.ExternalService
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+
+	if _, err := net.DefaultResolver.LookupHost(ctx, resp.Target); err != nil {
+		resp.TargetError = err.Error()
+	} else {
+		resp.TargetResolvable = true
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,322 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	cpstore "github.com/clusterlink-net/clusterlink/pkg/controlplane/store"
+)
+
+// IsLeader returns true if the instance is the leading controlplane replica.
+func (cp *Instance) IsLeader() bool {
+	return cp.leader.Load()
+}
+
+// StartLeading makes the instance the owner of the persisted state.
+// The in-memory state is synchronized with the store, and heartbeats are sent to all peers.
+func (cp *Instance) StartLeading() error {
+	cp.logger.Info("Started leading.")
+
+	// complete a store transaction interrupted by a crash of the previous leader
+	if err := cp.storeManager.Recover(); err != nil {
+		return fmt.Errorf("cannot recover store: %w", err)
+	}
+
+	if err := cp.Resync(); err != nil {
+		return err
+	}
+
+	if err := cp.loadJWK(true); err != nil {
+		return err
+	}
+
+	cp.completeIntents()
+
+	cp.leader.Store(true)
+	cp.SetLeaderAddress("")
+
+	cp.peerLock.RLock()
+	defer cp.peerLock.RUnlock()
+
+	for _, client := range cp.peerClient {
+		client.StartMonitor()
+	}
+
+	return nil
+}
+
+// StopLeading makes the instance a follower, which no longer sends heartbeats to peers.
+func (cp *Instance) StopLeading() {
+	cp.logger.Info("Stopped leading.")
+
+	cp.leader.Store(false)
+
+	cp.peerLock.RLock()
+	defer cp.peerLock.RUnlock()
+
+	for _, client := range cp.peerClient {
+		client.StopMonitor()
+	}
+}
+
+// LeaderAddress returns the address of the leading controlplane replica,
+// or an empty string if the instance is the leader or the leader is unknown.
+func (cp *Instance) LeaderAddress() string {
+	cp.leaderLock.RLock()
+	defer cp.leaderLock.RUnlock()
+	return cp.leaderAddress
+}
+
+// SetLeaderAddress sets the address of the leading controlplane replica.
+func (cp *Instance) SetLeaderAddress(address string) {
+	cp.leaderLock.Lock()
+	defer cp.leaderLock.Unlock()
+	cp.leaderAddress = address
+}
+
+// LeaderTLSConfig returns a TLS configuration for accessing the leading controlplane replica.
+func (cp *Instance) LeaderTLSConfig() *tls.Config {
+	return cp.peerTLS.ClientConfig(cp.PeerName())
+}
+
+// Resync reloads the persisted state from the store, and applies any changes to the in-memory state.
+// It is used by followers, which do not own the persisted state, to follow the changes made by the leader.
+func (cp *Instance) Resync() error {
+	cp.resyncLock.Lock()
+	defer cp.resyncLock.Unlock()
+
+	cp.logger.Debug("Resyncing.")
+
+	if _, _, err := cp.getJWK(); err != nil {
+		if err := cp.loadJWK(false); err != nil {
+			cp.logger.Warnf("Cannot load JWK: %v.", err)
+		}
+	}
+
+	return errors.Join(
+		cp.resyncPeers(),
+		cp.resyncExports(),
+		cp.resyncImports(),
+		cp.resyncBindings(),
+		cp.resyncAccessPolicies(),
+		cp.resyncLBPolicies(),
+		cp.resyncPeerStatus(),
+	)
+}
+
+func (cp *Instance) resyncPeers() error {
+	before := cp.peers.GetAll()
+	if err := cp.peers.Refresh(); err != nil {
+		return fmt.Errorf("cannot refresh peers: %w", err)
+	}
+
+	changed, removed := diff(before, cp.peers.GetAll(), func(pr *cpstore.Peer) string { return pr.Name })
+	for _, pr := range changed {
+		if err := cp.addPeer(pr); err != nil {
+			return err
+		}
+		// re-apply the last known status of the peer
+		delete(cp.peerStatus, pr.Name)
+	}
+	for _, pr := range removed {
+		if err := cp.removePeer(pr.Name); err != nil {
+			return err
+		}
+		delete(cp.peerStatus, pr.Name)
+	}
+
+	return nil
+}
+
+func (cp *Instance) resyncExports() error {
+	before := cp.exports.GetAll()
+	if err := cp.exports.Refresh(); err != nil {
+		return fmt.Errorf("cannot refresh exports: %w", err)
+	}
+
+	changed, removed := diff(before, cp.exports.GetAll(), func(export *cpstore.Export) string { return export.Name })
+	for _, export := range changed {
+		if _, err := cp.policyDecider.AddExport(&api.Export{Name: export.Name, Spec: export.ExportSpec}); err != nil {
+			return err
+		}
+		if err := cp.xdsManager.AddExport(export); err != nil {
+			return err
+		}
+	}
+	for _, export := range removed {
+		if err := cp.xdsManager.DeleteExport(export.Name); err != nil {
+			return err
+		}
+		cp.policyDecider.DeleteExport(export.Name)
+	}
+
+	return nil
+}
+
+func (cp *Instance) resyncImports() error {
+	before := cp.imports.GetAll()
+	if err := cp.imports.Refresh(); err != nil {
+		return fmt.Errorf("cannot refresh imports: %w", err)
+	}
+
+	leased := make(map[string]uint16, len(before))
+	for _, imp := range before {
+		leased[imp.Name] = imp.Port
+	}
+
+	changed, removed := diff(before, cp.imports.GetAll(), func(imp *cpstore.Import) string { return imp.Name })
+	for _, imp := range changed {
+		// track leased ports, in case this instance becomes the leader
+		if port, ok := leased[imp.Name]; !ok || port != imp.Port {
+			if ok {
+				cp.ports.Release(port)
+			}
+			if _, err := cp.ports.Lease(imp.Port); err != nil {
+				cp.logger.Warnf("Cannot lease port of import '%s': %v.", imp.Name, err)
+			}
+		}
+
+		if err := cp.xdsManager.AddImport(imp); err != nil {
+			return err
+		}
+	}
+	for _, imp := range removed {
+		if err := cp.xdsManager.DeleteImport(imp.Name); err != nil {
+			return err
+		}
+		cp.ports.Release(imp.Port)
+	}
+
+	return nil
+}
+
+func (cp *Instance) resyncBindings() error {
+	before := cp.bindings.GetAll()
+	if err := cp.bindings.Refresh(); err != nil {
+		return fmt.Errorf("cannot refresh bindings: %w", err)
+	}
+
+	changed, removed := diff(before, cp.bindings.GetAll(), func(binding *cpstore.Binding) string {
+		return binding.Import + "/" + binding.Peer
+	})
+	for _, binding := range changed {
+		cp.policyDecider.AddBinding(&api.Binding{Spec: binding.BindingSpec})
+	}
+	for _, binding := range removed {
+		cp.policyDecider.DeleteBinding(&api.Binding{Spec: binding.BindingSpec})
+	}
+
+	return nil
+}
+
+func (cp *Instance) resyncAccessPolicies() error {
+	before := cp.acPolicies.GetAll()
+	if err := cp.acPolicies.Refresh(); err != nil {
+		return fmt.Errorf("cannot refresh access policies: %w", err)
+	}
+
+	changed, removed := diff(before, cp.acPolicies.GetAll(), func(policy *cpstore.AccessPolicy) string {
+		return policy.Name
+	})
+	for _, policy := range changed {
+		if err := cp.policyDecider.AddAccessPolicy(&api.Policy{Spec: policy.Spec}); err != nil {
+			return err
+		}
+	}
+	for _, policy := range removed {
+		if err := cp.policyDecider.DeleteAccessPolicy(&policy.Policy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cp *Instance) resyncLBPolicies() error {
+	before := cp.lbPolicies.GetAll()
+	if err := cp.lbPolicies.Refresh(); err != nil {
+		return fmt.Errorf("cannot refresh load-balancing policies: %w", err)
+	}
+
+	changed, removed := diff(before, cp.lbPolicies.GetAll(), func(policy *cpstore.LBPolicy) string {
+		return policy.Name
+	})
+	for _, policy := range changed {
+		if err := cp.policyDecider.AddLBPolicy(&api.Policy{Spec: policy.Spec}); err != nil {
+			return err
+		}
+	}
+	for _, policy := range removed {
+		if err := cp.policyDecider.DeleteLBPolicy(&policy.Policy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resyncPeerStatus applies the status of peers, as observed by the leader.
+func (cp *Instance) resyncPeerStatus() error {
+	status, err := cp.state.GetPeerStatus()
+	if err != nil {
+		return fmt.Errorf("cannot get peer status: %w", err)
+	}
+
+	for name, active := range status {
+		if cp.GetPeer(name) == nil {
+			continue
+		}
+
+		if last, ok := cp.peerStatus[name]; ok && last == active {
+			continue
+		}
+
+		cp.peerStatus[name] = active
+		if active {
+			cp.policyDecider.AddPeer(name)
+		} else {
+			cp.policyDecider.DeletePeer(name)
+		}
+	}
+
+	return nil
+}
+
+// diff returns the objects which were added or changed, and the objects which were removed,
+// between two listings of objects, identified by the given key.
+func diff[T any](before, after []T, key func(T) string) (changed, removed []T) {
+	beforeMap := make(map[string]T, len(before))
+	for _, object := range before {
+		beforeMap[key(object)] = object
+	}
+
+	for _, object := range after {
+		k := key(object)
+		if old, ok := beforeMap[k]; !ok || !reflect.DeepEqual(old, object) {
+			changed = append(changed, object)
+		}
+		delete(beforeMap, k)
+	}
+
+	for _, object := range beforeMap {
+		removed = append(removed, object)
+	}
+
+	return changed, removed
+}
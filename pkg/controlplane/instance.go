@@ -16,17 +16,21 @@ package controlplane
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	"github.com/lestrrat-go/jwx/jwk"
 	"github.com/sirupsen/logrus"
 
 	"github.com/clusterlink-net/clusterlink/pkg/api"
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/audit"
 	"github.com/clusterlink-net/clusterlink/pkg/controlplane/peer"
 	cpstore "github.com/clusterlink-net/clusterlink/pkg/controlplane/store"
-	"github.com/clusterlink-net/clusterlink/pkg/platform/k8s"
+	"github.com/clusterlink-net/clusterlink/pkg/platform"
 	"github.com/clusterlink-net/clusterlink/pkg/policyengine"
 	"github.com/clusterlink-net/clusterlink/pkg/policyengine/policytypes"
 	"github.com/clusterlink-net/clusterlink/pkg/store"
@@ -43,6 +47,8 @@ const (
 type Instance struct {
 	peerTLS *tls.ParsedCertData
 
+	storeManager store.Manager
+
 	peers      *cpstore.Peers
 	exports    *cpstore.Exports
 	imports    *cpstore.Imports
@@ -56,11 +62,23 @@ type Instance struct {
 	xdsManager    *xdsManager
 	ports         *portManager
 	policyDecider policyengine.PolicyDecider
-	platform      *k8s.Platform
+	platform      platform.Platform
 
+	jwkLock      sync.RWMutex
 	jwkSignKey   jwk.Key
 	jwkVerifyKey jwk.Key
 
+	auditor *audit.Auditor
+	state   *cpstore.State
+
+	leader        atomic.Bool
+	leaderLock    sync.RWMutex
+	leaderAddress string
+	resyncLock    sync.Mutex
+	peerStatus    map[string]bool
+
+	autoBindings autoBindings
+
 	initialized bool
 
 	logger *logrus.Entry
@@ -76,30 +94,7 @@ func (cp *Instance) CreatePeer(pr *cpstore.Peer) error {
 		}
 	}
 
-	// initialize peer client
-	client := peer.NewClient(pr, cp.peerTLS.ClientConfig(pr.Name))
-
-	cp.peerLock.Lock()
-	cp.peerClient[pr.Name] = client
-	cp.peerLock.Unlock()
-
-	if err := cp.xdsManager.AddPeer(pr); err != nil {
-		// practically impossible
-		return err
-	}
-
-	cp.policyDecider.AddPeer(pr.Name)
-
-	client.SetPeerStatusCallback(func(isActive bool) {
-		if isActive {
-			cp.policyDecider.AddPeer(pr.Name)
-			return
-		}
-
-		cp.policyDecider.DeletePeer(pr.Name)
-	})
-
-	return nil
+	return cp.addPeer(pr)
 }
 
 // UpdatePeer updates new route target for egress dataplane connections.
@@ -113,13 +108,31 @@ func (cp *Instance) UpdatePeer(pr *cpstore.Peer) error {
 		return err
 	}
 
+	return cp.addPeer(pr)
+}
+
+// addPeer applies a peer to the in-memory state, replacing any previous client of the peer.
+func (cp *Instance) addPeer(pr *cpstore.Peer) error {
 	// initialize peer client
 	client := peer.NewClient(pr, cp.peerTLS.ClientConfig(pr.Name))
+	client.SetPeerStatusCallback(func(isActive bool) {
+		cp.setPeerStatus(pr.Name, isActive)
+	})
 
 	cp.peerLock.Lock()
+	oldClient := cp.peerClient[pr.Name]
 	cp.peerClient[pr.Name] = client
 	cp.peerLock.Unlock()
 
+	if oldClient != nil {
+		oldClient.StopMonitor()
+	}
+
+	// heartbeats are only sent by the leader
+	if cp.IsLeader() {
+		client.StartMonitor()
+	}
+
 	if err := cp.xdsManager.AddPeer(pr); err != nil {
 		// practically impossible
 		return err
@@ -130,6 +143,19 @@ func (cp *Instance) UpdatePeer(pr *cpstore.Peer) error {
 	return nil
 }
 
+// setPeerStatus updates the policy engine with the status of a peer, and persists it for the other replicas.
+func (cp *Instance) setPeerStatus(name string, active bool) {
+	if active {
+		cp.policyDecider.AddPeer(name)
+	} else {
+		cp.policyDecider.DeletePeer(name)
+	}
+
+	if err := cp.state.SetPeerStatus(name, active); err != nil {
+		cp.logger.Warnf("Cannot persist status of peer '%s': %v.", name, err)
+	}
+}
+
 // GetPeer returns an existing peer.
 func (cp *Instance) GetPeer(name string) *cpstore.Peer {
 	cp.logger.Infof("Getting peer '%s'.", name)
@@ -137,10 +163,11 @@ func (cp *Instance) GetPeer(name string) *cpstore.Peer {
 }
 
 // DeletePeer removes the possibility for egress dataplane connections to be routed to a given peer.
-func (cp *Instance) DeletePeer(name string) (*cpstore.Peer, error) {
+// A non-zero version must match the current resource version, or ObjectConflictError is returned.
+func (cp *Instance) DeletePeer(name string, version uint64) (*cpstore.Peer, error) {
 	cp.logger.Infof("Deleting peer '%s'.", name)
 
-	pr, err := cp.peers.Delete(name)
+	pr, err := cp.peers.Delete(name, version)
 	if err != nil {
 		return nil, err
 	}
@@ -148,19 +175,36 @@ func (cp *Instance) DeletePeer(name string) (*cpstore.Peer, error) {
 		return nil, nil
 	}
 
-	cp.peerClient[name].StopMonitor()
+	if err := cp.removePeer(name); err != nil {
+		return nil, err
+	}
+
+	if err := cp.state.DeletePeerStatus(name); err != nil {
+		cp.logger.Warnf("Cannot delete status of peer '%s': %v.", name, err)
+	}
+
+	return pr, nil
+}
+
+// removePeer removes a peer from the in-memory state.
+func (cp *Instance) removePeer(name string) error {
 	cp.peerLock.Lock()
+	client := cp.peerClient[name]
 	delete(cp.peerClient, name)
 	cp.peerLock.Unlock()
 
+	if client != nil {
+		client.StopMonitor()
+	}
+
 	if err := cp.xdsManager.DeletePeer(name); err != nil {
 		// practically impossible
-		return nil, err
+		return err
 	}
 
 	cp.policyDecider.DeletePeer(name)
 
-	return pr, nil
+	return nil
 }
 
 // GetAllPeers returns the list of all peers.
@@ -182,13 +226,22 @@ func (cp *Instance) CreateExport(export *cpstore.Export) error {
 		return err
 	}
 
+	var intent *cpstore.Intent
 	if cp.initialized {
-		if err := cp.exports.Create(export); err != nil {
+		err := cpstore.RunTransaction(cp.storeManager, func(tx *cpstore.Transaction) error {
+			if err := cp.exports.CreateTx(tx, export); err != nil {
+				return err
+			}
+
+			// a k8s external service is created once the export is stored
+			var err error
+			if eSpec.ExternalService != "" {
+				intent, err = cp.state.AddIntentTx(tx, exportIntent, export.Name, eSpec.Service.Host)
+			}
+			return err
+		})
+		if err != nil {
 			return err
-		}
-		// create a k8s external service.
-		if eSpec.ExternalService != "" {
-			cp.platform.CreateExternalService(exportPrefix+export.Name, eSpec.Service.Host, eSpec.ExternalService)
 		}
 	}
 
@@ -197,6 +250,10 @@ func (cp *Instance) CreateExport(export *cpstore.Export) error {
 		return err
 	}
 
+	if intent != nil {
+		cp.completeIntent(intent)
+	}
+
 	return nil
 }
 
@@ -208,28 +265,48 @@ func (cp *Instance) UpdateExport(export *cpstore.Export) error {
 		return fmt.Errorf("the external service %s is not a hostname or an IP address", eSpec.ExternalService)
 	}
 
-	// TODO: check policyDecider's answer
-	_, err := cp.policyDecider.AddExport(&api.Export{Name: export.Name, Spec: export.ExportSpec})
+	var intent *cpstore.Intent
+	err := cpstore.RunTransaction(cp.storeManager, func(tx *cpstore.Transaction) error {
+		var services []string
+		err := cp.exports.UpdateTx(tx, export.Name, func(old *cpstore.Export) *cpstore.Export {
+			if old.ExternalService != "" {
+				services = append(services, old.Service.Host)
+			}
+			return export
+		})
+		if err != nil {
+			return err
+		}
+
+		// the k8s external service is updated (or removed) once the export is stored
+		if eSpec.ExternalService != "" {
+			services = append(services, eSpec.Service.Host)
+		}
+		if len(services) > 0 {
+			intent, err = cp.state.AddIntentTx(tx, exportIntent, export.Name, services...)
+		}
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	err = cp.exports.Update(export.Name, func(old *cpstore.Export) *cpstore.Export {
-		return export
-	})
+	// the export is updated in the policy decider only once stored, as the update may conflict
+	// TODO: check policyDecider's answer
+	_, err = cp.policyDecider.AddExport(&api.Export{Name: export.Name, Spec: export.ExportSpec})
 	if err != nil {
 		return err
 	}
-	// Update a k8s external service.
-	if eSpec.ExternalService != "" {
-		cp.platform.UpdateExternalService(exportPrefix+export.Name, eSpec.Service.Host, eSpec.ExternalService)
-	}
 
 	if err := cp.xdsManager.AddExport(export); err != nil {
 		// practically impossible
 		return err
 	}
 
+	if intent != nil {
+		cp.completeIntent(intent)
+	}
+
 	return nil
 }
 
@@ -240,23 +317,34 @@ func (cp *Instance) GetExport(name string) *cpstore.Export {
 }
 
 // DeleteExport removes the possibility for ingress dataplane connections to access a given service.
-func (cp *Instance) DeleteExport(name string) (*cpstore.Export, error) {
+// A non-zero version must match the current resource version, or ObjectConflictError is returned.
+func (cp *Instance) DeleteExport(name string, version uint64) (*cpstore.Export, error) {
 	cp.logger.Infof("Deleting export '%s'.", name)
 
-	export, err := cp.exports.Delete(name)
-	if err != nil {
-		return nil, err
-	}
+	export := cp.exports.Get(name)
 	if export == nil {
 		return nil, nil
 	}
 
-	// Deleting a k8s external service.
-	if export.ExportSpec.ExternalService != "" {
-		cp.platform.DeleteService(exportPrefix+name, export.Name)
-		if err != nil {
-			return nil, err
+	var intent *cpstore.Intent
+	err := cpstore.RunTransaction(cp.storeManager, func(tx *cpstore.Transaction) error {
+		if err := cp.exports.DeleteTx(tx, name, version); err != nil {
+			return err
+		}
+
+		// the k8s external service is deleted once the export is removed from the store
+		var err error
+		if export.ExportSpec.ExternalService != "" {
+			intent, err = cp.state.AddIntentTx(tx, exportIntent, name, export.Service.Host)
 		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if intent != nil {
+		cp.completeIntent(intent)
 	}
 
 	if err := cp.xdsManager.DeleteExport(name); err != nil {
@@ -277,8 +365,27 @@ func (cp *Instance) GetAllExports() []*cpstore.Export {
 
 // CreateImport creates a listening socket for an imported remote service.
 func (cp *Instance) CreateImport(imp *cpstore.Import) error {
+	return cp.CreateImportWithBindings(imp, nil)
+}
+
+// CreateImportWithBindings creates an import, together with its bindings to remote exported services.
+// The import and its bindings are stored atomically: if any of the bindings cannot be created,
+// neither the import nor any of its bindings are created.
+func (cp *Instance) CreateImportWithBindings(imp *cpstore.Import, bindings []*cpstore.Binding) error {
 	cp.logger.Infof("Creating import '%s'.", imp.Name)
 
+	for _, binding := range bindings {
+		if binding.Import != imp.Name {
+			return fmt.Errorf("binding '%s'->'%s' does not bind import '%s'", binding.Import, binding.Peer, imp.Name)
+		}
+
+		if cp.initialized {
+			if err := cp.validateBinding(binding); err != nil {
+				return err
+			}
+		}
+	}
+
 	port, err := cp.ports.Lease(imp.Port)
 	if err != nil {
 		return fmt.Errorf("cannot generate listening port: %w", err)
@@ -286,9 +393,36 @@ func (cp *Instance) CreateImport(imp *cpstore.Import) error {
 
 	imp.Port = port
 
+	for i, binding := range bindings {
+		action := cp.policyDecider.AddBinding(&api.Binding{Spec: binding.BindingSpec})
+		if action != policytypes.ActionAllow {
+			cp.ports.Release(port)
+			cp.deletePolicyBindings(bindings[:i])
+			return fmt.Errorf("access policies deny creating binding '%s'->'%s'", binding.Import, binding.Peer)
+		}
+	}
+
+	var intent *cpstore.Intent
 	if cp.initialized {
-		if err := cp.imports.Create(imp); err != nil {
+		err := cpstore.RunTransaction(cp.storeManager, func(tx *cpstore.Transaction) error {
+			if err := cp.imports.CreateTx(tx, imp); err != nil {
+				return err
+			}
+
+			for _, binding := range bindings {
+				if err := cp.bindings.CreateTx(tx, binding); err != nil {
+					return err
+				}
+			}
+
+			// a k8s service is created once the import is stored
+			var err error
+			intent, err = cp.state.AddIntentTx(tx, importIntent, imp.Name, imp.Service.Host)
+			return err
+		})
+		if err != nil {
 			cp.ports.Release(port)
+			cp.deletePolicyBindings(bindings)
 			return err
 		}
 	}
@@ -298,21 +432,39 @@ func (cp *Instance) CreateImport(imp *cpstore.Import) error {
 		return err
 	}
 
-	// TODO: handle a crash happening between storing an import and creating a service
-	if cp.initialized {
-		cp.platform.CreateService(imp.Name, imp.Service.Host, dataplaneAppName, imp.Service.Port, imp.Port)
+	if intent != nil {
+		cp.completeIntent(intent)
 	}
 
 	return nil
 }
 
+// deletePolicyBindings removes bindings which were added to the policy engine, but not stored.
+func (cp *Instance) deletePolicyBindings(bindings []*cpstore.Binding) {
+	for _, binding := range bindings {
+		cp.policyDecider.DeleteBinding(&api.Binding{Spec: binding.BindingSpec})
+	}
+}
+
 // UpdateImport updates a listening socket for an imported remote service.
 func (cp *Instance) UpdateImport(imp *cpstore.Import) error {
 	cp.logger.Infof("Updating import '%s'.", imp.Name)
 
-	err := cp.imports.Update(imp.Name, func(old *cpstore.Import) *cpstore.Import {
-		imp.Port = old.Port
-		return imp
+	var intent *cpstore.Intent
+	err := cpstore.RunTransaction(cp.storeManager, func(tx *cpstore.Transaction) error {
+		var oldHost string
+		err := cp.imports.UpdateTx(tx, imp.Name, func(old *cpstore.Import) *cpstore.Import {
+			oldHost = old.Service.Host
+			imp.Port = old.Port
+			return imp
+		})
+		if err != nil {
+			return err
+		}
+
+		// the k8s service is updated once the import is stored
+		intent, err = cp.state.AddIntentTx(tx, importIntent, imp.Name, oldHost, imp.Service.Host)
+		return err
 	})
 	if err != nil {
 		return err
@@ -323,7 +475,7 @@ func (cp *Instance) UpdateImport(imp *cpstore.Import) error {
 		return err
 	}
 
-	cp.platform.UpdateService(imp.Name, imp.Service.Host, dataplaneAppName, imp.Service.Port, imp.Port)
+	cp.completeIntent(intent)
 
 	return nil
 }
@@ -335,17 +487,30 @@ func (cp *Instance) GetImport(name string) *cpstore.Import {
 }
 
 // DeleteImport removes the listening socket of a previously imported service.
-func (cp *Instance) DeleteImport(name string) (*cpstore.Import, error) {
+// A non-zero version must match the current resource version, or ObjectConflictError is returned.
+func (cp *Instance) DeleteImport(name string, version uint64) (*cpstore.Import, error) {
 	cp.logger.Infof("Deleting import '%s'.", name)
 
-	imp, err := cp.imports.Delete(name)
-	if err != nil {
-		return nil, err
-	}
+	imp := cp.imports.Get(name)
 	if imp == nil {
 		return nil, nil
 	}
 
+	var intent *cpstore.Intent
+	err := cpstore.RunTransaction(cp.storeManager, func(tx *cpstore.Transaction) error {
+		if err := cp.imports.DeleteTx(tx, name, version); err != nil {
+			return err
+		}
+
+		// the k8s service is deleted once the import is removed from the store
+		var err error
+		intent, err = cp.state.AddIntentTx(tx, importIntent, name, imp.Service.Host)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	if err := cp.xdsManager.DeleteImport(name); err != nil {
 		// practically impossible
 		return imp, err
@@ -353,7 +518,7 @@ func (cp *Instance) DeleteImport(name string) (*cpstore.Import, error) {
 
 	cp.ports.Release(imp.Port)
 
-	cp.platform.DeleteService(imp.Name, imp.Service.Host)
+	cp.completeIntent(intent)
 
 	return imp, nil
 }
@@ -368,6 +533,12 @@ func (cp *Instance) GetAllImports() []*cpstore.Import {
 func (cp *Instance) CreateBinding(binding *cpstore.Binding) error {
 	cp.logger.Infof("Creating binding '%s'->'%s'.", binding.Import, binding.Peer)
 
+	if cp.initialized {
+		if err := cp.validateBinding(binding); err != nil {
+			return err
+		}
+	}
+
 	action := cp.policyDecider.AddBinding(&api.Binding{Spec: binding.BindingSpec})
 	if action != policytypes.ActionAllow {
 		cp.logger.Warnf("Access policies deny creating binding '%s'->'%s' .", binding.Import, binding.Peer)
@@ -452,10 +623,11 @@ func (cp *Instance) UpdateAccessPolicy(policy *cpstore.AccessPolicy) error {
 }
 
 // DeleteAccessPolicy removes an access policy to allow/deny specific connections.
-func (cp *Instance) DeleteAccessPolicy(name string) (*cpstore.AccessPolicy, error) {
+// A non-zero version must match the current resource version, or ObjectConflictError is returned.
+func (cp *Instance) DeleteAccessPolicy(name string, version uint64) (*cpstore.AccessPolicy, error) {
 	cp.logger.Infof("Deleting access policy '%s'.", name)
 
-	policy, err := cp.acPolicies.Delete(name)
+	policy, err := cp.acPolicies.Delete(name, version)
 	if err != nil {
 		return nil, err
 	}
@@ -510,10 +682,11 @@ func (cp *Instance) UpdateLBPolicy(policy *cpstore.LBPolicy) error {
 }
 
 // DeleteLBPolicy removes a load-balancing policy.
-func (cp *Instance) DeleteLBPolicy(name string) (*cpstore.LBPolicy, error) {
+// A non-zero version must match the current resource version, or ObjectConflictError is returned.
+func (cp *Instance) DeleteLBPolicy(name string, version uint64) (*cpstore.LBPolicy, error) {
 	cp.logger.Infof("Deleting load-balancing policy '%s'.", name)
 
-	policy, err := cp.lbPolicies.Delete(name)
+	policy, err := cp.lbPolicies.Delete(name, version)
 	if err != nil {
 		return nil, err
 	}
@@ -540,6 +713,11 @@ func (cp *Instance) GetAllLBPolicies() []*cpstore.LBPolicy {
 	return cp.lbPolicies.GetAll()
 }
 
+// PeerName returns the name of the local peer.
+func (cp *Instance) PeerName() string {
+	return cp.peerTLS.DNSNames()[0]
+}
+
 // GetXDSClusterManager returns the xDS cluster manager.
 func (cp *Instance) GetXDSClusterManager() cache.Cache {
 	return cp.xdsManager.clusters
@@ -552,11 +730,6 @@ func (cp *Instance) GetXDSListenerManager() cache.Cache {
 
 // init initializes the controlplane manager.
 func (cp *Instance) init() error {
-	// generate the JWK key
-	if err := cp.generateJWK(); err != nil {
-		return fmt.Errorf("unable to generate JWK key: %w", err)
-	}
-
 	// add peers
 	for _, p := range cp.GetAllPeers() {
 		if err := cp.CreatePeer(p); err != nil {
@@ -604,9 +777,28 @@ func (cp *Instance) init() error {
 	return nil
 }
 
-// generateJWK generates a new JWK for signing JWT access tokens.
-func (cp *Instance) generateJWK() error {
-	cp.logger.Infof("Updating the JWK.")
+// loadJWK loads the JWK for signing JWT access tokens from the store.
+// If no JWK is stored and generate is set, a new JWK is generated and stored.
+func (cp *Instance) loadJWK(generate bool) error {
+	stored, err := cp.state.GetJWK()
+	if err != nil {
+		return fmt.Errorf("unable to get JWK: %w", err)
+	}
+
+	if stored != nil {
+		rsaKey, err := x509.ParsePKCS1PrivateKey(stored.Key)
+		if err != nil {
+			return fmt.Errorf("unable to parse stored JWK: %w", err)
+		}
+
+		return cp.setJWK(rsaKey)
+	}
+
+	if !generate {
+		return fmt.Errorf("JWK was not created yet")
+	}
+
+	cp.logger.Infof("Generating the JWK.")
 
 	// generate RSA key-pair
 	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
@@ -614,6 +806,21 @@ func (cp *Instance) generateJWK() error {
 		return fmt.Errorf("unable to generate RSA keys: %w", err)
 	}
 
+	if err := cp.state.CreateJWK(x509.MarshalPKCS1PrivateKey(rsaKey)); err != nil {
+		// another replica may have created the JWK concurrently
+		var objectExistsErr *store.ObjectExistsError
+		if errors.As(err, &objectExistsErr) {
+			return cp.loadJWK(false)
+		}
+
+		return fmt.Errorf("unable to store JWK: %w", err)
+	}
+
+	return cp.setJWK(rsaKey)
+}
+
+// setJWK sets the JWK for signing and verifying JWT access tokens.
+func (cp *Instance) setJWK(rsaKey *rsa.PrivateKey) error {
 	jwkSignKey, err := jwk.New(rsaKey)
 	if err != nil {
 		return fmt.Errorf("unable to create JWK signing key: %w", err)
@@ -624,21 +831,38 @@ func (cp *Instance) generateJWK() error {
 		return fmt.Errorf("unable to create JWK verifing key: %w", err)
 	}
 
+	cp.jwkLock.Lock()
+	defer cp.jwkLock.Unlock()
+
 	cp.jwkSignKey = jwkSignKey
 	cp.jwkVerifyKey = jwkVerifyKey
 	return nil
 }
 
-// NewInstance returns a new controlplane instance.
-func NewInstance(peerTLS *tls.ParsedCertData, storeManager store.Manager, namespace string) (*Instance, error) {
-	logger := logrus.WithField("component", "controlplane")
+// getJWK returns the JWK for signing and verifying JWT access tokens.
+func (cp *Instance) getJWK() (jwk.Key, jwk.Key, error) {
+	cp.jwkLock.RLock()
+	defer cp.jwkLock.RUnlock()
 
-	// initialize platform
-	pp, err := k8s.NewPlatform(namespace)
-	if err != nil {
-		return nil, err
+	if cp.jwkSignKey == nil {
+		return nil, nil, fmt.Errorf("JWK is not loaded")
 	}
 
+	return cp.jwkSignKey, cp.jwkVerifyKey, nil
+}
+
+// NewInstance returns a new controlplane instance.
+// Services of imports and exports are managed on the given platform, and authorization decisions
+// are recorded by the given auditor.
+// The instance does not own the persisted state (i.e. it is a follower) until StartLeading is called.
+func NewInstance(
+	peerTLS *tls.ParsedCertData,
+	storeManager store.Manager,
+	pp platform.Platform,
+	auditor *audit.Auditor,
+) (*Instance, error) {
+	logger := logrus.WithField("component", "controlplane")
+
 	peers, err := cpstore.NewPeers(storeManager)
 	if err != nil {
 		return nil, fmt.Errorf("cannot load peers from store: %w", err)
@@ -675,8 +899,14 @@ func NewInstance(peerTLS *tls.ParsedCertData, storeManager store.Manager, namesp
 	}
 	logger.Infof("Loaded %d load-balancing policies.", lbPolicies.Len())
 
+	state, err := cpstore.NewState(storeManager)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load controlplane state from store: %w", err)
+	}
+
 	cp := &Instance{
 		peerTLS:       peerTLS,
+		storeManager:  storeManager,
 		peerClient:    make(map[string]*peer.Client),
 		peers:         peers,
 		exports:       exports,
@@ -688,6 +918,10 @@ func NewInstance(peerTLS *tls.ParsedCertData, storeManager store.Manager, namesp
 		ports:         newPortManager(),
 		policyDecider: policyengine.NewPolicyHandler(),
 		platform:      pp,
+		auditor:       auditor,
+		state:         state,
+		peerStatus:    make(map[string]bool),
+		autoBindings:  autoBindings{peers: make(map[string]map[string]bool)},
 		initialized:   false,
 		logger:        logger,
 	}
@@ -0,0 +1,99 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"fmt"
+
+	cpstore "github.com/clusterlink-net/clusterlink/pkg/controlplane/store"
+)
+
+const (
+	// importIntent is the kind of intents for syncing the k8s service of an import.
+	importIntent = "import"
+	// exportIntent is the kind of intents for syncing the k8s external service of an export.
+	exportIntent = "export"
+)
+
+// completeIntents completes all pending intents, left in the store by a previous leader.
+func (cp *Instance) completeIntents() {
+	intents, err := cp.state.GetIntents()
+	if err != nil {
+		cp.logger.Errorf("Cannot get pending intents: %v.", err)
+		return
+	}
+
+	for _, intent := range intents {
+		cp.logger.Infof("Completing pending intent '%s'.", intent.Name)
+		cp.completeIntent(intent)
+	}
+}
+
+// completeIntent syncs the k8s services recorded by an intent with the current state of their object,
+// and removes the intent once done.
+// Services are created (or updated) if the object exists, and removed otherwise.
+// On failure, the intent is kept, to be completed by the next leader.
+func (cp *Instance) completeIntent(intent *cpstore.Intent) {
+	if err := cp.syncIntentServices(intent); err != nil {
+		cp.logger.Errorf("Cannot sync k8s services of %s '%s': %v.", intent.Kind, intent.Object, err)
+		return
+	}
+
+	if err := cp.state.DeleteIntent(intent); err != nil {
+		cp.logger.Errorf("Cannot delete intent '%s': %v.", intent.Name, err)
+	}
+}
+
+// syncIntentServices syncs the k8s services recorded by an intent.
+func (cp *Instance) syncIntentServices(intent *cpstore.Intent) error {
+	var name, host string
+	var apply func() error
+
+	switch intent.Kind {
+	case importIntent:
+		name = intent.Object
+		if imp := cp.imports.Get(intent.Object); imp != nil {
+			host = imp.Service.Host
+			apply = func() error {
+				return cp.platform.ApplyService(imp.Name, imp.Service.Host, dataplaneAppName, imp.Service.Port, imp.Port)
+			}
+		}
+	case exportIntent:
+		name = exportPrefix + intent.Object
+		if export := cp.exports.Get(intent.Object); export != nil && export.ExternalService != "" {
+			host = export.Service.Host
+			apply = func() error {
+				return cp.platform.ApplyExternalService(name, export.Service.Host, export.ExternalService)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown intent kind '%s'", intent.Kind)
+	}
+
+	// remove services no longer used by the object
+	for _, service := range intent.Services {
+		if service == host {
+			continue
+		}
+		if err := cp.platform.RemoveService(name, service); err != nil {
+			return err
+		}
+	}
+
+	if apply != nil {
+		return apply()
+	}
+
+	return nil
+}
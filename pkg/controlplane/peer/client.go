@@ -42,6 +42,7 @@ type Client struct {
 	clients            []*jsonapi.Client
 	lastSeen           time.Time
 	active             bool
+	monitoring         bool
 	stopSignal         chan struct{}
 	lock               sync.RWMutex
 	logger             *logrus.Entry
@@ -105,6 +106,91 @@ func (c *Client) Authorize(req *api.AuthorizationRequest) (*RemoteServerAuthoriz
 	return resp, nil
 }
 
+// Check a request for accessing a peer exported service, without accessing it.
+func (c *Client) Check(req *api.CheckRequest) (*api.CheckResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize check request: %w", err)
+	}
+
+	var serverResp *jsonapi.Response
+	for _, client := range c.clients {
+		serverResp, err = client.Post(api.RemotePeerCheckPath, body)
+		if err == nil {
+			break
+		}
+
+		c.logger.Errorf("Error checking using endpoint %s: %v",
+			client.ServerURL(), err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if serverResp.Status == http.StatusNotFound {
+		return nil, fmt.Errorf("peer does not support checks")
+	}
+
+	if serverResp.Status != http.StatusOK {
+		return nil, fmt.Errorf("unable to check (%d), server returned: %s",
+			serverResp.Status, serverResp.Body)
+	}
+
+	var resp api.CheckResponse
+	if err := json.Unmarshal(serverResp.Body, &resp); err != nil {
+		return nil, fmt.Errorf("unable to parse server response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// Exports lists the exported services of the peer which the local peer is allowed to access.
+func (c *Client) Exports(req *api.ExportsRequest) (*api.ExportsResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize exports request: %w", err)
+	}
+
+	var serverResp *jsonapi.Response
+	for _, client := range c.clients {
+		serverResp, err = client.Post(api.RemotePeerExportsPath, body)
+		if err == nil {
+			break
+		}
+
+		c.logger.Errorf("Error listing exports using endpoint %s: %v",
+			client.ServerURL(), err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if serverResp.Status == http.StatusNotFound {
+		return nil, fmt.Errorf("peer does not support listing exports")
+	}
+
+	if serverResp.Status != http.StatusOK {
+		return nil, fmt.Errorf("unable to list exports (%d), server returned: %s",
+			serverResp.Status, serverResp.Body)
+	}
+
+	var resp api.ExportsResponse
+	if err := json.Unmarshal(serverResp.Body, &resp); err != nil {
+		return nil, fmt.Errorf("unable to parse server response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// LastSeen returns the last time the peer responded to a heartbeat.
+func (c *Client) LastSeen() time.Time {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.lastSeen
+}
+
 // IsActive returns if the peer is active or not.
 func (c *Client) IsActive() bool {
 	c.lock.RLock()
@@ -155,19 +241,41 @@ func (c *Client) getHeartbeat() error {
 	return retErr // Return an error if all client targets are unreachable
 }
 
+// StartMonitor starts sending heartbeat requests to the peer, if not already started.
+func (c *Client) StartMonitor() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.monitoring {
+		return
+	}
+
+	c.monitoring = true
+	c.stopSignal = make(chan struct{})
+	go c.heartbeatMonitor(c.stopSignal)
+}
+
 // StopMonitor send signal to stop heartbeat monitor.
 func (c *Client) StopMonitor() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.monitoring {
+		return
+	}
+
+	c.monitoring = false
 	close(c.stopSignal)
 }
 
 // heartbeatMonitor checks all peers for responsiveness, every fixed amount of time.
-func (c *Client) heartbeatMonitor() {
+func (c *Client) heartbeatMonitor(stopSignal chan struct{}) {
 	c.logger.Info("Start sending heartbeat requests to peer")
 	ticker := time.NewTicker(heartbeatInterval)
 	defer ticker.Stop()
 	for {
 		select {
-		case <-c.stopSignal:
+		case <-stopSignal:
 			return
 		default:
 			t := time.Now()
@@ -194,22 +302,21 @@ func (c *Client) SetPeerStatusCallback(callback func(bool)) {
 }
 
 // NewClient returns a new Peer API client.
+// Heartbeat requests are not sent until StartMonitor is called.
 func NewClient(peer *store.Peer, tlsConfig *tls.Config) *Client {
 	clients := make([]*jsonapi.Client, len(peer.Gateways))
 	for i, endpoint := range peer.Gateways {
 		clients[i] = jsonapi.NewClient(endpoint.Host, endpoint.Port, tlsConfig)
 	}
 	clnt := &Client{
-		clients:    clients,
-		active:     false,
-		lastSeen:   time.Time{},
-		stopSignal: make(chan struct{}),
+		clients:  clients,
+		active:   false,
+		lastSeen: time.Time{},
 		logger: logrus.WithFields(logrus.Fields{
 			"component": "controlplane.peer.client",
 			"peer":      peer,
 		}),
 	}
 
-	go clnt.heartbeatMonitor()
 	return clnt
 }
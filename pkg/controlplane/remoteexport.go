@@ -0,0 +1,131 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	cpapi "github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+	cpstore "github.com/clusterlink-net/clusterlink/pkg/controlplane/store"
+	"github.com/clusterlink-net/clusterlink/pkg/policyengine"
+	"github.com/clusterlink-net/clusterlink/pkg/policyengine/policytypes"
+)
+
+// bindingValidationTimeout is the time limit for listing the exports of a peer when validating a binding.
+const bindingValidationTimeout = 3 * time.Second
+
+// GetPeerExports returns the exported services which ingress policies allow a remote peer to access.
+func (cp *Instance) GetPeerExports(req *cpapi.ExportsRequest, peer string) (*cpapi.ExportsResponse, error) {
+	cp.logger.Infof("Received exports request from peer '%s': %v.", peer, req)
+
+	resp := &cpapi.ExportsResponse{Exports: []cpapi.RemoteExport{}}
+	for _, export := range cp.GetAllExports() {
+		authResp, err := cp.policyDecider.AuthorizeAndRouteConnection(&policytypes.ConnectionRequest{
+			DstSvcName:       export.Name,
+			DstSvcNamespace:  req.ServiceNamespace,
+			Direction:        policytypes.Incoming,
+			SrcWorkloadAttrs: policytypes.WorkloadAttrs{policyengine.GatewayNameLabel: peer},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if authResp.Action != policytypes.ActionAllow {
+			continue
+		}
+
+		resp.Exports = append(resp.Exports, cpapi.RemoteExport{Name: export.Name, Labels: export.Labels})
+	}
+
+	return resp, nil
+}
+
+// GetRemoteExports returns the exported services which a remote peer exposes to the local peer.
+// Returns nil if the peer does not exist.
+func (cp *Instance) GetRemoteExports(peer string) ([]*api.RemoteExport, error) {
+	cp.logger.Infof("Listing exports of peer '%s'.", peer)
+
+	if cp.GetPeer(peer) == nil {
+		return nil, nil
+	}
+
+	cp.peerLock.RLock()
+	client, ok := cp.peerClient[peer]
+	cp.peerLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no client for peer '%s'", peer)
+	}
+
+	resp, err := client.Exports(&cpapi.ExportsRequest{ServiceNamespace: importNamespace})
+	if err != nil {
+		return nil, err
+	}
+
+	exports := make([]*api.RemoteExport, len(resp.Exports))
+	for i, export := range resp.Exports {
+		exports[i] = &api.RemoteExport{Peer: peer, Name: export.Name, Labels: export.Labels}
+	}
+
+	return exports, nil
+}
+
+// validateBinding checks that the bound peer exposes the imported service to the local peer.
+// Bindings are rejected only if the peer responds with a list of exports lacking the service.
+// Bindings to peers which are unknown, unreachable, slow to respond, or do not support listing exports
+// are accepted, as the peer (or its export) may be created later.
+func (cp *Instance) validateBinding(binding *cpstore.Binding) error {
+	cp.peerLock.RLock()
+	client, ok := cp.peerClient[binding.Peer]
+	cp.peerLock.RUnlock()
+
+	if !ok || !client.IsActive() {
+		return nil
+	}
+
+	type result struct {
+		resp *cpapi.ExportsResponse
+		err  error
+	}
+
+	results := make(chan result, 1)
+	go func() {
+		resp, err := client.Exports(&cpapi.ExportsRequest{ServiceNamespace: importNamespace})
+		results <- result{resp: resp, err: err}
+	}()
+
+	var res result
+	select {
+	case res = <-results:
+	case <-time.After(bindingValidationTimeout):
+		cp.logger.Warnf("Timeout listing exports of peer '%s' for validating binding.", binding.Peer)
+		return nil
+	}
+
+	if res.err != nil {
+		cp.logger.Warnf("Cannot list exports of peer '%s' for validating binding: %v.", binding.Peer, res.err)
+		return nil
+	}
+
+	for _, export := range res.resp.Exports {
+		if export.Name == binding.Import {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("peer '%s' does not export service '%s', or its access policies deny access to it",
+		binding.Peer, binding.Import)
+}
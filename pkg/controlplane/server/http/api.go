@@ -14,8 +14,10 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/clusterlink-net/clusterlink/pkg/api"
 	"github.com/clusterlink-net/clusterlink/pkg/controlplane"
@@ -28,41 +30,114 @@ func (s *Server) addAPIHandlers() {
 
 	s.AddObjectHandlers(&rest.ServerObjectSpec{
 		BasePath:      "/peers",
-		Handler:       &peerHandler{cp: s.cp},
+		Handler:       s.audited("peer", &peerHandler{cp: s.cp}),
 		DeleteByValue: false,
+		Key:           func(object any) string { return object.(*api.Peer).Name },
+		Labels:        func(object any) map[string]string { return object.(*api.Peer).Labels },
+		Fields:        peerFields,
+		Watch:         s.watchSpec("peer", func(o any) any { return s.peerToAPI(o.(*store.Peer)) }),
 	})
 
 	s.AddObjectHandlers(&rest.ServerObjectSpec{
 		BasePath:      "/exports",
-		Handler:       &exportHandler{cp: s.cp},
+		Handler:       s.audited("export", &exportHandler{cp: s.cp}),
 		DeleteByValue: false,
+		Key:           func(object any) string { return object.(*api.Export).Name },
+		Labels:        func(object any) map[string]string { return object.(*api.Export).Labels },
+		Fields:        exportFields,
+		Watch:         s.watchSpec("export", func(o any) any { return exportToAPI(o.(*store.Export)) }),
 	})
 
 	s.AddObjectHandlers(&rest.ServerObjectSpec{
 		BasePath:      "/imports",
-		Handler:       &importHandler{cp: s.cp},
+		Handler:       s.audited("import", &importHandler{cp: s.cp}),
 		DeleteByValue: false,
+		Key:           func(object any) string { return object.(*api.Import).Name },
+		Labels:        func(object any) map[string]string { return object.(*api.Import).Labels },
+		Fields:        importFields,
+		Watch:         s.watchSpec("import", func(o any) any { return importToAPI(o.(*store.Import)) }),
 	})
 
 	s.AddObjectHandlers(&rest.ServerObjectSpec{
 		BasePath:      "/bindings",
-		Handler:       &bindingHandler{cp: s.cp},
+		Handler:       s.audited("binding", &bindingHandler{cp: s.cp}),
 		DeleteByValue: true,
+		Key:           func(object any) string { return bindingName(&object.(*api.Binding).Spec) },
+		Fields:        bindingFields,
+		Watch:         s.watchSpec("binding", func(o any) any { return bindingToAPI(o.(*store.Binding)) }),
 	})
 
 	s.AddObjectHandlers(&rest.ServerObjectSpec{
 		BasePath:      "/policies",
-		Handler:       &accessPolicyHandler{cp: s.cp},
+		Handler:       s.audited("accessPolicy", &accessPolicyHandler{cp: s.cp}),
 		DeleteByValue: false,
+		Key:           func(object any) string { return object.(*api.Policy).Name },
+		Labels:        func(object any) map[string]string { return object.(*api.Policy).Labels },
+		Fields:        policyFields,
+		Watch:         s.watchSpec("accessPolicy", func(o any) any { return accessPolicyToAPI(o.(*store.AccessPolicy)) }),
 	})
 
 	s.AddObjectHandlers(&rest.ServerObjectSpec{
 		BasePath:      "/lbpolicies",
-		Handler:       &lbPolicyHandler{cp: s.cp},
+		Handler:       s.audited("lbPolicy", &lbPolicyHandler{cp: s.cp}),
 		DeleteByValue: false,
+		Key:           func(object any) string { return object.(*api.Policy).Name },
+		Labels:        func(object any) map[string]string { return object.(*api.Policy).Labels },
+		Fields:        policyFields,
+		Watch:         s.watchSpec("lbPolicy", func(o any) any { return lbPolicyToAPI(o.(*store.LBPolicy)) }),
 	})
 }
 
+// watchSpec returns the spec for watching the stored objects of the given kind, served as API objects.
+func (s *Server) watchSpec(kind string, toAPI func(object any) any) *rest.WatchSpec {
+	return &rest.WatchSpec{Store: s.cp.ObjectStore(kind), Convert: toAPI}
+}
+
+// peerFields returns the selectable fields of a peer.
+func peerFields(object any) map[string]string {
+	pr := object.(*api.Peer)
+	return map[string]string{
+		"name":         pr.Name,
+		"status.state": pr.Status.State,
+	}
+}
+
+// exportFields returns the selectable fields of an export.
+func exportFields(object any) map[string]string {
+	export := object.(*api.Export)
+	return map[string]string{
+		"name":                 export.Name,
+		"spec.service.host":    export.Spec.Service.Host,
+		"spec.service.port":    strconv.Itoa(int(export.Spec.Service.Port)),
+		"spec.externalService": export.Spec.ExternalService,
+	}
+}
+
+// importFields returns the selectable fields of an import.
+func importFields(object any) map[string]string {
+	imp := object.(*api.Import)
+	return map[string]string{
+		"name":                 imp.Name,
+		"spec.service.host":    imp.Spec.Service.Host,
+		"spec.service.port":    strconv.Itoa(int(imp.Spec.Service.Port)),
+		"status.listener.port": strconv.Itoa(int(imp.Status.Listener.Port)),
+	}
+}
+
+// bindingFields returns the selectable fields of a binding.
+func bindingFields(object any) map[string]string {
+	binding := object.(*api.Binding)
+	return map[string]string{
+		"spec.import": binding.Spec.Import,
+		"spec.peer":   binding.Spec.Peer,
+	}
+}
+
+// policyFields returns the selectable fields of a policy.
+func policyFields(object any) map[string]string {
+	return map[string]string{"name": object.(*api.Policy).Name}
+}
+
 type peerHandler struct {
 	cp *controlplane.Instance
 }
@@ -91,39 +166,50 @@ func (h *peerHandler) Decode(data []byte) (any, error) {
 }
 
 // Create a peer.
-func (h *peerHandler) Create(object any) error {
+func (h *peerHandler) Create(_ context.Context, object any) error {
 	return h.cp.CreatePeer(object.(*store.Peer))
 }
 
 // Update a peer.
-func (h *peerHandler) Update(object any) error {
-	return h.cp.UpdatePeer(object.(*store.Peer))
+func (h *peerHandler) Update(ctx context.Context, object any) error {
+	pr := object.(*store.Peer)
+	pr.ResourceVersion = rest.IfMatch(ctx)
+	return h.cp.UpdatePeer(pr)
 }
 
-func peerToAPI(peer *store.Peer) *api.Peer {
+func peerToAPI(peer *store.Peer, status api.PeerStatus) *api.Peer {
 	if peer == nil {
 		return nil
 	}
 
 	return &api.Peer{
 		Name:   peer.Name,
+		Labels: peer.Labels,
 		Spec:   peer.PeerSpec,
-		Status: api.PeerStatus{},
+		Status: status,
 	}
 }
 
+// peerToAPI converts a peer to its API representation, including its observed status.
+func (s *Server) peerToAPI(peer *store.Peer) *api.Peer {
+	if peer == nil {
+		return nil
+	}
+	return peerToAPI(peer, s.cp.GetPeerStatus(peer.Name))
+}
+
 // Get a peer.
 func (h *peerHandler) Get(name string) (any, error) {
-	peer := peerToAPI(h.cp.GetPeer(name))
+	peer := h.cp.GetPeer(name)
 	if peer == nil {
 		return nil, nil
 	}
-	return peer, nil
+	return &rest.Versioned{Object: peerToAPI(peer, h.cp.GetPeerStatus(name)), ResourceVersion: peer.ResourceVersion}, nil
 }
 
 // Delete a peer.
-func (h *peerHandler) Delete(name any) (any, error) {
-	return h.cp.DeletePeer(name.(string))
+func (h *peerHandler) Delete(ctx context.Context, name any) (any, error) {
+	return h.cp.DeletePeer(name.(string), rest.IfMatch(ctx))
 }
 
 // List all peers.
@@ -131,7 +217,7 @@ func (h *peerHandler) List() (any, error) {
 	peers := h.cp.GetAllPeers()
 	apiPeers := make([]*api.Peer, len(peers))
 	for i, peer := range peers {
-		apiPeers[i] = peerToAPI(peer)
+		apiPeers[i] = peerToAPI(peer, h.cp.GetPeerStatus(peer.Name))
 	}
 	return apiPeers, nil
 }
@@ -163,13 +249,15 @@ func (h *exportHandler) Decode(data []byte) (any, error) {
 }
 
 // Create an export.
-func (h *exportHandler) Create(object any) error {
+func (h *exportHandler) Create(_ context.Context, object any) error {
 	return h.cp.CreateExport(object.(*store.Export))
 }
 
 // Update an export.
-func (h *exportHandler) Update(object any) error {
-	return h.cp.UpdateExport(object.(*store.Export))
+func (h *exportHandler) Update(ctx context.Context, object any) error {
+	export := object.(*store.Export)
+	export.ResourceVersion = rest.IfMatch(ctx)
+	return h.cp.UpdateExport(export)
 }
 
 func exportToAPI(export *store.Export) *api.Export {
@@ -178,23 +266,24 @@ func exportToAPI(export *store.Export) *api.Export {
 	}
 
 	return &api.Export{
-		Name: export.Name,
-		Spec: export.ExportSpec,
+		Name:   export.Name,
+		Labels: export.Labels,
+		Spec:   export.ExportSpec,
 	}
 }
 
 // Get an export.
 func (h *exportHandler) Get(name string) (any, error) {
-	export := exportToAPI(h.cp.GetExport(name))
+	export := h.cp.GetExport(name)
 	if export == nil {
 		return nil, nil
 	}
-	return export, nil
+	return &rest.Versioned{Object: exportToAPI(export), ResourceVersion: export.ResourceVersion}, nil
 }
 
 // Delete an export.
-func (h *exportHandler) Delete(name any) (any, error) {
-	return h.cp.DeleteExport(name.(string))
+func (h *exportHandler) Delete(ctx context.Context, name any) (any, error) {
+	return h.cp.DeleteExport(name.(string), rest.IfMatch(ctx))
 }
 
 // List all exports.
@@ -211,9 +300,15 @@ type importHandler struct {
 	cp *controlplane.Instance
 }
 
-// Decode an import.
+// importWithBindings is an import, to be created atomically with its bindings.
+type importWithBindings struct {
+	*store.Import
+	bindings []*store.Binding
+}
+
+// Decode an import, optionally along with the peers to bind it to.
 func (h *importHandler) Decode(data []byte) (any, error) {
-	var imp api.Import
+	var imp api.ImportWithBindings
 	if err := json.Unmarshal(data, &imp); err != nil {
 		return nil, fmt.Errorf("cannot decode import: %w", err)
 	}
@@ -230,17 +325,40 @@ func (h *importHandler) Decode(data []byte) (any, error) {
 		return nil, fmt.Errorf("missing service port")
 	}
 
-	return store.NewImport(&imp), nil
+	if len(imp.Peers) == 0 {
+		return store.NewImport(&imp.Import), nil
+	}
+
+	bindings := make([]*store.Binding, len(imp.Peers))
+	for i, peer := range imp.Peers {
+		if peer == "" {
+			return nil, fmt.Errorf("empty peer name")
+		}
+
+		bindings[i] = store.NewBinding(&api.Binding{Spec: api.BindingSpec{Import: imp.Name, Peer: peer}})
+	}
+
+	return &importWithBindings{Import: store.NewImport(&imp.Import), bindings: bindings}, nil
 }
 
 // Create an import.
-func (h *importHandler) Create(object any) error {
+func (h *importHandler) Create(_ context.Context, object any) error {
+	if imp, ok := object.(*importWithBindings); ok {
+		return h.cp.CreateImportWithBindings(imp.Import, imp.bindings)
+	}
+
 	return h.cp.CreateImport(object.(*store.Import))
 }
 
 // Update an import.
-func (h *importHandler) Update(object any) error {
-	return h.cp.UpdateImport(object.(*store.Import))
+func (h *importHandler) Update(ctx context.Context, object any) error {
+	if _, ok := object.(*importWithBindings); ok {
+		return fmt.Errorf("peers can only be bound when creating an import")
+	}
+
+	imp := object.(*store.Import)
+	imp.ResourceVersion = rest.IfMatch(ctx)
+	return h.cp.UpdateImport(imp)
 }
 
 func importToAPI(imp *store.Import) *api.Import {
@@ -249,8 +367,9 @@ func importToAPI(imp *store.Import) *api.Import {
 	}
 
 	return &api.Import{
-		Name: imp.Name,
-		Spec: imp.ImportSpec,
+		Name:   imp.Name,
+		Labels: imp.Labels,
+		Spec:   imp.ImportSpec,
 		Status: api.ImportStatus{
 			Listener: api.Endpoint{ // Endpoint.Host is not set
 				Port: imp.Port,
@@ -261,16 +380,16 @@ func importToAPI(imp *store.Import) *api.Import {
 
 // Get an import.
 func (h *importHandler) Get(name string) (any, error) {
-	imp := importToAPI(h.cp.GetImport(name))
+	imp := h.cp.GetImport(name)
 	if imp == nil {
 		return nil, nil
 	}
-	return imp, nil
+	return &rest.Versioned{Object: importToAPI(imp), ResourceVersion: imp.ResourceVersion}, nil
 }
 
 // Delete an import.
-func (h *importHandler) Delete(name any) (any, error) {
-	return h.cp.DeleteImport(name.(string))
+func (h *importHandler) Delete(ctx context.Context, name any) (any, error) {
+	return h.cp.DeleteImport(name.(string), rest.IfMatch(ctx))
 }
 
 // List all imports.
@@ -306,23 +425,27 @@ func (h *bindingHandler) Decode(data []byte) (any, error) {
 }
 
 // Create a binding.
-func (h *bindingHandler) Create(object any) error {
+func (h *bindingHandler) Create(_ context.Context, object any) error {
 	return h.cp.CreateBinding(object.(*store.Binding))
 }
 
 // Create a binding.
-func (h *bindingHandler) Update(object any) error {
+func (h *bindingHandler) Update(_ context.Context, object any) error {
 	return h.cp.UpdateBinding(object.(*store.Binding))
 }
 
 func bindingsToAPI(bindings []*store.Binding) []*api.Binding {
 	apiBindings := make([]*api.Binding, len(bindings))
 	for i, binding := range bindings {
-		apiBindings[i] = &api.Binding{Spec: binding.BindingSpec}
+		apiBindings[i] = bindingToAPI(binding)
 	}
 	return apiBindings
 }
 
+func bindingToAPI(binding *store.Binding) *api.Binding {
+	return &api.Binding{Spec: binding.BindingSpec}
+}
+
 // Get a binding.
 func (h *bindingHandler) Get(name string) (any, error) {
 	binding := bindingsToAPI(h.cp.GetBindings(name))
@@ -333,7 +456,7 @@ func (h *bindingHandler) Get(name string) (any, error) {
 }
 
 // Delete a binding.
-func (h *bindingHandler) Delete(object any) (any, error) {
+func (h *bindingHandler) Delete(_ context.Context, object any) (any, error) {
 	return h.cp.DeleteBinding(object.(*store.Binding))
 }
 
@@ -361,13 +484,15 @@ func (h *accessPolicyHandler) Decode(data []byte) (any, error) {
 }
 
 // Create an access policy.
-func (h *accessPolicyHandler) Create(object any) error {
+func (h *accessPolicyHandler) Create(_ context.Context, object any) error {
 	return h.cp.CreateAccessPolicy(object.(*store.AccessPolicy))
 }
 
 // Update an access policy.
-func (h *accessPolicyHandler) Update(object any) error {
-	return h.cp.UpdateAccessPolicy(object.(*store.AccessPolicy))
+func (h *accessPolicyHandler) Update(ctx context.Context, object any) error {
+	policy := object.(*store.AccessPolicy)
+	policy.ResourceVersion = rest.IfMatch(ctx)
+	return h.cp.UpdateAccessPolicy(policy)
 }
 
 func accessPolicyToAPI(policy *store.AccessPolicy) *api.Policy {
@@ -375,8 +500,8 @@ func accessPolicyToAPI(policy *store.AccessPolicy) *api.Policy {
 }
 
 // Delete an access policy.
-func (h *accessPolicyHandler) Delete(name any) (any, error) {
-	return h.cp.DeleteAccessPolicy(name.(string))
+func (h *accessPolicyHandler) Delete(ctx context.Context, name any) (any, error) {
+	return h.cp.DeleteAccessPolicy(name.(string), rest.IfMatch(ctx))
 }
 
 // Get an access policy.
@@ -385,7 +510,7 @@ func (h *accessPolicyHandler) Get(name string) (any, error) {
 	if policy == nil {
 		return nil, nil
 	}
-	return accessPolicyToAPI(policy), nil
+	return &rest.Versioned{Object: accessPolicyToAPI(policy), ResourceVersion: policy.ResourceVersion}, nil
 }
 
 // List all access policies.
@@ -417,13 +542,15 @@ func (h *lbPolicyHandler) Decode(data []byte) (any, error) {
 }
 
 // Create a load-balancing policy.
-func (h *lbPolicyHandler) Create(object any) error {
+func (h *lbPolicyHandler) Create(_ context.Context, object any) error {
 	return h.cp.CreateLBPolicy(object.(*store.LBPolicy))
 }
 
 // Update an load-balancing policy.
-func (h *lbPolicyHandler) Update(object any) error {
-	return h.cp.UpdateLBPolicy(object.(*store.LBPolicy))
+func (h *lbPolicyHandler) Update(ctx context.Context, object any) error {
+	policy := object.(*store.LBPolicy)
+	policy.ResourceVersion = rest.IfMatch(ctx)
+	return h.cp.UpdateLBPolicy(policy)
 }
 
 func lbPolicyToAPI(policy *store.LBPolicy) *api.Policy {
@@ -431,8 +558,8 @@ func lbPolicyToAPI(policy *store.LBPolicy) *api.Policy {
 }
 
 // Delete a load-balancing policy.
-func (h *lbPolicyHandler) Delete(name any) (any, error) {
-	return h.cp.DeleteLBPolicy(name.(string))
+func (h *lbPolicyHandler) Delete(ctx context.Context, name any) (any, error) {
+	return h.cp.DeleteLBPolicy(name.(string), rest.IfMatch(ctx))
 }
 
 // Get an load-balancing policy.
@@ -441,7 +568,7 @@ func (h *lbPolicyHandler) Get(name string) (any, error) {
 	if policy == nil {
 		return nil, nil
 	}
-	return lbPolicyToAPI(policy), nil
+	return &rest.Versioned{Object: lbPolicyToAPI(policy), ResourceVersion: policy.ResourceVersion}, nil
 }
 
 // List all load-balancing policies.
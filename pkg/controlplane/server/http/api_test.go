@@ -0,0 +1,135 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	"github.com/clusterlink-net/clusterlink/pkg/util/rest"
+)
+
+func TestPeerStatus(t *testing.T) {
+	server, cp, _ := newServer(t, "peer1")
+	require.Nil(t, cp.StartLeading())
+	t.Cleanup(cp.StopLeading)
+
+	peers := &api.Backup{
+		Version: api.BackupVersion,
+		Peer:    "peer1",
+		Peers: []api.Peer{
+			{Name: "peer2", Spec: api.PeerSpec{Gateways: []api.Endpoint{{Host: "127.0.0.1", Port: 1}}}},
+		},
+	}
+	changes(t, restore(t, server, peers, false))
+
+	list := func(selector string) []api.Peer {
+		path := "/peers?" + rest.FieldSelectorParam + "=" + url.QueryEscape(selector)
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var result []api.Peer
+		require.Nil(t, json.Unmarshal(w.Body.Bytes(), &result))
+		return result
+	}
+
+	// the peer never responded to a heartbeat
+	unreachable := list("status.state=" + api.PeerStateUnreachable)
+	require.Len(t, unreachable, 1)
+	require.Equal(t, "peer2", unreachable[0].Name)
+	require.Equal(t, cp.GetPeerStatus("peer2"), unreachable[0].Status)
+	require.Empty(t, unreachable[0].Status.LastSeen)
+
+	require.Empty(t, list("status.state="+api.PeerStateReachable))
+
+	// status is not part of a backup
+	require.Equal(t, api.PeerStatus{}, backup(t, server).Peers[0].Status)
+}
+
+func TestCreateImportWithBindings(t *testing.T) {
+	server, cp, platform := newServer(t, "peer1")
+	require.Nil(t, cp.StartLeading())
+	t.Cleanup(cp.StopLeading)
+
+	create := func(peers ...string) int {
+		encoded, err := json.Marshal(&api.ImportWithBindings{
+			Import: api.Import{Name: "redis", Spec: api.ImportSpec{Service: api.Endpoint{Host: "redis", Port: 6379}}},
+			Peers:  peers,
+		})
+		require.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/imports", bytes.NewReader(encoded)))
+		return w.Code
+	}
+
+	// the second binding fails, rolling back the import and the first binding
+	require.Equal(t, http.StatusBadRequest, create("peer2", "peer2"))
+	state := backup(t, server)
+	require.Empty(t, state.Imports)
+	require.Empty(t, state.Bindings)
+	require.Empty(t, platform.services)
+
+	require.Equal(t, http.StatusCreated, create("peer2", "peer3"))
+	state = backup(t, server)
+	require.Len(t, state.Imports, 1)
+	require.Equal(t, []api.Binding{
+		{Spec: api.BindingSpec{Import: "redis", Peer: "peer2"}},
+		{Spec: api.BindingSpec{Import: "redis", Peer: "peer3"}},
+	}, state.Bindings)
+	require.Contains(t, platform.services, "redis")
+}
+
+func TestDeleteResourceVersion(t *testing.T) {
+	server, cp, _ := newServer(t, "peer1")
+	require.Nil(t, cp.StartLeading())
+	t.Cleanup(cp.StopLeading)
+
+	changes(t, restore(t, server, &api.Backup{
+		Version: api.BackupVersion,
+		Peer:    "peer1",
+		Exports: []api.Export{{Name: "mysql", Spec: api.ExportSpec{Service: api.Endpoint{Host: "mysql", Port: 3306}}}},
+	}, false))
+
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/exports/mysql", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+	version, err := rest.ParseETag(w.Header().Get("ETag"))
+	require.Nil(t, err)
+
+	remove := func(version uint64) int {
+		r := httptest.NewRequest(http.MethodDelete, "/exports/mysql", http.NoBody)
+		r.Header.Set("If-Match", rest.FormatETag(version))
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// a stale resource version is rejected by the store, leaving the export in place
+	require.Equal(t, http.StatusConflict, remove(version+1))
+	require.NotNil(t, cp.GetExport("mysql"))
+	require.Len(t, backup(t, server).Exports, 1)
+
+	require.Equal(t, http.StatusNoContent, remove(version))
+	require.Nil(t, cp.GetExport("mysql"))
+	require.Empty(t, backup(t, server).Exports)
+}
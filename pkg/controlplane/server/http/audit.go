@@ -0,0 +1,147 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/audit"
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/store"
+	"github.com/clusterlink-net/clusterlink/pkg/util/rest"
+)
+
+// identifyCaller is a middleware which sets the identity of the client certificate in the request context.
+// The identity is taken from the certificate SANs, falling back to its common name.
+func (s *Server) identifyCaller(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller := "unknown"
+		if forwarded := r.Header.Get(api.ForwardedCallerHeader); forwarded != "" && s.isLocalControlplane(r) {
+			// request was forwarded by a follower replica
+			caller = forwarded
+		} else if peerName, ok := remotePeerName(r); ok {
+			// a remote peer, identified the same way as for authorization
+			caller = peerName
+		} else if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			cert := r.TLS.PeerCertificates[0]
+			switch {
+			case len(cert.URIs) > 0:
+				caller = cert.URIs[0].String()
+			case len(cert.DNSNames) > 0:
+				caller = cert.DNSNames[0]
+			case cert.Subject.CommonName != "":
+				caller = cert.Subject.CommonName
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(audit.WithCaller(r.Context(), caller)))
+	})
+}
+
+// auditedHandler wraps a rest.Handler, recording all object mutations.
+type auditedHandler struct {
+	rest.Handler
+	kind    string
+	auditor *audit.Auditor
+}
+
+// Create an object.
+func (h *auditedHandler) Create(ctx context.Context, object any) error {
+	err := h.Handler.Create(ctx, object)
+	h.record(ctx, audit.ActionCreate, object, nil, err)
+	return err
+}
+
+// Update an object.
+func (h *auditedHandler) Update(ctx context.Context, object any) error {
+	before := h.get(objectName(object))
+	err := h.Handler.Update(ctx, object)
+	h.record(ctx, audit.ActionUpdate, object, before, err)
+	return err
+}
+
+// Delete an object.
+func (h *auditedHandler) Delete(ctx context.Context, object any) (any, error) {
+	before := h.get(objectName(object))
+	result, err := h.Handler.Delete(ctx, object)
+	h.record(ctx, audit.ActionDelete, object, before, err)
+	return result, err
+}
+
+func (h *auditedHandler) record(ctx context.Context, action audit.Action, object, before any, err error) {
+	name := objectName(object)
+	mutation := &audit.Mutation{
+		Caller: audit.CallerFromContext(ctx),
+		Action: action,
+		Kind:   h.kind,
+		Name:   name,
+		Before: before,
+	}
+
+	if err != nil {
+		mutation.Error = err.Error()
+	} else {
+		mutation.After = h.get(name)
+	}
+
+	h.auditor.RecordMutation(mutation)
+}
+
+// get returns the object recorded for the given name, without its resource version.
+func (h *auditedHandler) get(name string) any {
+	object, _ := h.Handler.Get(name)
+	if versioned, ok := object.(*rest.Versioned); ok {
+		return versioned.Object
+	}
+	return object
+}
+
+// objectName returns the name used for getting an object, given the object or its name.
+func objectName(object any) string {
+	switch o := object.(type) {
+	case string:
+		return o
+	case *store.Peer:
+		return o.Name
+	case *store.Export:
+		return o.Name
+	case *store.Import:
+		return o.Name
+	case *importWithBindings:
+		return o.Name
+	case *store.Binding:
+		// bindings are fetched by their import
+		return o.Import
+	case *store.AccessPolicy:
+		return o.Name
+	case *store.LBPolicy:
+		return o.Name
+	default:
+		return ""
+	}
+}
+
+// audited returns a handler recording mutations of objects of the given kind, if auditing is enabled.
+func (s *Server) audited(kind string, handler rest.Handler) rest.Handler {
+	if !s.auditor.Enabled() {
+		return handler
+	}
+
+	return &auditedHandler{
+		Handler: handler,
+		kind:    kind,
+		auditor: s.auditor,
+	}
+}
@@ -19,8 +19,11 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/go-chi/chi"
+
 	"github.com/clusterlink-net/clusterlink/pkg/controlplane"
 	"github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+	dpapi "github.com/clusterlink-net/clusterlink/pkg/dataplane/api"
 )
 
 const (
@@ -31,8 +34,37 @@ func (s *Server) addAuthzHandlers() {
 	r := s.Router()
 
 	r.Post(api.RemotePeerAuthorizationPath, s.PeerAuthorize)
-	r.Post(api.DataplaneEgressAuthorizationPath, s.DataplaneEgressAuthorize)
-	r.Post(api.DataplaneIngressAuthorizationPath, s.DataplaneIngressAuthorize)
+
+	// dataplane authorization endpoints are restricted to the local dataplane
+	r.Group(func(r chi.Router) {
+		r.Use(s.authenticateDataplane)
+
+		r.Post(api.DataplaneEgressAuthorizationPath, s.DataplaneEgressAuthorize)
+		r.Post(api.DataplaneIngressAuthorizationPath, s.DataplaneIngressAuthorize)
+	})
+}
+
+// authenticateDataplane verifies that a request originates from the local dataplane,
+// by checking that the client certificate carries the local dataplane server name.
+func (s *Server) authenticateDataplane(next http.Handler) http.Handler {
+	dataplaneName := dpapi.DataplaneServerName(s.cp.PeerName())
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+			http.Error(w, "missing client certificate", http.StatusUnauthorized)
+			return
+		}
+
+		for _, dnsName := range r.TLS.PeerCertificates[0].DNSNames {
+			if dnsName == dataplaneName {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		s.logger.Warnf("Rejecting dataplane authorization request to %s: client is not '%s'.",
+			r.URL.Path, dataplaneName)
+		http.Error(w, "client certificate does not belong to the local dataplane", http.StatusForbidden)
+	})
 }
 
 // PeerAuthorize authorizes a remote peer controlplane request for accessing an exported service,
@@ -44,13 +76,12 @@ func (s *Server) PeerAuthorize(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 || len(r.TLS.PeerCertificates[0].DNSNames) != 2 ||
-		r.TLS.PeerCertificates[0].DNSNames[0] == "" {
+	peerName, ok := remotePeerName(r)
+	if !ok {
 		http.Error(w, "certificate does not contain a valid DNS name for the peer gateway", http.StatusBadRequest)
 		return
 	}
 
-	peerName := r.TLS.PeerCertificates[0].DNSNames[0]
 	resp, err := s.cp.AuthorizeIngress(
 		&controlplane.IngressAuthorizationRequest{
 			ServiceName:      req.ServiceName,
@@ -81,10 +112,18 @@ func (s *Server) PeerAuthorize(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// remotePeerName returns the name of the remote peer sending a request, as set in its client certificate.
+func remotePeerName(r *http.Request) (string, bool) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 || len(r.TLS.PeerCertificates[0].DNSNames) != 2 ||
+		r.TLS.PeerCertificates[0].DNSNames[0] == "" {
+		return "", false
+	}
+
+	return r.TLS.PeerCertificates[0].DNSNames[0], true
+}
+
 // DataplaneEgressAuthorize authorizes access to an imported service.
 func (s *Server) DataplaneEgressAuthorize(w http.ResponseWriter, r *http.Request) {
-	// TODO: verify that request originates from local dataplane
-
 	ip := r.Header.Get(api.ClientIPHeader)
 	if ip == "" {
 		http.Error(w, fmt.Sprintf("missing '%s' header", api.ClientIPHeader), http.StatusBadRequest)
@@ -107,6 +146,7 @@ func (s *Server) DataplaneEgressAuthorize(w http.ResponseWriter, r *http.Request
 		ImportName:      importName,
 		ImportNamespace: importNamespace,
 		IP:              ip,
+		SPIFFEID:        r.Header.Get(api.ClientSPIFFEIDHeader),
 	})
 
 	switch {
@@ -0,0 +1,74 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+	dpapi "github.com/clusterlink-net/clusterlink/pkg/dataplane/api"
+)
+
+func TestAuthenticateDataplane(t *testing.T) {
+	server, _, _ := newServer(t, "peer1")
+
+	tests := []struct {
+		name     string
+		dnsNames []string
+		code     int
+	}{
+		{
+			name: "no client certificate",
+			code: http.StatusUnauthorized,
+		},
+		{
+			name:     "remote peer dataplane",
+			dnsNames: []string{dpapi.DataplaneServerName("peer2")},
+			code:     http.StatusForbidden,
+		},
+		{
+			name:     "local peer controlplane",
+			dnsNames: []string{"peer1", api.GRPCServerName("peer1")},
+			code:     http.StatusForbidden,
+		},
+		{
+			// the request is passed to the egress handler, which requires a client IP header
+			name:     "local dataplane",
+			dnsNames: []string{dpapi.DataplaneServerName("peer1")},
+			code:     http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, api.DataplaneEgressAuthorizationPath, http.NoBody)
+			if tt.dnsNames != nil {
+				r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{DNSNames: tt.dnsNames}}}
+			}
+
+			w := httptest.NewRecorder()
+			server.Router().ServeHTTP(w, r)
+			require.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusBadRequest {
+				require.Contains(t, w.Body.String(), api.ClientIPHeader)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/store"
+	"github.com/clusterlink-net/clusterlink/pkg/util/rest"
+)
+
+func (s *Server) addBackupHandlers() {
+	r := s.Router()
+
+	r.Get(api.BackupPath, s.Backup)
+	r.Post(api.RestorePath, s.Restore)
+}
+
+// Backup returns a snapshot of the controlplane configuration.
+func (s *Server) Backup(w http.ResponseWriter, _ *http.Request) {
+	backup := &api.Backup{
+		Version: api.BackupVersion,
+		Peer:    s.cp.PeerName(),
+		Time:    time.Now().UTC().Format(time.RFC3339),
+	}
+
+	for _, pr := range s.cp.GetAllPeers() {
+		backup.Peers = append(backup.Peers, *peerToAPI(pr, api.PeerStatus{}))
+	}
+	sort.Slice(backup.Peers, func(i, j int) bool { return backup.Peers[i].Name < backup.Peers[j].Name })
+
+	for _, export := range s.cp.GetAllExports() {
+		backup.Exports = append(backup.Exports, *exportToAPI(export))
+	}
+	sort.Slice(backup.Exports, func(i, j int) bool { return backup.Exports[i].Name < backup.Exports[j].Name })
+
+	for _, imp := range s.cp.GetAllImports() {
+		backup.Imports = append(backup.Imports, *importToAPI(imp))
+	}
+	sort.Slice(backup.Imports, func(i, j int) bool { return backup.Imports[i].Name < backup.Imports[j].Name })
+
+	for _, binding := range s.cp.GetAllBindings() {
+		backup.Bindings = append(backup.Bindings, api.Binding{Spec: binding.BindingSpec})
+	}
+	sort.Slice(backup.Bindings, func(i, j int) bool {
+		return bindingName(&backup.Bindings[i].Spec) < bindingName(&backup.Bindings[j].Spec)
+	})
+
+	for _, policy := range s.cp.GetAllAccessPolicies() {
+		backup.AccessPolicies = append(backup.AccessPolicies, policy.Policy)
+	}
+	sort.Slice(backup.AccessPolicies, func(i, j int) bool {
+		return backup.AccessPolicies[i].Name < backup.AccessPolicies[j].Name
+	})
+
+	for _, policy := range s.cp.GetAllLBPolicies() {
+		backup.LBPolicies = append(backup.LBPolicies, policy.Policy)
+	}
+	sort.Slice(backup.LBPolicies, func(i, j int) bool { return backup.LBPolicies[i].Name < backup.LBPolicies[j].Name })
+
+	s.writeJSON(w, backup)
+}
+
+// Restore applies a backup of the controlplane configuration.
+// Missing objects are created, and objects which differ from the backup are updated.
+// Objects which do not appear in the backup are left as is.
+func (s *Server) Restore(w http.ResponseWriter, r *http.Request) {
+	var backup api.Backup
+	if err := json.NewDecoder(r.Body).Decode(&backup); err != nil {
+		http.Error(w, fmt.Sprintf("cannot decode backup: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if backup.Version != api.BackupVersion {
+		http.Error(w, fmt.Sprintf("unsupported backup version '%s', expected '%s'",
+			backup.Version, api.BackupVersion), http.StatusBadRequest)
+		return
+	}
+
+	dryRun := false
+	if param := r.URL.Query().Get(api.RestoreDryRunParam); param != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(param); err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s parameter: %v", api.RestoreDryRunParam, err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	s.logger.Infof("Restoring backup of peer '%s' taken at %s (dry-run: %v).", backup.Peer, backup.Time, dryRun)
+
+	s.writeJSON(w, s.restore(r.Context(), &backup, dryRun))
+}
+
+// restore applies a backup through the regular object handlers, so that all side effects
+// (e.g. xDS resources and k8s services) are rebuilt.
+func (s *Server) restore(ctx context.Context, backup *api.Backup, dryRun bool) *api.RestoreResult {
+	result := &api.RestoreResult{DryRun: dryRun}
+	add := func(change *api.RestoreChange) {
+		result.Changes = append(result.Changes, *change)
+	}
+
+	peers := s.audited("peer", &peerHandler{cp: s.cp})
+	for i := range backup.Peers {
+		pr := &backup.Peers[i]
+		var before any
+		if existing := s.cp.GetPeer(pr.Name); existing != nil {
+			before = labeledSpec(existing.Labels, existing.PeerSpec)
+		}
+		add(s.restoreObject(ctx, peers, "peer", pr.Name, pr, before, labeledSpec(pr.Labels, pr.Spec), dryRun, nil))
+	}
+
+	exports := s.audited("export", &exportHandler{cp: s.cp})
+	for i := range backup.Exports {
+		export := &backup.Exports[i]
+		var before any
+		if existing := s.cp.GetExport(export.Name); existing != nil {
+			before = labeledSpec(existing.Labels, existing.ExportSpec)
+		}
+		add(s.restoreObject(ctx, exports, "export", export.Name, export, before, labeledSpec(export.Labels, export.Spec),
+			dryRun, nil))
+	}
+
+	imports := s.audited("import", &importHandler{cp: s.cp})
+	for i := range backup.Imports {
+		imp := &backup.Imports[i]
+		var before any
+		if existing := s.cp.GetImport(imp.Name); existing != nil {
+			before = labeledSpec(existing.Labels, existing.ImportSpec)
+		}
+		// try keeping the listener port of the imported service
+		keepPort := func(object any) {
+			object.(*store.Import).Port = imp.Status.Listener.Port
+		}
+		add(s.restoreObject(ctx, imports, "import", imp.Name, imp, before, labeledSpec(imp.Labels, imp.Spec), dryRun,
+			keepPort))
+	}
+
+	bindings := s.audited("binding", &bindingHandler{cp: s.cp})
+	for i := range backup.Bindings {
+		binding := &backup.Bindings[i]
+		var before any
+		for _, existing := range s.cp.GetBindings(binding.Spec.Import) {
+			if existing.Peer == binding.Spec.Peer {
+				before = existing.BindingSpec
+			}
+		}
+		add(s.restoreObject(ctx, bindings, "binding", bindingName(&binding.Spec), binding, before, binding.Spec,
+			dryRun, nil))
+	}
+
+	accessPolicies := s.audited("accessPolicy", &accessPolicyHandler{cp: s.cp})
+	for i := range backup.AccessPolicies {
+		policy := &backup.AccessPolicies[i]
+		var before any
+		if existing := s.cp.GetAccessPolicy(policy.Name); existing != nil {
+			before = labeledSpec(existing.Labels, policySpec(&existing.Spec))
+		}
+		add(s.restoreObject(ctx, accessPolicies, "accessPolicy", policy.Name, policy, before,
+			labeledSpec(policy.Labels, policySpec(&policy.Spec)), dryRun, nil))
+	}
+
+	lbPolicies := s.audited("lbPolicy", &lbPolicyHandler{cp: s.cp})
+	for i := range backup.LBPolicies {
+		policy := &backup.LBPolicies[i]
+		var before any
+		if existing := s.cp.GetLBPolicy(policy.Name); existing != nil {
+			before = labeledSpec(existing.Labels, policySpec(&existing.Spec))
+		}
+		add(s.restoreObject(ctx, lbPolicies, "lbPolicy", policy.Name, policy, before,
+			labeledSpec(policy.Labels, policySpec(&policy.Spec)), dryRun, nil))
+	}
+
+	return result
+}
+
+// restoreObject creates or updates a single object, given its current spec (before) and the spec in the backup (after).
+// prepare, if set, is called on the decoded object before it is created.
+func (s *Server) restoreObject(
+	ctx context.Context,
+	handler rest.Handler,
+	kind, name string,
+	object, before, after any,
+	dryRun bool,
+	prepare func(any),
+) *api.RestoreChange {
+	change := &api.RestoreChange{
+		Kind:   kind,
+		Name:   name,
+		Action: api.RestoreCreate,
+		Before: before,
+		After:  after,
+	}
+
+	if before != nil {
+		change.Action = api.RestoreUpdate
+		if rest.SameJSON(before, after) {
+			change.Action = api.RestoreNone
+		}
+	}
+
+	// validate the object, even on a dry-run
+	encoded, err := json.Marshal(object)
+	if err == nil {
+		object, err = handler.Decode(encoded)
+	}
+	if err != nil {
+		change.Error = err.Error()
+		return change
+	}
+
+	if dryRun {
+		return change
+	}
+
+	switch change.Action {
+	case api.RestoreCreate:
+		if prepare != nil {
+			prepare(object)
+		}
+		err = handler.Create(ctx, object)
+	case api.RestoreUpdate:
+		err = handler.Update(ctx, object)
+	}
+
+	if err != nil {
+		s.logger.Errorf("Cannot restore %s '%s': %v.", kind, name, err)
+		change.Error = err.Error()
+	}
+
+	return change
+}
+
+// writeJSON writes an object as a JSON response.
+func (s *Server) writeJSON(w http.ResponseWriter, object any) {
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if _, err := w.Write(encoded); err != nil {
+		s.logger.Errorf("Cannot write http response: %v.", err)
+	}
+}
+
+// bindingName returns a name identifying a binding.
+func bindingName(binding *api.BindingSpec) string {
+	return binding.Import + "/" + binding.Peer
+}
+
+// labeledSpec returns a displayable spec, together with the labels of its object (if any).
+func labeledSpec(labels map[string]string, spec any) any {
+	if len(labels) == 0 {
+		return spec
+	}
+
+	return struct {
+		Labels map[string]string
+		Spec   any
+	}{labels, spec}
+}
+
+// policySpec returns a displayable policy spec, where a JSON blob is shown as is rather than base64-encoded.
+func policySpec(spec *api.PolicySpec) any {
+	if json.Valid(spec.Blob) {
+		return struct{ Blob json.RawMessage }{Blob: spec.Blob}
+	}
+	return spec
+}
@@ -0,0 +1,252 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	"github.com/clusterlink-net/clusterlink/pkg/bootstrap"
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane"
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/audit"
+	cphttp "github.com/clusterlink-net/clusterlink/pkg/controlplane/server/http"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv/bolt"
+	"github.com/clusterlink-net/clusterlink/pkg/util/tls"
+)
+
+// fakePlatform is a platform which only records the services applied.
+type fakePlatform struct {
+	// services maps the host of each service to its target port.
+	services map[string]uint16
+	// ports maps the host of each service to its port.
+	ports map[string]uint16
+}
+
+func (p *fakePlatform) ApplyService(_, host, _ string, port, targetPort uint16) error {
+	p.services[host] = targetPort
+	p.ports[host] = port
+	return nil
+}
+
+func (p *fakePlatform) ApplyExternalService(_, host, _ string) error {
+	p.services[host] = 0
+	return nil
+}
+
+func (p *fakePlatform) RemoveService(_, host string) error {
+	delete(p.services, host)
+	delete(p.ports, host)
+	return nil
+}
+
+func (p *fakePlatform) GetService(host string) (*corev1.Service, error) {
+	port, ok := p.ports[host]
+	if !ok {
+		return nil, nil
+	}
+
+	service := &corev1.Service{}
+	service.Name = host
+	service.Spec.Ports = []corev1.ServicePort{{Port: int32(port)}}
+	return service, nil
+}
+
+func (p *fakePlatform) GetLabelsFromIP(string) map[string]string {
+	return nil
+}
+
+// newServer returns a controlplane HTTP server of a peer, backed by a new bolt store.
+func newServer(t *testing.T, peerName string) (*cphttp.Server, *controlplane.Instance, *fakePlatform) {
+	validity := time.Hour
+	fabricCert, err := bootstrap.CreateFabricCertificate(validity)
+	require.Nil(t, err)
+	peerCert, err := bootstrap.CreatePeerCertificate(peerName, fabricCert, validity)
+	require.Nil(t, err)
+	controlplaneCert, err := bootstrap.CreateControlplaneCertificate(peerName, peerCert, validity)
+	require.Nil(t, err)
+
+	parsedCertData, err := tls.ParseData(fabricCert.RawCert(), controlplaneCert.RawCert(), controlplaneCert.RawKey())
+	require.Nil(t, err)
+
+	kvStore, err := bolt.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.Nil(t, err)
+	t.Cleanup(func() { require.Nil(t, kvStore.Close()) })
+
+	platform := &fakePlatform{services: make(map[string]uint16), ports: make(map[string]uint16)}
+	auditor := audit.NewAuditor()
+	cp, err := controlplane.NewInstance(parsedCertData, kv.NewManager(kvStore), platform, auditor)
+	require.Nil(t, err)
+
+	return cphttp.NewServer(cp, auditor, nil), cp, platform
+}
+
+func backup(t *testing.T, server *cphttp.Server) *api.Backup {
+	w := httptest.NewRecorder()
+	server.Backup(w, httptest.NewRequest(http.MethodGet, api.BackupPath, http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var result api.Backup
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), &result))
+	return &result
+}
+
+func restore(t *testing.T, server *cphttp.Server, backup *api.Backup, dryRun bool) *api.RestoreResult {
+	encoded, err := json.Marshal(backup)
+	require.Nil(t, err)
+
+	path := api.RestorePath
+	if dryRun {
+		path += "?" + api.RestoreDryRunParam + "=true"
+	}
+
+	w := httptest.NewRecorder()
+	server.Restore(w, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(encoded)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var result api.RestoreResult
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Equal(t, dryRun, result.DryRun)
+	return &result
+}
+
+// changes returns the kind, name and action of each restore change, failing on restore errors.
+func changes(t *testing.T, result *api.RestoreResult) [][3]string {
+	var summary [][3]string
+	for _, change := range result.Changes {
+		require.Empty(t, change.Error, "%s '%s'", change.Kind, change.Name)
+		summary = append(summary, [3]string{change.Kind, change.Name, string(change.Action)})
+	}
+	return summary
+}
+
+func TestBackupRestore(t *testing.T) {
+	policy := []byte(`{"name":"allow-all","action":"allow","from":[{"workloadSelector":{}}],"to":[{"workloadSelector":{}}]}`)
+
+	// objects are given out of order, to check that a backup is sorted
+	original := &api.Backup{
+		Version: api.BackupVersion,
+		Peer:    "peer1",
+		Peers: []api.Peer{
+			{Name: "peer3", Spec: api.PeerSpec{Gateways: []api.Endpoint{{Host: "peer3.example.com", Port: 443}}}},
+			{
+				Name:   "peer2",
+				Labels: map[string]string{"region": "eu"},
+				Spec:   api.PeerSpec{Gateways: []api.Endpoint{{Host: "peer2.example.com", Port: 443}}},
+			},
+		},
+		Exports: []api.Export{
+			{Name: "mysql", Spec: api.ExportSpec{Service: api.Endpoint{Host: "mysql", Port: 3306}}},
+		},
+		Imports: []api.Import{
+			{
+				Name:   "redis",
+				Spec:   api.ImportSpec{Service: api.Endpoint{Host: "redis", Port: 6379}},
+				Status: api.ImportStatus{Listener: api.Endpoint{Port: 12345}},
+			},
+			{
+				Name:   "nginx",
+				Spec:   api.ImportSpec{Service: api.Endpoint{Host: "nginx", Port: 80}},
+				Status: api.ImportStatus{Listener: api.Endpoint{Port: 23456}},
+			},
+		},
+		Bindings: []api.Binding{
+			{Spec: api.BindingSpec{Import: "redis", Peer: "peer3"}},
+			{Spec: api.BindingSpec{Import: "nginx", Peer: "peer2"}},
+		},
+		AccessPolicies: []api.Policy{{Name: "allow-all", Spec: api.PolicySpec{Blob: policy}}},
+	}
+
+	server, _, platform := newServer(t, "peer1")
+
+	// a dry-run lists the objects to create, in dependency order, without creating them
+	expectedCreate := [][3]string{
+		{"peer", "peer3", string(api.RestoreCreate)},
+		{"peer", "peer2", string(api.RestoreCreate)},
+		{"export", "mysql", string(api.RestoreCreate)},
+		{"import", "redis", string(api.RestoreCreate)},
+		{"import", "nginx", string(api.RestoreCreate)},
+		{"binding", "redis/peer3", string(api.RestoreCreate)},
+		{"binding", "nginx/peer2", string(api.RestoreCreate)},
+		{"accessPolicy", "allow-all", string(api.RestoreCreate)},
+	}
+	require.Equal(t, expectedCreate, changes(t, restore(t, server, original, true)))
+
+	empty := backup(t, server)
+	require.Equal(t, "peer1", empty.Peer)
+	require.Empty(t, empty.Peers)
+	require.Empty(t, empty.Imports)
+	require.Empty(t, platform.services)
+
+	// restore
+	require.Equal(t, expectedCreate, changes(t, restore(t, server, original, false)))
+
+	// backup is sorted, and preserves the import listener ports
+	restored := backup(t, server)
+	require.Len(t, restored.Peers, 2)
+	require.Equal(t, "peer2", restored.Peers[0].Name)
+	require.Equal(t, original.Peers[1].Labels, restored.Peers[0].Labels)
+	require.Equal(t, original.Peers[1].Spec, restored.Peers[0].Spec)
+	require.Equal(t, "peer3", restored.Peers[1].Name)
+	require.Equal(t, original.Exports, restored.Exports)
+	require.Equal(t, []api.Import{original.Imports[1], original.Imports[0]}, restored.Imports)
+	require.Equal(t, []api.Binding{original.Bindings[1], original.Bindings[0]}, restored.Bindings)
+	require.Len(t, restored.AccessPolicies, 1)
+	require.Equal(t, "allow-all", restored.AccessPolicies[0].Name)
+	require.Equal(t, map[string]uint16{"redis": 12345, "nginx": 23456}, platform.services)
+
+	// restoring the backup into the same peer is a no-op
+	result := restore(t, server, restored, true)
+	for _, change := range changes(t, result) {
+		require.Equal(t, string(api.RestoreNone), change[2], "%s '%s'", change[0], change[1])
+	}
+
+	// a dry-run of a modified backup shows the difference
+	modified := *restored
+	modified.Exports = []api.Export{
+		{Name: "mysql", Spec: api.ExportSpec{Service: api.Endpoint{Host: "mysql", Port: 3307}}},
+	}
+	result = restore(t, server, &modified, true)
+	var exportChange *api.RestoreChange
+	for i := range result.Changes {
+		if result.Changes[i].Kind == "export" {
+			exportChange = &result.Changes[i]
+		}
+	}
+	require.NotNil(t, exportChange)
+	require.Equal(t, api.RestoreUpdate, exportChange.Action)
+	require.Equal(t, float64(3306), exportChange.Before.(map[string]any)["Service"].(map[string]any)["Port"])
+	require.Equal(t, float64(3307), exportChange.After.(map[string]any)["Service"].(map[string]any)["Port"])
+
+	// the dry-run did not change the export
+	require.Equal(t, original.Exports, backup(t, server).Exports)
+
+	// a backup restored into another peer re-creates the same configuration
+	other, _, otherPlatform := newServer(t, "peer4")
+	for _, change := range changes(t, restore(t, other, restored, false)) {
+		require.Equal(t, string(api.RestoreCreate), change[2], "%s '%s'", change[0], change[1])
+	}
+	require.Equal(t, restored.Imports, backup(t, other).Imports)
+	require.Equal(t, restored.Bindings, backup(t, other).Bindings)
+	require.Equal(t, platform.services, otherPlatform.services)
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	cpapi "github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+)
+
+func (s *Server) addDiagnoseHandlers() {
+	r := s.Router()
+
+	r.Get(api.DiagnoseImportPath+"/{name}", s.DiagnoseImport)
+	r.Post(cpapi.RemotePeerCheckPath, s.PeerCheck)
+}
+
+// DiagnoseImport checks the chain of components serving an imported service.
+func (s *Server) DiagnoseImport(w http.ResponseWriter, r *http.Request) {
+	s.writeJSON(w, s.cp.DiagnoseImport(chi.URLParam(r, "name")))
+}
+
+// PeerCheck checks a remote peer controlplane request for accessing an exported service, for diagnosis.
+func (s *Server) PeerCheck(w http.ResponseWriter, r *http.Request) {
+	var req cpapi.CheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	peerName, ok := remotePeerName(r)
+	if !ok {
+		http.Error(w, "certificate does not contain a valid DNS name for the peer gateway", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.cp.CheckIngress(&req, peerName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.writeJSON(w, resp)
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	cpapi "github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+	cphttp "github.com/clusterlink-net/clusterlink/pkg/controlplane/server/http"
+)
+
+var allowAllPolicy = api.Policy{
+	Name: "allow-all",
+	Spec: api.PolicySpec{
+		Blob: []byte(`{"name":"allow-all","action":"allow","from":[{"workloadSelector":{}}],"to":[{"workloadSelector":{}}]}`),
+	},
+}
+
+// diagnose returns the name and status of each check of an import diagnosis.
+func diagnose(t *testing.T, server *cphttp.Server, name string) [][2]string {
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, api.DiagnoseImportPath+"/"+name, http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var result api.Diagnosis
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Equal(t, name, result.Import)
+
+	checks := make([][2]string, len(result.Checks))
+	for i, check := range result.Checks {
+		checks[i] = [2]string{check.Name, string(check.Status)}
+	}
+	return checks
+}
+
+func TestDiagnoseImport(t *testing.T) {
+	server, cp, _ := newServer(t, "peer1")
+	require.Nil(t, cp.StartLeading())
+	t.Cleanup(cp.StopLeading)
+
+	require.Equal(t, [][2]string{{"Import exists", string(api.CheckFailed)}}, diagnose(t, server, "redis"))
+
+	changes(t, restore(t, server, &api.Backup{
+		Version: api.BackupVersion,
+		Peer:    "peer1",
+		Peers: []api.Peer{
+			{Name: "peer2", Spec: api.PeerSpec{Gateways: []api.Endpoint{{Host: "127.0.0.1", Port: 1}}}},
+		},
+		Imports: []api.Import{
+			{Name: "redis", Spec: api.ImportSpec{Service: api.Endpoint{Host: "redis", Port: 6379}}},
+			{Name: "nginx", Spec: api.ImportSpec{Service: api.Endpoint{Host: "nginx", Port: 80}}},
+		},
+		Bindings: []api.Binding{{Spec: api.BindingSpec{Import: "redis", Peer: "peer2"}}},
+	}, false))
+
+	// an unbound import
+	require.Equal(t, [][2]string{
+		{"Import exists", string(api.CheckPassed)},
+		{"Import listener", string(api.CheckPassed)},
+		{"Kubernetes service", string(api.CheckPassed)},
+		{"Bindings", string(api.CheckFailed)},
+		{"Peers", string(api.CheckSkipped)},
+	}, diagnose(t, server, "nginx"))
+
+	// an import bound to a peer which never responded to a heartbeat, with no egress policy
+	require.Equal(t, [][2]string{
+		{"Import exists", string(api.CheckPassed)},
+		{"Import listener", string(api.CheckPassed)},
+		{"Kubernetes service", string(api.CheckPassed)},
+		{"Bindings", string(api.CheckPassed)},
+		{"Peer 'peer2' reachable", string(api.CheckFailed)},
+		{"Egress policy", string(api.CheckFailed)},
+		{"Peer 'peer2' exports 'redis'", string(api.CheckSkipped)},
+		{"Peer 'peer2' ingress policy", string(api.CheckSkipped)},
+		{"Peer 'peer2' export target", string(api.CheckSkipped)},
+	}, diagnose(t, server, "redis"))
+}
+
+func TestCheckIngress(t *testing.T) {
+	server, cp, _ := newServer(t, "peer1")
+
+	exports := &api.Backup{
+		Version: api.BackupVersion,
+		Peer:    "peer1",
+		Exports: []api.Export{
+			{Name: "mysql", Spec: api.ExportSpec{Service: api.Endpoint{Host: "mysql", Port: 3306}}},
+		},
+	}
+	changes(t, restore(t, server, exports, false))
+
+	check := func(name string) *cpapi.CheckResponse {
+		resp, err := cp.CheckIngress(&cpapi.CheckRequest{ServiceName: name, ServiceNamespace: "default"}, "peer2")
+		require.Nil(t, err)
+		return resp
+	}
+
+	// a denied peer learns nothing about the exports
+	require.Equal(t, &cpapi.CheckResponse{}, check("mysql"))
+	require.Equal(t, &cpapi.CheckResponse{}, check("redis"))
+
+	exports.AccessPolicies = []api.Policy{allowAllPolicy}
+	changes(t, restore(t, server, exports, false))
+
+	resp := check("mysql")
+	require.True(t, resp.Allowed)
+	require.Equal(t, "allow-all", resp.MatchedBy)
+	require.True(t, resp.ServiceExists)
+	require.Equal(t, "mysql", resp.Target)
+
+	require.Equal(t, &cpapi.CheckResponse{Allowed: true, MatchedBy: "allow-all"}, check("redis"))
+}
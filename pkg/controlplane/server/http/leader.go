@@ -0,0 +1,77 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/audit"
+)
+
+// forwardToLeader is a middleware which forwards management requests received by a follower
+// controlplane replica to the leader. Authorization, check and heartbeat requests are served by all replicas.
+func (s *Server) forwardToLeader(next http.Handler) http.Handler {
+	proxy := &httputil.ReverseProxy{
+		Director: func(r *http.Request) {
+			r.URL.Scheme = "https"
+			r.URL.Host = s.cp.LeaderAddress()
+			r.Header.Set(api.ForwardedCallerHeader, audit.CallerFromContext(r.Context()))
+		},
+		Transport: &http.Transport{TLSClientConfig: s.cp.LeaderTLSConfig()},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			s.logger.Errorf("Cannot forward request to leader: %v.", err)
+			http.Error(w, "cannot forward request to the controlplane leader", http.StatusBadGateway)
+		},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.cp.IsLeader() || !isManagementPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if s.cp.LeaderAddress() == "" {
+			http.Error(w, "controlplane leader is not available", http.StatusServiceUnavailable)
+			return
+		}
+
+		s.logger.Debugf("Forwarding %s %s to leader.", r.Method, r.URL.Path)
+		proxy.ServeHTTP(w, r)
+	})
+}
+
+// isLocalControlplane returns true if a request originates from a replica of the local controlplane.
+func (s *Server) isLocalControlplane(r *http.Request) bool {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return false
+	}
+
+	grpcServerName := api.GRPCServerName(s.cp.PeerName())
+	for _, dnsName := range r.TLS.PeerCertificates[0].DNSNames {
+		if dnsName == grpcServerName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isManagementPath returns true for paths of the management API.
+func isManagementPath(path string) bool {
+	return !strings.HasPrefix(path, api.RemotePeerAuthorizationPath) && path != api.RemotePeerCheckPath &&
+		path != api.RemotePeerExportsPath && path != api.HeartbeatPath
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	cpapi "github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+)
+
+func (s *Server) addRemoteExportsHandlers() {
+	r := s.Router()
+
+	r.Get(api.RemoteExportsPath+"/{peer}", s.RemoteExports)
+	r.Post(cpapi.RemotePeerExportsPath, s.PeerExports)
+}
+
+// RemoteExports lists the exported services which a remote peer exposes to the local peer.
+func (s *Server) RemoteExports(w http.ResponseWriter, r *http.Request) {
+	exports, err := s.cp.GetRemoteExports(chi.URLParam(r, "peer"))
+	switch {
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	case exports == nil:
+		http.Error(w, "peer not found", http.StatusNotFound)
+		return
+	}
+
+	s.writeJSON(w, exports)
+}
+
+// PeerExports lists the exported services which a remote peer controlplane is allowed to access.
+func (s *Server) PeerExports(w http.ResponseWriter, r *http.Request) {
+	var req cpapi.ExportsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	peerName, ok := remotePeerName(r)
+	if !ok {
+		http.Error(w, "certificate does not contain a valid DNS name for the peer gateway", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.cp.GetPeerExports(&req, peerName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.writeJSON(w, resp)
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	cpapi "github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+)
+
+func TestPeerExports(t *testing.T) {
+	server, cp, _ := newServer(t, "peer1")
+
+	policy := []byte(`{"name":"peer2-mysql","action":"allow",` +
+		`"from":[{"workloadSelector":{"matchLabels":{"clusterlink/metadata.gatewayName":"peer2"}}}],` +
+		`"to":[{"workloadSelector":{"matchLabels":{"clusterlink/metadata.serviceName":"mysql"}}}]}`)
+
+	changes(t, restore(t, server, &api.Backup{
+		Version: api.BackupVersion,
+		Peer:    "peer1",
+		Exports: []api.Export{
+			{
+				Name:   "mysql",
+				Labels: map[string]string{"tier": "db"},
+				Spec:   api.ExportSpec{Service: api.Endpoint{Host: "mysql", Port: 3306}},
+			},
+			{Name: "redis", Spec: api.ExportSpec{Service: api.Endpoint{Host: "redis", Port: 6379}}},
+		},
+		AccessPolicies: []api.Policy{{Name: "peer2-mysql", Spec: api.PolicySpec{Blob: policy}}},
+	}, false))
+
+	exports := func(peer string) []cpapi.RemoteExport {
+		resp, err := cp.GetPeerExports(&cpapi.ExportsRequest{ServiceNamespace: "default"}, peer)
+		require.Nil(t, err)
+		return resp.Exports
+	}
+
+	// only exports allowed by the ingress policies are listed
+	require.Equal(t, []cpapi.RemoteExport{{Name: "mysql", Labels: map[string]string{"tier": "db"}}}, exports("peer2"))
+	require.Equal(t, []cpapi.RemoteExport{}, exports("peer3"))
+}
+
+func TestRemoteExports(t *testing.T) {
+	server, cp, _ := newServer(t, "peer1")
+	require.Nil(t, cp.StartLeading())
+	t.Cleanup(cp.StopLeading)
+
+	changes(t, restore(t, server, &api.Backup{
+		Version: api.BackupVersion,
+		Peer:    "peer1",
+		Peers: []api.Peer{
+			{Name: "peer2", Spec: api.PeerSpec{Gateways: []api.Endpoint{{Host: "127.0.0.1", Port: 1}}}},
+		},
+	}, false))
+
+	list := func(peer string) int {
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, api.RemoteExportsPath+"/"+peer, http.NoBody))
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusNotFound, list("peer3"))
+	// the peer gateway does not respond
+	require.Equal(t, http.StatusBadGateway, list("peer2"))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/clusterlink-net/clusterlink/pkg/controlplane"
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/audit"
 	"github.com/clusterlink-net/clusterlink/pkg/util/rest"
 )
 
@@ -26,20 +27,29 @@ import (
 // Furthermore, this server implements the various authorization APIs.
 type Server struct {
 	rest.Server
-	cp *controlplane.Instance
+	cp      *controlplane.Instance
+	auditor *audit.Auditor
 
 	logger *logrus.Entry
 }
 
 // NewServer returns a new controlplane HTTP server.
-func NewServer(cp *controlplane.Instance, tlsConfig *tls.Config) *Server {
+// Management actions are recorded by the given auditor.
+func NewServer(cp *controlplane.Instance, auditor *audit.Auditor, tlsConfig *tls.Config) *Server {
 	s := &Server{
-		Server: rest.NewServer("controlplane-http", tlsConfig),
-		cp:     cp,
-		logger: logrus.WithField("component", "controlplane.server.http"),
+		Server:  rest.NewServer("controlplane-http", tlsConfig),
+		cp:      cp,
+		auditor: auditor,
+		logger:  logrus.WithField("component", "controlplane.server.http"),
 	}
 
+	s.Router().Use(s.identifyCaller, s.forwardToLeader)
+
 	s.addAPIHandlers()
+	s.addBackupHandlers()
+	s.addDiagnoseHandlers()
+	s.addStatusHandler()
+	s.addRemoteExportsHandlers()
 	s.addAuthzHandlers()
 	s.addHeartbeatHandler()
 
@@ -0,0 +1,31 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+)
+
+func (s *Server) addStatusHandler() {
+	r := s.Router()
+
+	r.Get(api.StatusPath, s.Status)
+}
+
+// Status returns the status of the controlplane.
+func (s *Server) Status(w http.ResponseWriter, _ *http.Request) {
+	s.writeJSON(w, s.cp.GetStatus())
+}
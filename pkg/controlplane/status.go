@@ -0,0 +1,47 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"time"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+)
+
+// GetStatus returns the status of the controlplane.
+func (cp *Instance) GetStatus() *api.Status {
+	return &api.Status{Peer: cp.PeerName()}
+}
+
+// GetPeerStatus returns the status of a remote peer, as observed by the heartbeats.
+// An empty status is returned for peers which are not monitored.
+func (cp *Instance) GetPeerStatus(name string) api.PeerStatus {
+	cp.peerLock.RLock()
+	defer cp.peerLock.RUnlock()
+
+	client, ok := cp.peerClient[name]
+	if !ok {
+		return api.PeerStatus{}
+	}
+
+	status := api.PeerStatus{State: api.PeerStateUnreachable}
+	if client.IsActive() {
+		status.State = api.PeerStateReachable
+	}
+	if lastSeen := client.LastSeen(); !lastSeen.IsZero() {
+		status.LastSeen = lastSeen.UTC().Format(time.RFC3339)
+	}
+
+	return status
+}
@@ -84,12 +84,13 @@ func (s *AccessPolicies) Get(name string) *AccessPolicy {
 	return s.cache[name]
 }
 
-// Delete an access policy.
-func (s *AccessPolicies) Delete(name string) (*AccessPolicy, error) {
+// Delete an access policy, if its resource version matches the given version.
+// A zero version deletes an access policy regardless of its resource version.
+func (s *AccessPolicies) Delete(name string, version uint64) (*AccessPolicy, error) {
 	s.logger.Infof("Deleting: '%s'.", name)
 
 	// delete from store
-	if err := s.store.Delete(name); err != nil {
+	if err := s.store.DeleteIfMatch(name, version); err != nil {
 		return nil, err
 	}
 
@@ -124,10 +125,24 @@ func (s *AccessPolicies) Len() int {
 	return len(s.cache)
 }
 
+// ObjectStore returns the persistent store of the access policies, e.g. for watching their changes.
+func (s *AccessPolicies) ObjectStore() store.ObjectStore {
+	return s.store
+}
+
+// Refresh reloads the cache with the current items of the backing store.
+func (s *AccessPolicies) Refresh() error {
+	return s.load()
+}
+
 // init loads the cache with items from the backing store.
 func (s *AccessPolicies) init() error {
 	s.logger.Info("Initializing.")
+	return s.load()
+}
 
+// load replaces the cache with the items of the backing store.
+func (s *AccessPolicies) load() error {
 	// get all policies from backing store
 	policies, err := s.store.GetAll()
 	if err != nil {
@@ -137,6 +152,9 @@ func (s *AccessPolicies) init() error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	// discard previously cached items
+	s.cache = make(map[string]*AccessPolicy)
+
 	// store all policies to the cache
 	for _, object := range policies {
 		if policy, ok := object.(*AccessPolicy); ok {
@@ -151,9 +169,14 @@ func (s *AccessPolicies) init() error {
 func NewAccessPolicies(manager store.Manager) (*AccessPolicies, error) {
 	logger := logrus.WithField("component", "controlplane.store.accesspolicies")
 
+	objectStore, err := getObjectStore(manager, accessPolicyStoreName, AccessPolicy{})
+	if err != nil {
+		return nil, err
+	}
+
 	policies := &AccessPolicies{
 		cache:  make(map[string]*AccessPolicy),
-		store:  manager.GetObjectStore(accessPolicyStoreName, AccessPolicy{}),
+		store:  objectStore,
 		logger: logger,
 	}
 
@@ -50,17 +50,24 @@ func (s *Bindings) Create(binding *Binding) error {
 		return err
 	}
 
-	s.lock.Lock()
-	defer s.lock.Unlock()
+	s.setCache(binding)
+	return nil
+}
 
-	// store in cache
-	valMap, ok := s.cache[binding.Import]
-	if !ok {
-		valMap = make(map[string]*Binding)
-		s.cache[binding.Import] = valMap
+// CreateTx creates a binding within a transaction.
+func (s *Bindings) CreateTx(tx *Transaction, binding *Binding) error {
+	s.logger.Infof("Creating in transaction: '%s'->'%s'.", binding.Import, binding.Peer)
+
+	if binding.Version > bindingStructVersion {
+		return fmt.Errorf("incompatible binding version %d, expected: %d",
+			binding.Version, bindingStructVersion)
 	}
 
-	valMap[binding.Peer] = binding
+	if err := tx.objectStore(bindingStoreName, Binding{}).Create(bindingName(binding), binding); err != nil {
+		return err
+	}
+
+	tx.afterCommit(func() { s.setCache(binding) })
 	return nil
 }
 
@@ -77,10 +84,15 @@ func (s *Bindings) Update(binding *Binding, mutator func(*Binding) *Binding) err
 		return err
 	}
 
+	s.setCache(binding)
+	return nil
+}
+
+// setCache stores a binding in the cache.
+func (s *Bindings) setCache(binding *Binding) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	// store in cache
 	valMap, ok := s.cache[binding.Import]
 	if !ok {
 		valMap = make(map[string]*Binding)
@@ -88,7 +100,6 @@ func (s *Bindings) Update(binding *Binding, mutator func(*Binding) *Binding) err
 	}
 
 	valMap[binding.Peer] = binding
-	return nil
 }
 
 // Get all bindings for an import.
@@ -171,10 +182,24 @@ func (s *Bindings) Len() int {
 	return length
 }
 
+// ObjectStore returns the persistent store of the bindings, e.g. for watching their changes.
+func (s *Bindings) ObjectStore() store.ObjectStore {
+	return s.store
+}
+
+// Refresh reloads the cache with the current items of the backing store.
+func (s *Bindings) Refresh() error {
+	return s.load()
+}
+
 // init loads the cache with items from the backing store.
 func (s *Bindings) init() error {
 	s.logger.Info("Initializing.")
+	return s.load()
+}
 
+// load replaces the cache with the items of the backing store.
+func (s *Bindings) load() error {
 	// get all bindings from backing store
 	bindings, err := s.store.GetAll()
 	if err != nil {
@@ -184,6 +209,9 @@ func (s *Bindings) init() error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	// discard previously cached items
+	s.cache = make(map[string]map[string]*Binding)
+
 	// store all bindings to the cache
 	for _, object := range bindings {
 		if binding, ok := object.(*Binding); ok {
@@ -204,9 +232,14 @@ func (s *Bindings) init() error {
 func NewBindings(manager store.Manager) (*Bindings, error) {
 	logger := logrus.WithField("component", "controlplane.store.bindings")
 
+	objectStore, err := getObjectStore(manager, bindingStoreName, Binding{})
+	if err != nil {
+		return nil, err
+	}
+
 	bindings := &Bindings{
 		cache:  make(map[string]map[string]*Binding),
-		store:  manager.GetObjectStore(bindingStoreName, Binding{}),
+		store:  objectStore,
 		logger: logger,
 	}
 
@@ -45,11 +45,24 @@ func (s *Exports) Create(export *Export) error {
 		return err
 	}
 
-	s.lock.Lock()
-	defer s.lock.Unlock()
+	s.setCache(export.Name, export)
+	return nil
+}
+
+// CreateTx creates an export within a transaction.
+func (s *Exports) CreateTx(tx *Transaction, export *Export) error {
+	s.logger.Infof("Creating in transaction: '%s'.", export.Name)
+
+	if export.Version > exportStructVersion {
+		return fmt.Errorf("incompatible export version %d, expected: %d",
+			export.Version, exportStructVersion)
+	}
+
+	if err := tx.objectStore(exportStoreName, Export{}).Create(export.Name, export); err != nil {
+		return err
+	}
 
-	// store in cache
-	s.cache[export.Name] = export
+	tx.afterCommit(func() { s.setCache(export.Name, export) })
 	return nil
 }
 
@@ -67,11 +80,24 @@ func (s *Exports) Update(name string, mutator func(*Export) *Export) error {
 		return err
 	}
 
-	s.lock.Lock()
-	defer s.lock.Unlock()
+	s.setCache(name, export)
+	return nil
+}
 
-	// store in cache
-	s.cache[name] = export
+// UpdateTx updates an export within a transaction.
+func (s *Exports) UpdateTx(tx *Transaction, name string, mutator func(*Export) *Export) error {
+	s.logger.Infof("Updating in transaction: '%s'.", name)
+
+	var export *Export
+	err := tx.objectStore(exportStoreName, Export{}).Update(name, func(a any) any {
+		export = mutator(a.(*Export))
+		return export
+	})
+	if err != nil {
+		return err
+	}
+
+	tx.afterCommit(func() { s.setCache(name, export) })
 	return nil
 }
 
@@ -85,12 +111,13 @@ func (s *Exports) Get(name string) *Export {
 	return s.cache[name]
 }
 
-// Delete an export.
-func (s *Exports) Delete(name string) (*Export, error) {
+// Delete an export, if its resource version matches the given version.
+// A zero version deletes an export regardless of its resource version.
+func (s *Exports) Delete(name string, version uint64) (*Export, error) {
 	s.logger.Infof("Deleting: '%s'.", name)
 
 	// delete from store
-	if err := s.store.Delete(name); err != nil {
+	if err := s.store.DeleteIfMatch(name, version); err != nil {
 		return nil, err
 	}
 
@@ -103,6 +130,32 @@ func (s *Exports) Delete(name string) (*Export, error) {
 	return val, nil
 }
 
+// DeleteTx deletes an export within a transaction, if its resource version matches the given version.
+// A zero version deletes an export regardless of its resource version.
+func (s *Exports) DeleteTx(tx *Transaction, name string, version uint64) error {
+	s.logger.Infof("Deleting in transaction: '%s'.", name)
+
+	if err := tx.objectStore(exportStoreName, Export{}).DeleteIfMatch(name, version); err != nil {
+		return err
+	}
+
+	tx.afterCommit(func() { s.setCache(name, nil) })
+	return nil
+}
+
+// setCache stores an export in the cache, or removes it if export is nil.
+func (s *Exports) setCache(name string, export *Export) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if export == nil {
+		delete(s.cache, name)
+		return
+	}
+
+	s.cache[name] = export
+}
+
 // GetAll returns all exports in the cache.
 func (s *Exports) GetAll() []*Export {
 	s.logger.Debug("Getting all exports.")
@@ -125,10 +178,24 @@ func (s *Exports) Len() int {
 	return len(s.cache)
 }
 
+// ObjectStore returns the persistent store of the exports, e.g. for watching their changes.
+func (s *Exports) ObjectStore() store.ObjectStore {
+	return s.store
+}
+
+// Refresh reloads the cache with the current items of the backing store.
+func (s *Exports) Refresh() error {
+	return s.load()
+}
+
 // init loads the cache with items from the backing store.
 func (s *Exports) init() error {
 	s.logger.Info("Initializing.")
+	return s.load()
+}
 
+// load replaces the cache with the items of the backing store.
+func (s *Exports) load() error {
 	// get all exports from backing store
 	exports, err := s.store.GetAll()
 	if err != nil {
@@ -138,6 +205,9 @@ func (s *Exports) init() error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	// discard previously cached items
+	s.cache = make(map[string]*Export)
+
 	// store all exports to the cache
 	for _, object := range exports {
 		if export, ok := object.(*Export); ok {
@@ -152,9 +222,14 @@ func (s *Exports) init() error {
 func NewExports(manager store.Manager) (*Exports, error) {
 	logger := logrus.WithField("component", "controlplane.store.exports")
 
+	objectStore, err := getObjectStore(manager, exportStoreName, Export{})
+	if err != nil {
+		return nil, err
+	}
+
 	exports := &Exports{
 		cache:  make(map[string]*Export),
-		store:  manager.GetObjectStore(exportStoreName, Export{}),
+		store:  objectStore,
 		logger: logger,
 	}
 
@@ -45,11 +45,24 @@ func (s *Imports) Create(imp *Import) error {
 		return err
 	}
 
-	s.lock.Lock()
-	defer s.lock.Unlock()
+	s.setCache(imp.Name, imp)
+	return nil
+}
+
+// CreateTx creates an import within a transaction.
+func (s *Imports) CreateTx(tx *Transaction, imp *Import) error {
+	s.logger.Infof("Creating in transaction: '%s'.", imp.Name)
+
+	if imp.Version > importStructVersion {
+		return fmt.Errorf("incompatible import version %d, expected: %d",
+			imp.Version, importStructVersion)
+	}
+
+	if err := tx.objectStore(importStoreName, Import{}).Create(imp.Name, imp); err != nil {
+		return err
+	}
 
-	// store in cache
-	s.cache[imp.Name] = imp
+	tx.afterCommit(func() { s.setCache(imp.Name, imp) })
 	return nil
 }
 
@@ -67,11 +80,24 @@ func (s *Imports) Update(name string, mutator func(*Import) *Import) error {
 		return err
 	}
 
-	s.lock.Lock()
-	defer s.lock.Unlock()
+	s.setCache(name, imp)
+	return nil
+}
 
-	// store in cache
-	s.cache[name] = imp
+// UpdateTx updates an import within a transaction.
+func (s *Imports) UpdateTx(tx *Transaction, name string, mutator func(*Import) *Import) error {
+	s.logger.Infof("Updating in transaction: '%s'.", name)
+
+	var imp *Import
+	err := tx.objectStore(importStoreName, Import{}).Update(name, func(a any) any {
+		imp = mutator(a.(*Import))
+		return imp
+	})
+	if err != nil {
+		return err
+	}
+
+	tx.afterCommit(func() { s.setCache(name, imp) })
 	return nil
 }
 
@@ -84,12 +110,13 @@ func (s *Imports) Get(name string) *Import {
 	return s.cache[name]
 }
 
-// Delete an import.
-func (s *Imports) Delete(name string) (*Import, error) {
+// Delete an import, if its resource version matches the given version.
+// A zero version deletes an import regardless of its resource version.
+func (s *Imports) Delete(name string, version uint64) (*Import, error) {
 	s.logger.Infof("Deleting: '%s'.", name)
 
 	// delete from store
-	if err := s.store.Delete(name); err != nil {
+	if err := s.store.DeleteIfMatch(name, version); err != nil {
 		return nil, err
 	}
 
@@ -102,6 +129,32 @@ func (s *Imports) Delete(name string) (*Import, error) {
 	return val, nil
 }
 
+// DeleteTx deletes an import within a transaction, if its resource version matches the given version.
+// A zero version deletes an import regardless of its resource version.
+func (s *Imports) DeleteTx(tx *Transaction, name string, version uint64) error {
+	s.logger.Infof("Deleting in transaction: '%s'.", name)
+
+	if err := tx.objectStore(importStoreName, Import{}).DeleteIfMatch(name, version); err != nil {
+		return err
+	}
+
+	tx.afterCommit(func() { s.setCache(name, nil) })
+	return nil
+}
+
+// setCache stores an import in the cache, or removes it if imp is nil.
+func (s *Imports) setCache(name string, imp *Import) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if imp == nil {
+		delete(s.cache, name)
+		return
+	}
+
+	s.cache[name] = imp
+}
+
 // GetAll returns all imports in the cache.
 func (s *Imports) GetAll() []*Import {
 	s.logger.Debug("Getting all imports.")
@@ -124,10 +177,24 @@ func (s *Imports) Len() int {
 	return len(s.cache)
 }
 
+// ObjectStore returns the persistent store of the imports, e.g. for watching their changes.
+func (s *Imports) ObjectStore() store.ObjectStore {
+	return s.store
+}
+
+// Refresh reloads the cache with the current items of the backing store.
+func (s *Imports) Refresh() error {
+	return s.load()
+}
+
 // init loads the cache with items from the backing store.
 func (s *Imports) init() error {
 	s.logger.Info("Initializing.")
+	return s.load()
+}
 
+// load replaces the cache with the items of the backing store.
+func (s *Imports) load() error {
 	// get all imports from backing store
 	imports, err := s.store.GetAll()
 	if err != nil {
@@ -137,6 +204,9 @@ func (s *Imports) init() error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	// discard previously cached items
+	s.cache = make(map[string]*Import)
+
 	// store all imports to the cache
 	for _, object := range imports {
 		if imp, ok := object.(*Import); ok {
@@ -151,9 +221,14 @@ func (s *Imports) init() error {
 func NewImports(manager store.Manager) (*Imports, error) {
 	logger := logrus.WithField("component", "controlplane.store.imports")
 
+	objectStore, err := getObjectStore(manager, importStoreName, Import{})
+	if err != nil {
+		return nil, err
+	}
+
 	imports := &Imports{
 		cache:  make(map[string]*Import),
-		store:  manager.GetObjectStore(importStoreName, Import{}),
+		store:  objectStore,
 		logger: logger,
 	}
 
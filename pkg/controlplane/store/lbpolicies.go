@@ -84,12 +84,13 @@ func (s *LBPolicies) Get(name string) *LBPolicy {
 	return s.cache[name]
 }
 
-// Delete a load-balancing policy.
-func (s *LBPolicies) Delete(name string) (*LBPolicy, error) {
+// Delete a load-balancing policy, if its resource version matches the given version.
+// A zero version deletes a load-balancing policy regardless of its resource version.
+func (s *LBPolicies) Delete(name string, version uint64) (*LBPolicy, error) {
 	s.logger.Infof("Deleting: '%s'.", name)
 
 	// delete from store
-	if err := s.store.Delete(name); err != nil {
+	if err := s.store.DeleteIfMatch(name, version); err != nil {
 		return nil, err
 	}
 
@@ -124,10 +125,24 @@ func (s *LBPolicies) Len() int {
 	return len(s.cache)
 }
 
+// ObjectStore returns the persistent store of the load-balancing policies, e.g. for watching their changes.
+func (s *LBPolicies) ObjectStore() store.ObjectStore {
+	return s.store
+}
+
+// Refresh reloads the cache with the current items of the backing store.
+func (s *LBPolicies) Refresh() error {
+	return s.load()
+}
+
 // init loads the cache with items from the backing store.
 func (s *LBPolicies) init() error {
 	s.logger.Info("Initializing.")
+	return s.load()
+}
 
+// load replaces the cache with the items of the backing store.
+func (s *LBPolicies) load() error {
 	// get all policies from backing store
 	policies, err := s.store.GetAll()
 	if err != nil {
@@ -137,6 +152,9 @@ func (s *LBPolicies) init() error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	// discard previously cached items
+	s.cache = make(map[string]*LBPolicy)
+
 	// store all policies to the cache
 	for _, object := range policies {
 		if policy, ok := object.(*LBPolicy); ok {
@@ -151,9 +169,14 @@ func (s *LBPolicies) init() error {
 func NewLBPolicies(manager store.Manager) (*LBPolicies, error) {
 	logger := logrus.WithField("component", "controlplane.store.lbpolicies")
 
+	objectStore, err := getObjectStore(manager, lbPolicyStoreName, LBPolicy{})
+	if err != nil {
+		return nil, err
+	}
+
 	policies := &LBPolicies{
 		cache:  make(map[string]*LBPolicy),
-		store:  manager.GetObjectStore(lbPolicyStoreName, LBPolicy{}),
+		store:  objectStore,
 		logger: logger,
 	}
 
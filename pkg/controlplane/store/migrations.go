@@ -0,0 +1,73 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"github.com/clusterlink-net/clusterlink/pkg/store"
+)
+
+// migrations holds the migration chain of each object type, keyed by the store name.
+// When bumping the struct version of an object type, append a migration upgrading objects
+// from the previous version.
+var migrations = map[string]*store.MigrationChain{
+	peerStoreName: {
+		Type:       "peer",
+		Version:    peerStructVersion,
+		Migrations: []store.Migration{addResourceVersion},
+	},
+	exportStoreName: {
+		Type:       "export",
+		Version:    exportStructVersion,
+		Migrations: []store.Migration{addResourceVersion},
+	},
+	importStoreName: {
+		Type:       "import",
+		Version:    importStructVersion,
+		Migrations: []store.Migration{addResourceVersion},
+	},
+	bindingStoreName: {
+		Type:       "binding",
+		Version:    bindingStructVersion,
+		Migrations: []store.Migration{addResourceVersion},
+	},
+	accessPolicyStoreName: {
+		Type:       "access policy",
+		Version:    accessPolicyStructVersion,
+		Migrations: []store.Migration{addResourceVersion},
+	},
+	lbPolicyStoreName: {
+		Type:       "load-balancing policy",
+		Version:    lbPolicyStructVersion,
+		Migrations: []store.Migration{addResourceVersion},
+	},
+	jwkStoreName:        {Type: "jwk", Version: jwkStructVersion},
+	peerStatusStoreName: {Type: "peer status", Version: peerStatusStructVersion},
+	intentStoreName:     {Type: "intent", Version: intentStructVersion},
+}
+
+// getObjectStore returns the object store of the given type, after upgrading its objects to their current version.
+func getObjectStore(manager store.Manager, name string, sampleObject any) (store.ObjectStore, error) {
+	objectStore := manager.GetObjectStore(name, sampleObject)
+	if err := migrations[name].Migrate(objectStore); err != nil {
+		return nil, err
+	}
+
+	return objectStore, nil
+}
+
+// addResourceVersion upgrades an object persisted before objects were versioned.
+func addResourceVersion(object map[string]any) error {
+	object["ResourceVersion"] = 1
+	return nil
+}
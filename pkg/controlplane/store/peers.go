@@ -84,12 +84,13 @@ func (s *Peers) Get(name string) *Peer {
 	return s.cache[name]
 }
 
-// Delete a peer.
-func (s *Peers) Delete(name string) (*Peer, error) {
+// Delete a peer, if its resource version matches the given version.
+// A zero version deletes a peer regardless of its resource version.
+func (s *Peers) Delete(name string, version uint64) (*Peer, error) {
 	s.logger.Infof("Deleting: '%s'.", name)
 
 	// delete from store
-	if err := s.store.Delete(name); err != nil {
+	if err := s.store.DeleteIfMatch(name, version); err != nil {
 		return nil, err
 	}
 
@@ -124,10 +125,24 @@ func (s *Peers) Len() int {
 	return len(s.cache)
 }
 
+// ObjectStore returns the persistent store of the peers, e.g. for watching their changes.
+func (s *Peers) ObjectStore() store.ObjectStore {
+	return s.store
+}
+
+// Refresh reloads the cache with the current items of the backing store.
+func (s *Peers) Refresh() error {
+	return s.load()
+}
+
 // init loads the cache with items from the backing store.
 func (s *Peers) init() error {
 	s.logger.Info("Initializing.")
+	return s.load()
+}
 
+// load replaces the cache with the items of the backing store.
+func (s *Peers) load() error {
 	// get all peers from backing store
 	peers, err := s.store.GetAll()
 	if err != nil {
@@ -137,6 +152,9 @@ func (s *Peers) init() error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	// discard previously cached items
+	s.cache = make(map[string]*Peer)
+
 	// store all peers to the cache
 	for _, object := range peers {
 		if peer, ok := object.(*Peer); ok {
@@ -151,9 +169,14 @@ func (s *Peers) init() error {
 func NewPeers(manager store.Manager) (*Peers, error) {
 	logger := logrus.WithField("component", "controlplane.store.peers")
 
+	objectStore, err := getObjectStore(manager, peerStoreName, Peer{})
+	if err != nil {
+		return nil, err
+	}
+
 	peers := &Peers{
 		cache:  make(map[string]*Peer),
-		store:  manager.GetObjectStore(peerStoreName, Peer{}),
+		store:  objectStore,
 		logger: logger,
 	}
 
@@ -0,0 +1,213 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store"
+)
+
+const (
+	// jwkName is the name of the JWK used for signing access tokens.
+	jwkName = "access-token"
+)
+
+// State is a persistent store of internal controlplane state, shared by all controlplane replicas.
+type State struct {
+	jwks       store.ObjectStore
+	peerStatus store.ObjectStore
+	intents    store.ObjectStore
+
+	logger *logrus.Entry
+}
+
+// GetJWK returns the key used for signing access tokens, or nil if it was not yet created.
+func (s *State) GetJWK() (*JWK, error) {
+	objects, err := s.jwks.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, object := range objects {
+		if key, ok := object.(*JWK); ok && key.Name == jwkName {
+			return key, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// CreateJWK stores the key used for signing access tokens.
+// Returns ObjectExistsError if a key was already stored.
+func (s *State) CreateJWK(key []byte) error {
+	s.logger.Info("Creating JWK.")
+
+	return s.jwks.Create(jwkName, &JWK{
+		Name:    jwkName,
+		Key:     key,
+		Version: jwkStructVersion,
+	})
+}
+
+// GetPeerStatus returns the last known status of all peers.
+func (s *State) GetPeerStatus() (map[string]bool, error) {
+	objects, err := s.peerStatus.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	status := make(map[string]bool, len(objects))
+	for _, object := range objects {
+		if peerStatus, ok := object.(*PeerStatus); ok {
+			if peerStatus.Version > peerStatusStructVersion {
+				return nil, fmt.Errorf("incompatible peer status version %d, expected: %d",
+					peerStatus.Version, peerStatusStructVersion)
+			}
+			status[peerStatus.Name] = peerStatus.Active
+		}
+	}
+
+	return status, nil
+}
+
+// SetPeerStatus stores the status of a peer.
+func (s *State) SetPeerStatus(name string, active bool) error {
+	s.logger.Debugf("Setting status of peer '%s': %v.", name, active)
+
+	peerStatus := &PeerStatus{
+		Name:    name,
+		Active:  active,
+		Version: peerStatusStructVersion,
+	}
+
+	err := s.peerStatus.Update(name, func(any) any {
+		return peerStatus
+	})
+	var notFoundErr *store.ObjectNotFoundError
+	if errors.As(err, &notFoundErr) {
+		err = s.peerStatus.Create(name, peerStatus)
+	}
+
+	return err
+}
+
+// PeerStatusStore returns the persistent store of the peers status, e.g. for watching its changes.
+func (s *State) PeerStatusStore() store.ObjectStore {
+	return s.peerStatus
+}
+
+// DeletePeerStatus removes the status of a peer.
+func (s *State) DeletePeerStatus(name string) error {
+	return s.peerStatus.Delete(name)
+}
+
+// GetIntents returns all pending intents.
+func (s *State) GetIntents() ([]*Intent, error) {
+	objects, err := s.intents.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	intents := make([]*Intent, 0, len(objects))
+	for _, object := range objects {
+		if intent, ok := object.(*Intent); ok {
+			if intent.Version > intentStructVersion {
+				return nil, fmt.Errorf("incompatible intent version %d, expected: %d",
+					intent.Version, intentStructVersion)
+			}
+			intents = append(intents, intent)
+		}
+	}
+
+	return intents, nil
+}
+
+// AddIntentTx stores an intent for syncing the k8s services of an object, within a transaction.
+// If a pending intent exists for the same object, the services are added to it.
+func (s *State) AddIntentTx(tx *Transaction, kind, object string, services ...string) (*Intent, error) {
+	intent := &Intent{
+		Name:     kind + "." + object,
+		Kind:     kind,
+		Object:   object,
+		Services: services,
+		Version:  intentStructVersion,
+	}
+
+	s.logger.Debugf("Adding intent '%s': %v.", intent.Name, services)
+
+	intents := tx.objectStore(intentStoreName, Intent{})
+	err := intents.Update(intent.Name, func(a any) any {
+		pending := a.(*Intent)
+		for _, service := range pending.Services {
+			if !contains(intent.Services, service) {
+				intent.Services = append(intent.Services, service)
+			}
+		}
+		return intent
+	})
+	var notFoundErr *store.ObjectNotFoundError
+	if errors.As(err, &notFoundErr) {
+		err = intents.Create(intent.Name, intent)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return intent, nil
+}
+
+// DeleteIntent removes a completed intent.
+func (s *State) DeleteIntent(intent *Intent) error {
+	s.logger.Debugf("Deleting intent '%s'.", intent.Name)
+	return s.intents.Delete(intent.Name)
+}
+
+// contains returns true if values contains the given value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// NewState returns a new store of internal controlplane state.
+func NewState(manager store.Manager) (*State, error) {
+	jwks, err := getObjectStore(manager, jwkStoreName, JWK{})
+	if err != nil {
+		return nil, err
+	}
+
+	peerStatus, err := getObjectStore(manager, peerStatusStoreName, PeerStatus{})
+	if err != nil {
+		return nil, err
+	}
+
+	intents, err := getObjectStore(manager, intentStoreName, Intent{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &State{
+		jwks:       jwks,
+		peerStatus: peerStatus,
+		intents:    intents,
+		logger:     logrus.WithField("component", "controlplane.store.state"),
+	}, nil
+}
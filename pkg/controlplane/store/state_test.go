@@ -0,0 +1,136 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/api"
+	"github.com/clusterlink-net/clusterlink/pkg/controlplane/store"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv/bolt"
+)
+
+func TestState(t *testing.T) {
+	kvStore, err := bolt.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.Nil(t, err)
+	defer kvStore.Close()
+
+	manager := kv.NewManager(kvStore)
+	state, err := store.NewState(manager)
+	require.Nil(t, err)
+
+	// JWK is created once
+	key, err := state.GetJWK()
+	require.Nil(t, err)
+	require.Nil(t, key)
+
+	require.Nil(t, state.CreateJWK([]byte("key1")))
+	require.NotNil(t, state.CreateJWK([]byte("key2")))
+
+	// JWK is shared with other instances (replicas) using the same store
+	otherState, err := store.NewState(manager)
+	require.Nil(t, err)
+	key, err = otherState.GetJWK()
+	require.Nil(t, err)
+	require.Equal(t, []byte("key1"), key.Key)
+
+	// peer status
+	require.Nil(t, state.SetPeerStatus("peer1", true))
+	require.Nil(t, state.SetPeerStatus("peer2", true))
+	require.Nil(t, state.SetPeerStatus("peer2", false))
+
+	status, err := state.GetPeerStatus()
+	require.Nil(t, err)
+	require.Equal(t, map[string]bool{"peer1": true, "peer2": false}, status)
+
+	require.Nil(t, state.DeletePeerStatus("peer1"))
+	status, err = state.GetPeerStatus()
+	require.Nil(t, err)
+	require.Equal(t, map[string]bool{"peer2": false}, status)
+}
+
+func TestTransactionIntents(t *testing.T) {
+	kvStore, err := bolt.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.Nil(t, err)
+	defer kvStore.Close()
+
+	manager := kv.NewManager(kvStore)
+	state, err := store.NewState(manager)
+	require.Nil(t, err)
+	imports, err := store.NewImports(manager)
+	require.Nil(t, err)
+	bindings, err := store.NewBindings(manager)
+	require.Nil(t, err)
+
+	imp := store.NewImport(&api.Import{Name: "svc", Spec: api.ImportSpec{Service: api.Endpoint{Host: "svc", Port: 80}}})
+	binding := store.NewBinding(&api.Binding{Spec: api.BindingSpec{Import: "svc", Peer: "peer1"}})
+
+	// failed transaction is not persisted, nor cached
+	err = store.RunTransaction(manager, func(tx *store.Transaction) error {
+		require.Nil(t, imports.CreateTx(tx, imp))
+		require.Nil(t, bindings.CreateTx(tx, binding))
+		_, err := state.AddIntentTx(tx, "import", imp.Name, "svc")
+		require.Nil(t, err)
+		return errors.New("failed")
+	})
+	require.NotNil(t, err)
+	require.Nil(t, imports.Get("svc"))
+	require.Nil(t, bindings.Get("svc"))
+
+	intents, err := state.GetIntents()
+	require.Nil(t, err)
+	require.Empty(t, intents)
+
+	// committed transaction
+	require.Nil(t, store.RunTransaction(manager, func(tx *store.Transaction) error {
+		require.Nil(t, imports.CreateTx(tx, imp))
+		require.Nil(t, bindings.CreateTx(tx, binding))
+		_, err := state.AddIntentTx(tx, "import", imp.Name, "svc")
+		return err
+	}))
+	require.Equal(t, imp, imports.Get("svc"))
+	require.Len(t, bindings.Get("svc"), 1)
+
+	// pending intents of the same object are merged
+	var intent *store.Intent
+	require.Nil(t, store.RunTransaction(manager, func(tx *store.Transaction) error {
+		require.Nil(t, imports.UpdateTx(tx, "svc", func(old *store.Import) *store.Import {
+			old.Service.Host = "svc2"
+			return old
+		}))
+		intent, err = state.AddIntentTx(tx, "import", imp.Name, "svc", "svc2")
+		return err
+	}))
+	require.Equal(t, "svc2", imports.Get("svc").Service.Host)
+
+	intents, err = state.GetIntents()
+	require.Nil(t, err)
+	require.Len(t, intents, 1)
+	require.Equal(t, []string{"svc", "svc2"}, intents[0].Services)
+
+	// state is persisted
+	otherImports, err := store.NewImports(manager)
+	require.Nil(t, err)
+	require.Equal(t, "svc2", otherImports.Get("svc").Service.Host)
+
+	require.Nil(t, state.DeleteIntent(intent))
+	intents, err = state.GetIntents()
+	require.Nil(t, err)
+	require.Empty(t, intents)
+}
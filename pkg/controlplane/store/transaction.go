@@ -0,0 +1,55 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"github.com/clusterlink-net/clusterlink/pkg/store"
+)
+
+// Transaction changes multiple stores atomically.
+// Caches are only updated once the transaction is committed.
+type Transaction struct {
+	manager  store.Manager
+	onCommit []func()
+}
+
+// objectStore returns a store for a specific object type, operating within the transaction.
+func (tx *Transaction) objectStore(name string, sampleObject any) store.ObjectStore {
+	return tx.manager.GetObjectStore(name, sampleObject)
+}
+
+// afterCommit registers a function to be called once the transaction is committed.
+func (tx *Transaction) afterCommit(f func()) {
+	tx.onCommit = append(tx.onCommit, f)
+}
+
+// RunTransaction calls f with a transaction over the stores persisted by the given manager.
+// If f returns an error, none of its changes are persisted.
+func RunTransaction(manager store.Manager, f func(*Transaction) error) error {
+	tx := &Transaction{}
+	err := manager.Transaction(func(m store.Manager) error {
+		tx.manager = m
+		tx.onCommit = nil
+		return f(tx)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, apply := range tx.onCommit {
+		apply()
+	}
+
+	return nil
+}
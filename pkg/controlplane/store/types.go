@@ -15,6 +15,7 @@ package store
 
 import (
 	"github.com/clusterlink-net/clusterlink/pkg/api"
+	"github.com/clusterlink-net/clusterlink/pkg/store"
 )
 
 const (
@@ -24,20 +25,29 @@ const (
 	bindingStoreName      = "binding"
 	accessPolicyStoreName = "accessPolicy"
 	lbPolicyStoreName     = "lbPolicy"
-
-	bindingStructVersion      = 1
-	exportStructVersion       = 1
-	importStructVersion       = 1
-	peerStructVersion         = 1
-	accessPolicyStructVersion = 1
-	lbPolicyStructVersion     = 1
+	jwkStoreName          = "jwk"
+	peerStatusStoreName   = "peerStatus"
+	intentStoreName       = "intent"
+
+	bindingStructVersion      = 2
+	exportStructVersion       = 2
+	importStructVersion       = 2
+	peerStructVersion         = 2
+	accessPolicyStructVersion = 2
+	lbPolicyStructVersion     = 2
+	jwkStructVersion          = 1
+	peerStatusStructVersion   = 1
+	intentStructVersion       = 1
 )
 
 // Peer represents a remote peer.
 type Peer struct {
 	api.PeerSpec
+	store.Versioned
 	// Name of the peer.
 	Name string
+	// Labels of the peer.
+	Labels map[string]string
 	// Version of the struct when object was created.
 	Version uint32
 }
@@ -47,6 +57,7 @@ func NewPeer(peer *api.Peer) *Peer {
 	return &Peer{
 		PeerSpec: peer.Spec,
 		Name:     peer.Name,
+		Labels:   peer.Labels,
 		Version:  peerStructVersion,
 	}
 }
@@ -54,8 +65,11 @@ func NewPeer(peer *api.Peer) *Peer {
 // Export represents a local service that may be exported.
 type Export struct {
 	api.ExportSpec
+	store.Versioned
 	// Name of the export.
 	Name string
+	// Labels of the export.
+	Labels map[string]string
 	// Version of the struct when object was created.
 	Version uint32
 }
@@ -65,6 +79,7 @@ func NewExport(export *api.Export) *Export {
 	return &Export{
 		ExportSpec: export.Spec,
 		Name:       export.Name,
+		Labels:     export.Labels,
 		Version:    exportStructVersion,
 	}
 }
@@ -72,8 +87,11 @@ func NewExport(export *api.Export) *Export {
 // Import represents an external service that can be bound to (multiple) exported services of remote peers.
 type Import struct {
 	api.ImportSpec
+	store.Versioned
 	// Name of import.
 	Name string
+	// Labels of the import.
+	Labels map[string]string
 	// Version of the struct when object was created.
 	Version uint32
 	// Port is the port where the imported service should listen on.
@@ -85,6 +103,7 @@ func NewImport(imp *api.Import) *Import {
 	return &Import{
 		ImportSpec: imp.Spec,
 		Name:       imp.Name,
+		Labels:     imp.Labels,
 		Version:    importStructVersion,
 	}
 }
@@ -92,6 +111,7 @@ func NewImport(imp *api.Import) *Import {
 // Binding of an imported service to a remote exported service.
 type Binding struct {
 	api.BindingSpec
+	store.Versioned
 	// Version of the struct when object was created.
 	Version uint32
 }
@@ -107,6 +127,7 @@ func NewBinding(binding *api.Binding) *Binding {
 // AccessPolicy to allow/deny specific connections.
 type AccessPolicy struct {
 	api.Policy
+	store.Versioned
 	// Version of the struct when object was created.
 	Version uint32
 }
@@ -122,6 +143,7 @@ func NewAccessPolicy(policy *api.Policy) *AccessPolicy {
 // LBPolicy specifies the load-balancing scheme for specific connections.
 type LBPolicy struct {
 	api.Policy
+	store.Versioned
 	// Version of the struct when object was created.
 	Version uint32
 }
@@ -130,6 +152,42 @@ type LBPolicy struct {
 func NewLBPolicy(policy *api.Policy) *LBPolicy {
 	return &LBPolicy{
 		Policy:  *policy,
-		Version: accessPolicyStructVersion,
+		Version: lbPolicyStructVersion,
 	}
 }
+
+// JWK is the key used for signing access tokens, shared by all controlplane replicas.
+type JWK struct {
+	// Name of the key.
+	Name string
+	// Key is the DER-encoded (PKCS #1) RSA private key.
+	Key []byte
+	// Version of the struct when object was created.
+	Version uint32
+}
+
+// PeerStatus is the status of a remote peer, as observed by the leading controlplane replica.
+type PeerStatus struct {
+	// Name of the peer.
+	Name string
+	// Active is true if the peer responds to heartbeats.
+	Active bool
+	// Version of the struct when object was created.
+	Version uint32
+}
+
+// Intent records k8s services which may be out of sync with a stored object.
+// An intent is stored atomically with a change to the object, and is completed once the services are synced.
+// Intents left in the store (e.g. due to a crash) are completed by the next leading controlplane replica.
+type Intent struct {
+	// Name of the intent, identifying the changed object.
+	Name string
+	// Kind of the changed object (import or export).
+	Kind string
+	// Object is the name of the changed object.
+	Object string
+	// Services are the hosts of the k8s services which may be out of sync with the object.
+	Services []string
+	// Version of the struct when object was created.
+	Version uint32
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"sync"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store"
+)
+
+// ObjectStore returns the persistent store of the API objects of the given kind
+// (peer, export, import, binding, accessPolicy or lbPolicy), or nil if the kind is unknown.
+// Changes to the objects, made by any controlplane replica, can be watched using the store.
+func (cp *Instance) ObjectStore(kind string) store.ObjectStore {
+	switch kind {
+	case "peer":
+		return cp.peers.ObjectStore()
+	case "export":
+		return cp.exports.ObjectStore()
+	case "import":
+		return cp.imports.ObjectStore()
+	case "binding":
+		return cp.bindings.ObjectStore()
+	case "accessPolicy":
+		return cp.acPolicies.ObjectStore()
+	case "lbPolicy":
+		return cp.lbPolicies.ObjectStore()
+	default:
+		return nil
+	}
+}
+
+// watchStores returns a channel which is signaled whenever objects of the given stores change,
+// and a function to stop watching.
+// Signals are coalesced, so watchers should re-read the objects on every signal.
+// A store watch which falls behind is restarted, signaling a change, as changes may have been missed.
+func watchStores(stores ...store.ObjectStore) (<-chan struct{}, func()) {
+	changes := make(chan struct{}, 1)
+	done := make(chan struct{})
+
+	signal := func() {
+		select {
+		case changes <- struct{}{}:
+		default:
+			// a signal is already pending
+		}
+	}
+
+	for _, s := range stores {
+		go func(s store.ObjectStore) {
+			for {
+				events, stopWatch := s.Watch("")
+				for open := true; open; {
+					select {
+					case _, open = <-events:
+						signal()
+					case <-done:
+						stopWatch()
+						return
+					}
+				}
+				stopWatch()
+			}
+		}(s)
+	}
+
+	var once sync.Once
+	return changes, func() {
+		once.Do(func() { close(done) })
+	}
+}
@@ -36,6 +36,9 @@ import (
 	dpapi "github.com/clusterlink-net/clusterlink/pkg/dataplane/api"
 )
 
+// importNamespace is the namespace of imported services, set by their egress listeners on authorization requests.
+const importNamespace = "default"
+
 // xdsManager manages the core routing components of the dataplane.
 // It maps the following controlplane types to xDS types:
 // - Peer -> Cluster (whose name starts with a designated prefix)
@@ -121,7 +124,7 @@ func (m *xdsManager) DeleteExport(name string) error {
 func (m *xdsManager) AddImport(imp *store.Import) error {
 	m.logger.Infof("Adding import '%s'.", imp.Name)
 
-	listenerName := cpapi.ImportListenerName(imp.Name, "default")
+	listenerName := cpapi.ImportListenerName(imp.Name, importNamespace)
 	egressRouterHostname := "egress-router:443"
 
 	tunnelingConfig := &tcpproxy.TcpProxy_TunnelingConfig{
@@ -138,7 +141,7 @@ func (m *xdsManager) AddImport(imp *store.Import) error {
 			{
 				Header: &core.HeaderValue{
 					Key:   cpapi.ImportNamespaceHeader,
-					Value: "default",
+					Value: importNamespace,
 				},
 				KeepEmptyValue: true,
 			},
@@ -183,7 +186,7 @@ func (m *xdsManager) AddImport(imp *store.Import) error {
 func (m *xdsManager) DeleteImport(name string) error {
 	m.logger.Infof("Deleting import '%s'.", name)
 
-	listenerName := cpapi.ImportListenerName(name, "default")
+	listenerName := cpapi.ImportListenerName(name, importNamespace)
 	return m.listeners.DeleteResource(listenerName)
 }
 
@@ -0,0 +1,23 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+const (
+	// MetricsPort is the port the dataplane serves its Prometheus metrics on.
+	MetricsPort = 9090
+	// MetricsPath is the path the dataplane serves its Prometheus metrics on.
+	MetricsPath = "/metrics"
+	// ActiveConnectionsMetric is the name of the gauge of active connections passing through the dataplane.
+	ActiveConnectionsMetric = "clusterlink_dataplane_active_connections"
+)
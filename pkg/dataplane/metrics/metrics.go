@@ -0,0 +1,129 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/clusterlink-net/clusterlink/pkg/dataplane/api"
+)
+
+const (
+	// envoyConnectionsStat is the Envoy gauge of the total number of connections.
+	envoyConnectionsStat = "server.total_connections"
+	// envoyAdminTimeout is the time limit for querying the Envoy admin interface.
+	envoyAdminTimeout = time.Second
+	// readHeaderTimeout is the time limit for reading the headers of a metrics request.
+	readHeaderTimeout = 5 * time.Second
+)
+
+// ConnectionsFunc returns the number of active connections passing through the dataplane.
+type ConnectionsFunc func() (int64, error)
+
+// collector collects the dataplane metrics on each scrape.
+type collector struct {
+	activeConnections     ConnectionsFunc
+	activeConnectionsDesc *prometheus.Desc
+}
+
+// Describe sends the descriptors of the dataplane metrics.
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.activeConnectionsDesc
+}
+
+// Collect sends the current values of the dataplane metrics.
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	connections, err := c.activeConnections()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.activeConnectionsDesc, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.activeConnectionsDesc, prometheus.GaugeValue, float64(connections))
+}
+
+// Handler returns an HTTP handler serving the dataplane metrics in the Prometheus format.
+func Handler(activeConnections ConnectionsFunc) http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(&collector{
+		activeConnections: activeConnections,
+		activeConnectionsDesc: prometheus.NewDesc(
+			api.ActiveConnectionsMetric, "Number of active connections passing through the dataplane.", nil, nil),
+	})
+
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Serve serves the dataplane metrics on the dataplane metrics port.
+func Serve(activeConnections ConnectionsFunc) error {
+	mux := http.NewServeMux()
+	mux.Handle(api.MetricsPath, Handler(activeConnections))
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", api.MetricsPort),
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	return server.ListenAndServe()
+}
+
+// EnvoyConnections returns a ConnectionsFunc reading the number of active connections from the Envoy admin
+// interface at the given address.
+func EnvoyConnections(adminAddress string) ConnectionsFunc {
+	client := &http.Client{Timeout: envoyAdminTimeout}
+	statsURL := url.URL{
+		Scheme:   "http",
+		Host:     adminAddress,
+		Path:     "/stats",
+		RawQuery: url.Values{"filter": {"^" + regexp.QuoteMeta(envoyConnectionsStat) + "$"}}.Encode(),
+	}
+
+	return func() (int64, error) {
+		resp, err := client.Get(statsURL.String())
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return 0, fmt.Errorf("envoy admin returned status %d", resp.StatusCode)
+		}
+
+		// stats are listed one per line, in the form '<name>: <value>'
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			name, value, ok := strings.Cut(scanner.Text(), ":")
+			if !ok || name != envoyConnectionsStat {
+				continue
+			}
+
+			return strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		}
+		if err := scanner.Err(); err != nil {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("envoy stat '%s' not found", envoyConnectionsStat)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/dataplane/api"
+	"github.com/clusterlink-net/clusterlink/pkg/dataplane/metrics"
+)
+
+// scrape returns the status code and body of a metrics request.
+func scrape(connections metrics.ConnectionsFunc) (int, string) {
+	w := httptest.NewRecorder()
+	metrics.Handler(connections).ServeHTTP(w, httptest.NewRequest(http.MethodGet, api.MetricsPath, http.NoBody))
+	return w.Code, w.Body.String()
+}
+
+func TestHandler(t *testing.T) {
+	code, body := scrape(func() (int64, error) { return 3, nil })
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, body, api.ActiveConnectionsMetric+" 3\n")
+
+	code, _ = scrape(func() (int64, error) { return 0, fmt.Errorf("unavailable") })
+	require.Equal(t, http.StatusInternalServerError, code)
+}
+
+func TestEnvoyConnections(t *testing.T) {
+	var stats string
+	admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/stats", r.URL.Path)
+		require.Equal(t, `^server\.total_connections$`, r.URL.Query().Get("filter"))
+		_, err := io.WriteString(w, stats)
+		require.Nil(t, err)
+	}))
+	t.Cleanup(admin.Close)
+
+	connections := metrics.EnvoyConnections(strings.TrimPrefix(admin.URL, "http://"))
+
+	stats = "server.total_connections: 42\n"
+	count, err := connections()
+	require.Nil(t, err)
+	require.Equal(t, int64(42), count)
+
+	// the stat is missing
+	stats = ""
+	_, err = connections()
+	require.NotNil(t, err)
+
+	// the admin interface is down
+	admin.Close()
+	_, err = connections()
+	require.NotNil(t, err)
+}
@@ -15,9 +15,11 @@ package server
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
@@ -26,6 +28,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/clusterlink-net/clusterlink/pkg/controlplane/api"
+	"github.com/clusterlink-net/clusterlink/pkg/util/spiffe"
 	utiltls "github.com/clusterlink-net/clusterlink/pkg/util/tls"
 )
 
@@ -40,9 +43,25 @@ type Dataplane struct {
 	clusters           map[string]*cluster.Cluster
 	listeners          map[string]*listener.Listener
 	listenerEnd        map[string]chan bool
+	spiffeSource       *spiffe.Source
+	spiffeAudience     string
+	activeConnections  atomic.Int64
 	logger             *logrus.Entry
 }
 
+// ActiveConnections returns the number of connections currently forwarded by the dataplane.
+func (d *Dataplane) ActiveConnections() (int64, error) {
+	return d.activeConnections.Load(), nil
+}
+
+// forward forwards a connection between a workload and a peer, until either side closes it.
+func (d *Dataplane) forward(workloadConn, peerConn net.Conn) {
+	d.activeConnections.Add(1)
+	defer d.activeConnections.Add(-1)
+
+	newForwarder(workloadConn, peerConn).run()
+}
+
 // GetClusterTarget returns the cluster address:port from the cluster map.
 func (d *Dataplane) GetClusterTarget(name string) (string, error) {
 	if _, ok := d.clusters[name]; !ok {
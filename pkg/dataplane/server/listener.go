@@ -60,34 +60,50 @@ func (d *Dataplane) serveEgressConnections(name string, listener net.Listener) e
 			"Received an egress connection at listener for imported service %s from %s.", name, conn.RemoteAddr().String())
 		d.logger.Debugf("Connection: %+v.", conn)
 
-		targetPeer, accessToken, err := d.getEgressAuth(name, strings.Split(conn.RemoteAddr().String(), ":")[0])
-		if err != nil {
-			d.logger.Infof("Failed egress authorization: %v.", err)
-			conn.Close()
-			continue
-		}
-		d.logger.Infof("Received auth from controlplane: target peer: %s with %s", targetPeer, accessToken)
+		go d.serveEgressConnection(name, conn)
+	}
+}
 
-		targetHost, err := d.GetClusterHost(targetPeer)
+func (d *Dataplane) serveEgressConnection(name string, conn net.Conn) {
+	var spiffeID string
+	if d.spiffeSource != nil {
+		id, workloadConn, err := d.authenticateWorkload(conn)
 		if err != nil {
-			d.logger.Errorf("Unable to get cluster host :%v.", err)
+			d.logger.Infof("Failed workload authentication: %v.", err)
 			conn.Close()
-			continue
+			return
 		}
-		tlsConfig := d.parsedCertData.ClientConfig(targetHost)
-
-		go func() {
-			err := d.initiateEgressConnection(targetPeer, accessToken, conn, tlsConfig)
-			if err != nil {
-				d.logger.Errorf("Failed to initiate egress connection: %v.", err)
-				conn.Close()
-			}
-		}()
+		d.logger.Debugf("Authenticated workload %s.", id)
+
+		spiffeID = id.String()
+		conn = workloadConn
+	}
+
+	targetPeer, accessToken, err := d.getEgressAuth(name, strings.Split(conn.RemoteAddr().String(), ":")[0], spiffeID)
+	if err != nil {
+		d.logger.Infof("Failed egress authorization: %v.", err)
+		conn.Close()
+		return
+	}
+	d.logger.Infof("Received auth from controlplane: target peer: %s with %s", targetPeer, accessToken)
+
+	targetHost, err := d.GetClusterHost(targetPeer)
+	if err != nil {
+		d.logger.Errorf("Unable to get cluster host :%v.", err)
+		conn.Close()
+		return
+	}
+	tlsConfig := d.parsedCertData.ClientConfig(targetHost)
+
+	err = d.initiateEgressConnection(targetPeer, accessToken, conn, tlsConfig)
+	if err != nil {
+		d.logger.Errorf("Failed to initiate egress connection: %v.", err)
+		conn.Close()
 	}
 }
 
 // getEgressAuth returns the target cluster and authorization token for the outgoing connection.
-func (d *Dataplane) getEgressAuth(name, sourceIP string) (string, string, error) { //nolint:gocritic // unnamedResult
+func (d *Dataplane) getEgressAuth(name, sourceIP, spiffeID string) (string, string, error) { //nolint:gocritic // unnamedResult
 	url := "https://" + d.controlplaneTarget + api.DataplaneEgressAuthorizationPath
 	egressAuthReq, err := http.NewRequest(http.MethodPost, url, http.NoBody)
 	if err != nil {
@@ -98,6 +114,9 @@ func (d *Dataplane) getEgressAuth(name, sourceIP string) (string, string, error)
 	components := strings.SplitN(name, "/", 2)
 
 	egressAuthReq.Header.Add(api.ClientIPHeader, sourceIP)
+	if spiffeID != "" {
+		egressAuthReq.Header.Add(api.ClientSPIFFEIDHeader, spiffeID)
+	}
 	egressAuthReq.Header.Add(api.ImportNamespaceHeader, components[0])
 	egressAuthReq.Header.Add(api.ImportNameHeader, components[1])
 	egressAuthResp, err := d.apiClient.Do(egressAuthReq)
@@ -125,8 +125,7 @@ func (d *Dataplane) dataplaneIngressAuthorize(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	forward := newForwarder(appConn, peerConn)
-	forward.run()
+	d.forward(appConn, peerConn)
 }
 
 func (d *Dataplane) hijackConn(w http.ResponseWriter) (net.Conn, error) {
@@ -200,7 +199,6 @@ func (d *Dataplane) initiateEgressConnection(targetCluster, authToken string, ap
 
 	d.logger.Infof("Connection established successfully!")
 
-	forward := newForwarder(appConn, peerConn)
-	forward.run()
+	d.forward(appConn, peerConn)
 	return nil
 }
@@ -0,0 +1,145 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/clusterlink-net/clusterlink/pkg/util/spiffe"
+)
+
+const (
+	// workloadAuthTimeout is the time a client workload has to present its SPIFFE SVID.
+	workloadAuthTimeout = 10 * time.Second
+	// tlsHandshakeRecordType is the first byte sent by a TLS client.
+	tlsHandshakeRecordType = 0x16
+	// proxyAuthorizationHeader holds the JWT-SVID of a client workload using an HTTP CONNECT request.
+	proxyAuthorizationHeader = "Proxy-Authorization"
+	// bearerSchemaPrefix is the prefix of a bearer token in an authorization header.
+	bearerSchemaPrefix = "Bearer "
+)
+
+// bufferedConn is a connection whose initial bytes were already read into a buffer.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// EnableWorkloadIdentity requires client workloads connecting to imported services to authenticate
+// using SPIFFE SVIDs, which are verified using the given source.
+// JWT-SVIDs must be issued for the given audience.
+func (d *Dataplane) EnableWorkloadIdentity(source *spiffe.Source, audience string) {
+	d.spiffeSource = source
+	d.spiffeAudience = audience
+}
+
+// authenticateWorkload authenticates a client workload connecting to an imported service.
+// The client either starts a TLS handshake presenting its X.509 SVID, or sends an HTTP CONNECT request,
+// with its JWT-SVID set as a bearer token in the Proxy-Authorization header.
+// It returns the SPIFFE ID of the client, and the connection to forward.
+func (d *Dataplane) authenticateWorkload(conn net.Conn) (spiffeid.ID, net.Conn, error) {
+	if err := conn.SetDeadline(time.Now().Add(workloadAuthTimeout)); err != nil {
+		return spiffeid.ID{}, nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	first, err := reader.Peek(1)
+	if err != nil {
+		return spiffeid.ID{}, nil, fmt.Errorf("cannot read from client: %w", err)
+	}
+
+	bufConn := &bufferedConn{Conn: conn, reader: reader}
+
+	var id spiffeid.ID
+	var workloadConn net.Conn
+	if first[0] == tlsHandshakeRecordType {
+		id, workloadConn, err = d.authenticateX509SVID(bufConn)
+	} else {
+		id, workloadConn, err = d.authenticateJWTSVID(bufConn)
+	}
+	if err != nil {
+		return spiffeid.ID{}, nil, err
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return spiffeid.ID{}, nil, err
+	}
+
+	return id, workloadConn, nil
+}
+
+func (d *Dataplane) authenticateX509SVID(conn net.Conn) (spiffeid.ID, net.Conn, error) {
+	tlsConn := tls.Server(conn, d.spiffeSource.ServerTLSConfig())
+	if err := tlsConn.Handshake(); err != nil {
+		return spiffeid.ID{}, nil, fmt.Errorf("TLS handshake with client failed: %w", err)
+	}
+
+	state := tlsConn.ConnectionState()
+	id, err := spiffe.PeerID(&state)
+	if err != nil {
+		return spiffeid.ID{}, nil, err
+	}
+
+	return id, tlsConn, nil
+}
+
+func (d *Dataplane) authenticateJWTSVID(conn *bufferedConn) (spiffeid.ID, net.Conn, error) {
+	req, err := http.ReadRequest(conn.reader)
+	if err != nil {
+		return spiffeid.ID{}, nil, fmt.Errorf("cannot read client request: %w", err)
+	}
+
+	if req.Method != http.MethodConnect {
+		d.writeProxyResponse(conn, http.StatusMethodNotAllowed)
+		return spiffeid.ID{}, nil, fmt.Errorf("expected a CONNECT request, but got %s", req.Method)
+	}
+
+	authorization := req.Header.Get(proxyAuthorizationHeader)
+	if !strings.HasPrefix(authorization, bearerSchemaPrefix) {
+		d.writeProxyResponse(conn, http.StatusProxyAuthRequired)
+		return spiffeid.ID{}, nil, fmt.Errorf("missing JWT-SVID bearer token")
+	}
+
+	id, err := d.spiffeSource.VerifyJWTSVID(strings.TrimPrefix(authorization, bearerSchemaPrefix), d.spiffeAudience)
+	if err != nil {
+		d.writeProxyResponse(conn, http.StatusProxyAuthRequired)
+		return spiffeid.ID{}, nil, err
+	}
+
+	d.writeProxyResponse(conn, http.StatusOK)
+	return id, conn, nil
+}
+
+func (d *Dataplane) writeProxyResponse(conn net.Conn, status int) {
+	reason := http.StatusText(status)
+	if status == http.StatusOK {
+		reason = "Connection established"
+	}
+
+	if _, err := fmt.Fprintf(conn, "HTTP/1.1 %d %s\r\n\r\n", status, reason); err != nil {
+		d.logger.Errorf("Cannot write response to client: %v.", err)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	clusterlink "github.com/clusterlink-net/clusterlink/pkg/apis/clusterlink.net/v1alpha1"
+	dpapi "github.com/clusterlink-net/clusterlink/pkg/dataplane/api"
+)
+
+// dataplaneReplicas returns the initial number of dataplane replicas.
+func dataplaneReplicas(instance *clusterlink.Instance) int {
+	if autoscaling := instance.Spec.DataPlane.Autoscaling; autoscaling != nil {
+		return int(autoscalingMinReplicas(autoscaling))
+	}
+	if instance.Spec.DataPlane.Replicas < 1 {
+		return 1
+	}
+	return instance.Spec.DataPlane.Replicas
+}
+
+// autoscalingMinReplicas returns the minimal number of dataplane replicas when autoscaling.
+func autoscalingMinReplicas(autoscaling *clusterlink.AutoscalingSpec) int32 {
+	if autoscaling.MinReplicas < 1 {
+		return 1
+	}
+	return autoscaling.MinReplicas
+}
+
+// autoscalingMetric returns the metric used for autoscaling the dataplane.
+func autoscalingMetric(autoscaling *clusterlink.AutoscalingSpec) clusterlink.AutoscalingMetric {
+	if autoscaling.Metric == "" {
+		return clusterlink.AutoscalingMetricCPU
+	}
+	return autoscaling.Metric
+}
+
+// autoscalingTarget returns the target average value of the autoscaling metric.
+func autoscalingTarget(autoscaling *clusterlink.AutoscalingSpec) int32 {
+	if autoscaling.Target > 0 {
+		return autoscaling.Target
+	}
+	if autoscalingMetric(autoscaling) == clusterlink.AutoscalingMetricConnections {
+		return clusterlink.DefaultTargetActiveConnections
+	}
+	return clusterlink.DefaultTargetCPUUtilization
+}
+
+// validateDataplaneScaling checks the autoscaling and disruption budget settings of the dataplane.
+func validateDataplaneScaling(spec *clusterlink.DataPlaneSpec) error {
+	if autoscaling := spec.Autoscaling; autoscaling != nil {
+		if autoscaling.MaxReplicas < autoscalingMinReplicas(autoscaling) {
+			return fmt.Errorf("autoscaling: maxReplicas (%d) is lower than minReplicas (%d)",
+				autoscaling.MaxReplicas, autoscalingMinReplicas(autoscaling))
+		}
+
+		switch autoscalingMetric(autoscaling) {
+		case clusterlink.AutoscalingMetricCPU:
+			if _, ok := spec.Pod.Resources.Requests[corev1.ResourceCPU]; !ok {
+				return fmt.Errorf("autoscaling: the '%s' metric requires a CPU request in the dataplane pod resources",
+					clusterlink.AutoscalingMetricCPU)
+			}
+		case clusterlink.AutoscalingMetricConnections:
+		default:
+			return fmt.Errorf("autoscaling: unsupported metric '%s'", autoscaling.Metric)
+		}
+	}
+
+	if budget := spec.DisruptionBudget; budget != nil && budget.MinAvailable != nil && budget.MaxUnavailable != nil {
+		return fmt.Errorf("disruptionBudget: minAvailable and maxUnavailable are mutually exclusive")
+	}
+
+	return nil
+}
+
+// applyDataplaneAutoscaler sets up the dataplane HorizontalPodAutoscaler, or deletes it if autoscaling is not set.
+func (r *InstanceReconciler) applyDataplaneAutoscaler(ctx context.Context, instance *clusterlink.Instance) error {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DataPlaneName,
+			Namespace: instance.Spec.Namespace,
+		},
+	}
+
+	autoscaling := instance.Spec.DataPlane.Autoscaling
+	if autoscaling == nil {
+		return r.deleteResource(ctx, hpa)
+	}
+
+	target := autoscalingTarget(autoscaling)
+	metric := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: corev1.ResourceCPU,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &target,
+			},
+		},
+	}
+	if autoscalingMetric(autoscaling) == clusterlink.AutoscalingMetricConnections {
+		metric = autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: dpapi.ActiveConnectionsMetric},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: resource.NewQuantity(int64(target), resource.DecimalSI),
+				},
+			},
+		}
+	}
+
+	spec := autoscalingv2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       DataPlaneName,
+		},
+		MinReplicas: int32Ptr(autoscalingMinReplicas(autoscaling)),
+		MaxReplicas: autoscaling.MaxReplicas,
+		Metrics:     []autoscalingv2.MetricSpec{metric},
+	}
+
+	return r.createOrUpdateSpec(ctx, hpa, func() { hpa.Spec = spec })
+}
+
+// applyDataplaneDisruptionBudget sets up the dataplane PodDisruptionBudget.
+func (r *InstanceReconciler) applyDataplaneDisruptionBudget(ctx context.Context, instance *clusterlink.Instance) error {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DataPlaneName,
+			Namespace: instance.Spec.Namespace,
+		},
+	}
+
+	maxUnavailable := intstr.FromInt32(1)
+	spec := policyv1.PodDisruptionBudgetSpec{
+		Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": DataPlaneName}},
+		MaxUnavailable: &maxUnavailable,
+	}
+	if budget := instance.Spec.DataPlane.DisruptionBudget; budget != nil &&
+		(budget.MinAvailable != nil || budget.MaxUnavailable != nil) {
+		spec.MinAvailable = budget.MinAvailable
+		spec.MaxUnavailable = budget.MaxUnavailable
+	}
+
+	return r.createOrUpdateSpec(ctx, pdb, func() { pdb.Spec = spec })
+}
+
+// createOrUpdateSpec creates a k8s resource, or updates it using setSpec if it already exists.
+func (r *InstanceReconciler) createOrUpdateSpec(ctx context.Context, object client.Object, setSpec func()) error {
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, object, func() error {
+		setSpec()
+		return nil
+	})
+	if err != nil {
+		r.Logger.Error("create or update resource:", err)
+		return err
+	}
+
+	if result != controllerutil.OperationResultNone {
+		r.Logger.Infof("Resource %v Name: %s Namespace: %s %s",
+			reflect.TypeOf(object), object.GetName(), object.GetNamespace(), result)
+	}
+
+	return nil
+}
@@ -21,7 +21,9 @@ import (
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -54,6 +56,8 @@ const (
 	StatusModeNotExist    = "NotExist"
 	StatusModeProgressing = "ProgressingMode"
 	StatusModeReady       = "Ready"
+	StatusModeValid       = "Valid"
+	StatusModeInvalid     = "InvalidSpec"
 )
 
 // InstanceReconciler reconciles a ClusterLink instance object.
@@ -68,12 +72,18 @@ type InstanceReconciler struct {
 // +kubebuilder:rbac:groups=clusterlink.net,resources=instances/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=clusterlink.net,resources=instances/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=list;get;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=list;get;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services;serviceaccounts,verbs=list;get;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=list;get;watch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=list;get;watch
+// +kubebuilder:rbac:groups="coordination.k8s.io",resources=leases,verbs=get;create;update
 // +kubebuilder:rbac:groups="apps",resources=deployments,verbs=list;get;watch;create;update;patch;delete
 //nolint:lll // Ignore long line warning for Kubebuilder command.
+// +kubebuilder:rbac:groups="autoscaling",resources=horizontalpodautoscalers,verbs=list;get;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="policy",resources=poddisruptionbudgets,verbs=list;get;watch;create;update;patch;delete
+//nolint:lll // Ignore long line warning for Kubebuilder command.
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterroles;clusterrolebindings,verbs=list;get;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles;rolebindings,verbs=list;get;watch;create;update;patch;delete
 
 // TODO- should review the operator RABCs.
 
@@ -140,7 +150,9 @@ func (r *InstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	// CRD details
 	r.Logger.Infof("Enter instance Reconcile - (Namespace: %s, Name: %s)", instance.Namespace, instance.Name)
 	r.Logger.Info("InstanceSpec- ",
-		" DataPlane.Type: ", instance.Spec.DataPlane.Type,
+		" ControlPlane.Store: ", instance.Spec.ControlPlane.Store,
+		", ControlPlane.Replicas: ", instance.Spec.ControlPlane.Replicas,
+		", DataPlane.Type: ", instance.Spec.DataPlane.Type,
 		", DataPlane.Replicas: ", instance.Spec.DataPlane.Replicas,
 		", Ingress.Type: ", instance.Spec.Ingress.Type,
 		", Ingress.Port: ", instance.Spec.Ingress.Port,
@@ -159,6 +171,11 @@ func (r *InstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, err
 	}
 
+	// Invalid settings are reported in the instance status, and are not retried until the instance changes
+	if invalid, err := r.checkSpec(ctx, instance); err != nil || invalid {
+		return ctrl.Result{}, err
+	}
+
 	// Apply ClusterLink components if needed
 	if err := r.applyClusterLink(ctx, instance); err != nil {
 		return ctrl.Result{}, err
@@ -179,8 +196,15 @@ func (r *InstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 // applyClusterLink sets up all the components for the ClusterLink project.
 func (r *InstanceReconciler) applyClusterLink(ctx context.Context, instance *clusterlink.Instance) error {
 	// Create controlplane components
-	if err := r.createPVC(ctx, ControlPlaneName, instance.Spec.Namespace); err != nil {
-		return err
+	switch controlplaneStore(instance) {
+	case clusterlink.StoreTypeBolt:
+		if err := r.createPVC(ctx, ControlPlaneName, instance.Spec.Namespace); err != nil {
+			return err
+		}
+	case clusterlink.StoreTypeK8s:
+		if err := r.createStoreAccessControl(ctx, ControlPlaneName, instance.Spec.Namespace); err != nil {
+			return err
+		}
 	}
 
 	if err := r.createAccessControl(ctx, ControlPlaneName, instance.Spec.Namespace); err != nil {
@@ -204,13 +228,93 @@ func (r *InstanceReconciler) applyClusterLink(ctx context.Context, instance *clu
 		return err
 	}
 
+	if err := r.applyDataplaneAutoscaler(ctx, instance); err != nil {
+		return err
+	}
+
+	if err := r.applyDataplaneDisruptionBudget(ctx, instance); err != nil {
+		return err
+	}
+
 	// create external ingress service
 	return r.createExternalService(ctx, instance)
 }
 
+// checkSpec validates the component settings of an instance, and sets the SpecValid condition of each component.
+// Returns true if any of the settings are invalid.
+func (r *InstanceReconciler) checkSpec(ctx context.Context, instance *clusterlink.Instance) (bool, error) {
+	components := []struct {
+		status *clusterlink.ComponentStatus
+		err    error
+	}{
+		{&instance.Status.Controlplane, validateControlplane(instance)},
+		{&instance.Status.Dataplane, validateDataplane(instance)},
+	}
+
+	invalid, update := false, false
+	for _, component := range components {
+		condition := metav1.Condition{
+			Type:               string(clusterlink.SpecValid),
+			Status:             metav1.ConditionTrue,
+			Reason:             StatusModeValid,
+			Message:            "Component settings are valid",
+			LastTransitionTime: metav1.Now(),
+		}
+		if component.err != nil {
+			r.Logger.Errorf("Invalid instance settings: %v.", component.err)
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = StatusModeInvalid
+			condition.Message = component.err.Error()
+			invalid = true
+		}
+
+		if component.status.Conditions == nil {
+			component.status.Conditions = make(map[string]metav1.Condition)
+		}
+		if r.updateCondition(component.status.Conditions, []metav1.Condition{condition}) {
+			update = true
+		}
+	}
+
+	if update {
+		if err := r.Status().Update(ctx, instance); err != nil {
+			return false, err
+		}
+	}
+
+	return invalid, nil
+}
+
+// validateControlplane checks the controlplane settings of an instance.
+func validateControlplane(instance *clusterlink.Instance) error {
+	if controlplaneReplicas(instance) > 1 && controlplaneStore(instance) != clusterlink.StoreTypeK8s {
+		return fmt.Errorf("multiple controlplane replicas require the '%s' store", clusterlink.StoreTypeK8s)
+	}
+
+	if err := validatePodOverrides(&instance.Spec.ControlPlane.Pod); err != nil {
+		return fmt.Errorf("invalid controlplane pod settings: %w", err)
+	}
+
+	return nil
+}
+
+// validateDataplane checks the dataplane settings of an instance.
+func validateDataplane(instance *clusterlink.Instance) error {
+	if err := validatePodOverrides(&instance.Spec.DataPlane.Pod); err != nil {
+		return fmt.Errorf("invalid dataplane pod settings: %w", err)
+	}
+
+	if err := validateDataplaneScaling(&instance.Spec.DataPlane); err != nil {
+		return fmt.Errorf("invalid dataplane scaling settings: %w", err)
+	}
+
+	return nil
+}
+
 // applyControlplane sets up the controlplane deployment.
 func (r *InstanceReconciler) applyControlplane(ctx context.Context, instance *clusterlink.Instance) error {
-	cpDeployment := r.setDeployment(ControlPlaneName, instance.Spec.Namespace, 1)
+	replicas := controlplaneReplicas(instance)
+	cpDeployment := r.setDeployment(ControlPlaneName, instance.Spec.Namespace, int32(replicas))
 	cpDeployment.Spec.Template.Spec = corev1.PodSpec{
 		ServiceAccountName: ControlPlaneName,
 		Volumes: []corev1.Volume{
@@ -230,21 +334,16 @@ func (r *InstanceReconciler) applyControlplane(ctx context.Context, instance *cl
 					},
 				},
 			},
-			{
-				Name: ControlPlaneName,
-				VolumeSource: corev1.VolumeSource{
-					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-						ClaimName: ControlPlaneName,
-					},
-				},
-			},
 		},
 		Containers: []corev1.Container{
 			{
 				Name:            ControlPlaneName,
 				Image:           instance.Spec.ContainerRegistry + ControlPlaneName + ":" + instance.Spec.ImageTag,
 				ImagePullPolicy: corev1.PullIfNotPresent,
-				Args:            []string{"--log-level", instance.Spec.LogLevel},
+				Args: []string{
+					"--log-level", instance.Spec.LogLevel,
+					"--store", string(controlplaneStore(instance)),
+				},
 				Ports: []corev1.ContainerPort{
 					{
 						ContainerPort: cpapi.ListenPort,
@@ -269,10 +368,6 @@ func (r *InstanceReconciler) applyControlplane(ctx context.Context, instance *cl
 						SubPath:   "key",
 						ReadOnly:  true,
 					},
-					{
-						Name:      ControlPlaneName,
-						MountPath: filepath.Dir(cpapp.StoreFile),
-					},
 				},
 				Env: []corev1.EnvVar{
 					{
@@ -288,7 +383,46 @@ func (r *InstanceReconciler) applyControlplane(ctx context.Context, instance *cl
 		},
 	}
 
-	return r.createOrUpdateResource(ctx, &cpDeployment)
+	if replicas > 1 {
+		container := &cpDeployment.Spec.Template.Spec.Containers[0]
+		container.Args = append(container.Args, "--leader-elect")
+	}
+
+	if controlplaneStore(instance) == clusterlink.StoreTypeBolt {
+		podSpec := &cpDeployment.Spec.Template.Spec
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: ControlPlaneName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: ControlPlaneName,
+				},
+			},
+		})
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      ControlPlaneName,
+			MountPath: filepath.Dir(cpapp.StoreFile),
+		})
+	}
+
+	applyPodOverrides(&cpDeployment.Spec.Template.Spec, &instance.Spec.ControlPlane.Pod)
+
+	return r.createOrUpdateDeployment(ctx, &cpDeployment, false)
+}
+
+// controlplaneStore returns the type of store used by the controlplane.
+func controlplaneStore(instance *clusterlink.Instance) clusterlink.StoreType {
+	if instance.Spec.ControlPlane.Store == "" {
+		return clusterlink.StoreTypeBolt
+	}
+	return instance.Spec.ControlPlane.Store
+}
+
+// controlplaneReplicas returns the number of controlplane replicas.
+func controlplaneReplicas(instance *clusterlink.Instance) int {
+	if instance.Spec.ControlPlane.Replicas < 1 {
+		return 1
+	}
+	return instance.Spec.ControlPlane.Replicas
 }
 
 // applyDataplane sets up the dataplane deployment.
@@ -298,7 +432,7 @@ func (r *InstanceReconciler) applyDataplane(ctx context.Context, instance *clust
 		DataplaneImage = GoDataPlaneName
 	}
 
-	dpDeployment := r.setDeployment(DataPlaneName, instance.Spec.Namespace, int32(instance.Spec.DataPlane.Replicas))
+	dpDeployment := r.setDeployment(DataPlaneName, instance.Spec.Namespace, int32(dataplaneReplicas(instance)))
 	dpDeployment.Spec.Template.Spec = corev1.PodSpec{
 		Volumes: []corev1.Volume{
 			{
@@ -331,6 +465,10 @@ func (r *InstanceReconciler) applyDataplane(ctx context.Context, instance *clust
 					{
 						ContainerPort: dpapi.ListenPort,
 					},
+					{
+						Name:          "metrics",
+						ContainerPort: dpapi.MetricsPort,
+					},
 				},
 				VolumeMounts: []corev1.VolumeMount{
 					{
@@ -356,7 +494,10 @@ func (r *InstanceReconciler) applyDataplane(ctx context.Context, instance *clust
 		},
 	}
 
-	return r.createOrUpdateResource(ctx, &dpDeployment)
+	applyPodOverrides(&dpDeployment.Spec.Template.Spec, &instance.Spec.DataPlane.Pod)
+
+	// the replicas of an autoscaled dataplane are managed by its autoscaler
+	return r.createOrUpdateDeployment(ctx, &dpDeployment, instance.Spec.DataPlane.Autoscaling != nil)
 }
 
 func (r *InstanceReconciler) setDeployment(name, namespace string, replicas int32) appsv1.Deployment {
@@ -419,6 +560,53 @@ func (r *InstanceReconciler) createPVC(ctx context.Context, name, namespace stri
 	return r.createResource(ctx, controlplanePVC)
 }
 
+// createStoreAccessControl sets up k8s Role and RoleBinding allowing the controlplane
+// to persist its state in ConfigMaps, and to elect a leader using a Lease.
+func (r *InstanceReconciler) createStoreAccessControl(ctx context.Context, name, namespace string) error {
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get", "list", "watch", "create", "update", "delete"},
+			},
+			{
+				APIGroups: []string{"coordination.k8s.io"},
+				Resources: []string{"leases"},
+				Verbs:     []string{"get", "create", "update"},
+			},
+		},
+	}
+
+	if err := r.createResource(ctx, role); err != nil {
+		return err
+	}
+
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      ControlPlaneName,
+				Namespace: namespace,
+			},
+		},
+	}
+	return r.createResource(ctx, roleBinding)
+}
+
 // createAccessControl sets up k8s ClusterRule and ClusterRoleBinding for the controlplane.
 func (r *InstanceReconciler) createAccessControl(ctx context.Context, name, namespace string) error {
 	// Create ServiceAccount object
@@ -440,7 +628,7 @@ func (r *InstanceReconciler) createAccessControl(ctx context.Context, name, name
 			{
 				APIGroups: []string{""},
 				Resources: []string{"services"},
-				Verbs:     []string{"create", "delete", "update"},
+				Verbs:     []string{"create", "delete", "get", "update"},
 			},
 			{
 				APIGroups: []string{""},
@@ -477,6 +665,16 @@ func (r *InstanceReconciler) createAccessControl(ctx context.Context, name, name
 
 // createExternalService sets up the external service for the project.
 func (r *InstanceReconciler) createExternalService(ctx context.Context, instance *clusterlink.Instance) error {
+	service := ExternalService(instance)
+	if service == nil {
+		return nil
+	}
+
+	return r.createResource(ctx, service)
+}
+
+// ExternalService returns the external ingress service of an instance, or nil if the instance has no ingress.
+func ExternalService(instance *clusterlink.Instance) *corev1.Service {
 	if instance.Spec.Ingress.Type == clusterlink.IngressTypeNone {
 		return nil
 	}
@@ -514,7 +712,7 @@ func (r *InstanceReconciler) createExternalService(ctx context.Context, instance
 		}
 	}
 
-	return r.createResource(ctx, service)
+	return service
 }
 
 // createFinalizer sets up finalizer for the instance CRD.
@@ -534,21 +732,38 @@ func (r *InstanceReconciler) deleteFinalizer(ctx context.Context, instance *clus
 	return r.Update(ctx, instance)
 }
 
-// createOrUpdateResource uses for creates or updates k8s resource, in our case used only for deployments.
-func (r *InstanceReconciler) createOrUpdateResource(ctx context.Context, object client.Object) error {
-	// Use CreateOrUpdate to create or update the PVC for cl-controlplane
-	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, object, func() error {
-		createTime := object.GetCreationTimestamp()
-		if createTime.IsZero() {
-			r.Logger.Infof("Create resource %v Name: %s Namespace: %s", reflect.TypeOf(object), object.GetName(), object.GetNamespace())
+// createOrUpdateDeployment creates a deployment, or updates an existing deployment to the desired spec.
+// If keepReplicas is set, the replicas of an existing deployment are kept (e.g. when set by an autoscaler).
+func (r *InstanceReconciler) createOrUpdateDeployment(
+	ctx context.Context,
+	desired *appsv1.Deployment,
+	keepReplicas bool,
+) error {
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		exists := deployment.ResourceVersion != ""
+		if !exists || !keepReplicas || deployment.Spec.Replicas == nil {
+			deployment.Spec.Replicas = desired.Spec.Replicas
+		}
+
+		// the selector of an existing deployment is immutable
+		if !exists {
+			deployment.Spec.Selector = desired.Spec.Selector
 		}
+
+		deployment.Spec.Template = desired.Spec.Template
 		return nil
 	})
 	if err != nil {
-		r.Logger.Error("create resource:", err)
+		r.Logger.Error("create or update deployment:", err)
+		return err
 	}
 
-	return err
+	if result != controllerutil.OperationResultNone {
+		r.Logger.Infof("Deployment %s/%s %s", deployment.Namespace, deployment.Name, result)
+	}
+
+	return nil
 }
 
 // createResource uses for creates k8s resource.
@@ -590,6 +805,14 @@ func (r *InstanceReconciler) deleteClusterLink(ctx context.Context, namespace st
 		return err
 	}
 
+	if err := r.deleteResource(ctx, &rbacv1.Role{ObjectMeta: cpObj}); err != nil {
+		return err
+	}
+
+	if err := r.deleteResource(ctx, &rbacv1.RoleBinding{ObjectMeta: cpObj}); err != nil {
+		return err
+	}
+
 	// Delete dataplane Resources
 	dpObj := metav1.ObjectMeta{Name: DataPlaneName, Namespace: namespace}
 	if err := r.deleteResource(ctx, &appsv1.Deployment{ObjectMeta: dpObj}); err != nil {
@@ -600,6 +823,14 @@ func (r *InstanceReconciler) deleteClusterLink(ctx context.Context, namespace st
 		return err
 	}
 
+	if err := r.deleteResource(ctx, &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: dpObj}); err != nil {
+		return err
+	}
+
+	if err := r.deleteResource(ctx, &policyv1.PodDisruptionBudget{ObjectMeta: dpObj}); err != nil {
+		return err
+	}
+
 	// Delete external ingress service
 	ingerssObj := metav1.ObjectMeta{Name: IngressName, Namespace: namespace}
 	return r.deleteResource(ctx, &corev1.Service{ObjectMeta: ingerssObj})
@@ -621,34 +852,46 @@ func int32Ptr(i int32) *int32 {
 
 // checkStatus check the status of ClusterLink components.
 func (r *InstanceReconciler) checkStatus(ctx context.Context, instance *clusterlink.Instance) error {
-	cpUpdate, err := r.checkControlplaneStatus(ctx, instance)
+	update, err := r.UpdateStatus(ctx, instance)
 	if err != nil {
 		return err
 	}
 
+	if update {
+		return r.Status().Update(ctx, instance)
+	}
+
+	return nil
+}
+
+// UpdateStatus sets the status conditions of an instance according to its ClusterLink components in the cluster,
+// without updating the instance object itself. Returns true if the status has changed.
+func (r *InstanceReconciler) UpdateStatus(ctx context.Context, instance *clusterlink.Instance) (bool, error) {
+	cpUpdate, err := r.checkControlplaneStatus(ctx, instance)
+	if err != nil {
+		return false, err
+	}
+
 	dpUpdate, err := r.checkDataplaneStatus(ctx, instance)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	ingressUpdate := false
 	if instance.Spec.Ingress.Type != clusterlink.IngressTypeNone {
 		ingressUpdate, err = r.checkIngressStatus(ctx, instance)
 		if err != nil {
-			return err
+			return false, err
 		}
 	}
-	if cpUpdate || dpUpdate || ingressUpdate {
-		return r.Status().Update(ctx, instance)
-	}
 
-	return nil
+	return cpUpdate || dpUpdate || ingressUpdate, nil
 }
 
 // checkControlplaneStatus check the status of the controlplane components.
 func (r *InstanceReconciler) checkControlplaneStatus(ctx context.Context, instance *clusterlink.Instance) (bool, error) {
 	cp := types.NamespacedName{Name: ControlPlaneName, Namespace: instance.Spec.Namespace}
-	deploymentStatus, err := r.checkDeploymnetStatus(ctx, cp)
+	deployment, deploymentStatus, err := r.checkDeploymnetStatus(ctx, cp)
 	if err != nil {
 		return false, err
 	}
@@ -662,14 +905,15 @@ func (r *InstanceReconciler) checkControlplaneStatus(ctx context.Context, instan
 	}
 
 	updateFlag := r.updateCondition(instance.Status.Controlplane.Conditions, []metav1.Condition{deploymentStatus, serviceStatus})
+	replicasUpdate := r.updateReplicas(&instance.Status.Controlplane, deployment, nil)
 
-	return updateFlag, nil
+	return updateFlag || replicasUpdate, nil
 }
 
 // checkDataplaneStatus check the status of the dataplane components.
 func (r *InstanceReconciler) checkDataplaneStatus(ctx context.Context, instance *clusterlink.Instance) (bool, error) {
 	dp := types.NamespacedName{Name: DataPlaneName, Namespace: instance.Spec.Namespace}
-	deploymentStatus, err := r.checkDeploymnetStatus(ctx, dp)
+	deployment, deploymentStatus, err := r.checkDeploymnetStatus(ctx, dp)
 	if err != nil {
 		return false, err
 	}
@@ -682,8 +926,20 @@ func (r *InstanceReconciler) checkDataplaneStatus(ctx context.Context, instance
 		instance.Status.Dataplane.Conditions = make(map[string]metav1.Condition)
 	}
 
+	var hpa *autoscalingv2.HorizontalPodAutoscaler
+	if instance.Spec.DataPlane.Autoscaling != nil {
+		hpa = &autoscalingv2.HorizontalPodAutoscaler{}
+		if err := r.Get(ctx, dp, hpa); err != nil {
+			if !errors.IsNotFound(err) {
+				return false, err
+			}
+			hpa = nil
+		}
+	}
+
 	updateFlag := r.updateCondition(instance.Status.Dataplane.Conditions, []metav1.Condition{deploymentStatus, serviceStatus})
-	return updateFlag, nil
+	replicasUpdate := r.updateReplicas(&instance.Status.Dataplane, deployment, hpa)
+	return updateFlag || replicasUpdate, nil
 }
 
 // checkIngressStatus check the status of the ingress components.
@@ -703,7 +959,9 @@ func (r *InstanceReconciler) checkIngressStatus(ctx context.Context, instance *c
 }
 
 // checkDeploymnetStatus check the status of a deployment.
-func (r *InstanceReconciler) checkDeploymnetStatus(ctx context.Context, name types.NamespacedName) (metav1.Condition, error) {
+func (r *InstanceReconciler) checkDeploymnetStatus(ctx context.Context, name types.NamespacedName) (
+	*appsv1.Deployment, metav1.Condition, error,
+) {
 	d := &appsv1.Deployment{}
 	status := metav1.Condition{
 		Type:               string(clusterlink.DeploymentReady),
@@ -715,9 +973,9 @@ func (r *InstanceReconciler) checkDeploymnetStatus(ctx context.Context, name typ
 		if errors.IsNotFound(err) {
 			status.Reason = "NotExist"
 			status.Message = "Deployment does not exist"
-			return status, nil
+			return nil, status, nil
 		}
-		return metav1.Condition{}, err
+		return nil, metav1.Condition{}, err
 	}
 
 	status.Reason = StatusModeProgressing
@@ -732,18 +990,18 @@ func (r *InstanceReconciler) checkDeploymnetStatus(ctx context.Context, name typ
 				status.Status = metav1.ConditionTrue
 				status.Reason = StatusModeReady
 				status.Message = "Deployment is ready"
-				return status, nil
+				return d, status, nil
 			}
 		case appsv1.DeploymentProgressing, appsv1.DeploymentReplicaFailure:
 			if condition.Status != corev1.ConditionTrue {
 				status.Reason = condition.Reason
 				status.Message = condition.Message
-				return status, nil
+				return d, status, nil
 			}
 		}
 	}
 
-	return status, nil
+	return d, status, nil
 }
 
 // checkExternlaServiceStatus check the status of a external service.
@@ -822,6 +1080,35 @@ func (r *InstanceReconciler) updateCondition(conditions map[string]metav1.Condit
 	return update
 }
 
+// updateReplicas updates the component status replica counts, according to its deployment and autoscaler.
+// Returns true if the counts have changed.
+func (r *InstanceReconciler) updateReplicas(
+	status *clusterlink.ComponentStatus,
+	deployment *appsv1.Deployment,
+	hpa *autoscalingv2.HorizontalPodAutoscaler,
+) bool {
+	var replicas, desired, ready int32
+	if deployment != nil {
+		replicas = deployment.Status.Replicas
+		ready = deployment.Status.ReadyReplicas
+		if deployment.Spec.Replicas != nil {
+			desired = *deployment.Spec.Replicas
+		}
+	}
+	if hpa != nil && hpa.Status.DesiredReplicas > 0 {
+		desired = hpa.Status.DesiredReplicas
+	}
+
+	if status.Replicas == replicas && status.DesiredReplicas == desired && status.ReadyReplicas == ready {
+		return false
+	}
+
+	status.Replicas = replicas
+	status.DesiredReplicas = desired
+	status.ReadyReplicas = ready
+	return true
+}
+
 // updateCondition updates the component status conditions.
 func (r *InstanceReconciler) getNodeIP(ctx context.Context) (string, error) {
 	nodeList := corev1.NodeList{}
@@ -0,0 +1,201 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterlink "github.com/clusterlink-net/clusterlink/pkg/apis/clusterlink.net/v1alpha1"
+	dpapi "github.com/clusterlink-net/clusterlink/pkg/dataplane/api"
+	"github.com/clusterlink-net/clusterlink/pkg/operator/controller"
+)
+
+// newReconciler returns an instance reconciler backed by a fake client, holding the given instance.
+func newReconciler(t *testing.T, instance *clusterlink.Instance) *controller.InstanceReconciler {
+	scheme := runtime.NewScheme()
+	require.Nil(t, clientgoscheme.AddToScheme(scheme))
+	require.Nil(t, clusterlink.AddToScheme(scheme))
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(instance).
+		WithStatusSubresource(&clusterlink.Instance{}).
+		Build()
+
+	return &controller.InstanceReconciler{
+		Client:        cl,
+		Scheme:        scheme,
+		Logger:        logrus.WithField("component", "test"),
+		InstancesMeta: make(map[string]string),
+	}
+}
+
+func reconcile(t *testing.T, r *controller.InstanceReconciler, instance *clusterlink.Instance) *clusterlink.Instance {
+	name := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: name})
+	require.Nil(t, err)
+
+	var result clusterlink.Instance
+	require.Nil(t, r.Get(context.Background(), name, &result))
+	return &result
+}
+
+func TestReconcileUpdatesDeployments(t *testing.T) {
+	instance := &clusterlink.Instance{
+		ObjectMeta: metav1.ObjectMeta{Name: "cl-instance", Namespace: controller.OperatorNameSpace},
+		Spec: clusterlink.InstanceSpec{
+			DataPlane: clusterlink.DataPlaneSpec{
+				Type: clusterlink.DataplaneTypeEnvoy,
+				Autoscaling: &clusterlink.AutoscalingSpec{
+					MinReplicas: 1,
+					MaxReplicas: 5,
+					Metric:      clusterlink.AutoscalingMetricCPU,
+				},
+				Pod: clusterlink.PodOverrides{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resourceQuantity(t, "100m")},
+					},
+				},
+			},
+			Ingress:   clusterlink.IngressSpec{Type: clusterlink.IngressTypeNone},
+			LogLevel:  "info",
+			Namespace: controller.InstanceNamespace,
+		},
+	}
+	r := newReconciler(t, instance)
+	reconcile(t, r, instance)
+
+	dataplane := types.NamespacedName{Name: controller.DataPlaneName, Namespace: controller.InstanceNamespace}
+	var deployment appsv1.Deployment
+	require.Nil(t, r.Get(context.Background(), dataplane, &deployment))
+	require.Equal(t, int32(1), *deployment.Spec.Replicas)
+
+	// the autoscaler scales the dataplane
+	deployment.Spec.Replicas = int32Ptr(3)
+	require.Nil(t, r.Update(context.Background(), &deployment))
+
+	// a changed instance updates the pod template, but keeps the autoscaled replicas
+	current := reconcile(t, r, instance)
+	current.Spec.LogLevel = "debug"
+	require.Nil(t, r.Update(context.Background(), current))
+	reconcile(t, r, instance)
+
+	require.Nil(t, r.Get(context.Background(), dataplane, &deployment))
+	require.Equal(t, int32(3), *deployment.Spec.Replicas)
+	require.Contains(t, deployment.Spec.Template.Spec.Containers[0].Args, "debug")
+
+	// without autoscaling, the replicas are set by the instance
+	current = reconcile(t, r, instance)
+	current.Spec.DataPlane.Autoscaling = nil
+	current.Spec.DataPlane.Replicas = 2
+	require.Nil(t, r.Update(context.Background(), current))
+	reconcile(t, r, instance)
+
+	require.Nil(t, r.Get(context.Background(), dataplane, &deployment))
+	require.Equal(t, int32(2), *deployment.Spec.Replicas)
+}
+
+func TestReconcileInvalidSpec(t *testing.T) {
+	instance := &clusterlink.Instance{
+		ObjectMeta: metav1.ObjectMeta{Name: "cl-instance", Namespace: controller.OperatorNameSpace},
+		Spec: clusterlink.InstanceSpec{
+			DataPlane: clusterlink.DataPlaneSpec{
+				Type: clusterlink.DataplaneTypeEnvoy,
+				Pod: clusterlink.PodOverrides{
+					NodeSelector: map[string]string{"invalid key!": "value"},
+				},
+			},
+			Ingress:   clusterlink.IngressSpec{Type: clusterlink.IngressTypeNone},
+			Namespace: controller.InstanceNamespace,
+		},
+	}
+	r := newReconciler(t, instance)
+	result := reconcile(t, r, instance)
+
+	// invalid settings are reported in the status, and no components are deployed
+	condition := result.Status.Dataplane.Conditions[string(clusterlink.SpecValid)]
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Contains(t, condition.Message, "nodeSelector")
+	require.Equal(t, metav1.ConditionTrue,
+		result.Status.Controlplane.Conditions[string(clusterlink.SpecValid)].Status)
+
+	var deployments appsv1.DeploymentList
+	require.Nil(t, r.List(context.Background(), &deployments))
+	require.Empty(t, deployments.Items)
+
+	// fixing the settings deploys the components
+	result.Spec.DataPlane.Pod.NodeSelector = nil
+	require.Nil(t, r.Update(context.Background(), result))
+	result = reconcile(t, r, instance)
+
+	require.Equal(t, metav1.ConditionTrue, result.Status.Dataplane.Conditions[string(clusterlink.SpecValid)].Status)
+	require.Nil(t, r.List(context.Background(), &deployments))
+	require.Len(t, deployments.Items, 2)
+}
+
+func resourceQuantity(t *testing.T, value string) resource.Quantity {
+	quantity, err := resource.ParseQuantity(value)
+	require.Nil(t, err)
+	return quantity
+}
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
+
+func TestReconcileConnectionsAutoscaling(t *testing.T) {
+	instance := &clusterlink.Instance{
+		ObjectMeta: metav1.ObjectMeta{Name: "cl-instance", Namespace: controller.OperatorNameSpace},
+		Spec: clusterlink.InstanceSpec{
+			DataPlane: clusterlink.DataPlaneSpec{
+				Type: clusterlink.DataplaneTypeEnvoy,
+				Autoscaling: &clusterlink.AutoscalingSpec{
+					MinReplicas: 2,
+					MaxReplicas: 5,
+					Metric:      clusterlink.AutoscalingMetricConnections,
+				},
+			},
+			Ingress:   clusterlink.IngressSpec{Type: clusterlink.IngressTypeNone},
+			Namespace: controller.InstanceNamespace,
+		},
+	}
+	r := newReconciler(t, instance)
+	reconcile(t, r, instance)
+
+	var hpa autoscalingv2.HorizontalPodAutoscaler
+	name := types.NamespacedName{Name: controller.DataPlaneName, Namespace: controller.InstanceNamespace}
+	require.Nil(t, r.Get(context.Background(), name, &hpa))
+	require.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+	require.Len(t, hpa.Spec.Metrics, 1)
+
+	// the dataplane pods are scaled by the average of the connections gauge they export
+	metric := hpa.Spec.Metrics[0]
+	require.Equal(t, autoscalingv2.PodsMetricSourceType, metric.Type)
+	require.Equal(t, dpapi.ActiveConnectionsMetric, metric.Pods.Metric.Name)
+	require.Equal(t, int64(clusterlink.DefaultTargetActiveConnections), metric.Pods.Target.AverageValue.Value())
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	clusterlink "github.com/clusterlink-net/clusterlink/pkg/apis/clusterlink.net/v1alpha1"
+)
+
+// validatePodOverrides checks the pod overrides of a ClusterLink component.
+func validatePodOverrides(overrides *clusterlink.PodOverrides) error {
+	var errs []error
+
+	for name, request := range overrides.Resources.Requests {
+		if limit, ok := overrides.Resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			errs = append(errs, fmt.Errorf("resources: %s request (%s) exceeds its limit (%s)",
+				name, request.String(), limit.String()))
+		}
+	}
+
+	for key, value := range overrides.NodeSelector {
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs = append(errs, fmt.Errorf("nodeSelector: invalid key '%s': %s", key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(value) {
+			errs = append(errs, fmt.Errorf("nodeSelector: invalid value '%s' for key '%s': %s", value, key, msg))
+		}
+	}
+
+	for i := range overrides.Tolerations {
+		if err := validateToleration(&overrides.Tolerations[i]); err != nil {
+			errs = append(errs, fmt.Errorf("tolerations[%d]: %w", i, err))
+		}
+	}
+
+	for i, secret := range overrides.ImagePullSecrets {
+		if secret.Name == "" {
+			errs = append(errs, fmt.Errorf("imagePullSecrets[%d]: missing name", i))
+		}
+	}
+
+	if overrides.PriorityClassName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(overrides.PriorityClassName) {
+			errs = append(errs, fmt.Errorf("priorityClassName: %s", msg))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateToleration checks a single pod toleration.
+func validateToleration(toleration *corev1.Toleration) error {
+	switch toleration.Operator {
+	case "", corev1.TolerationOpEqual:
+		if toleration.Key == "" && toleration.Value != "" {
+			return fmt.Errorf("value requires a key")
+		}
+	case corev1.TolerationOpExists:
+		if toleration.Value != "" {
+			return fmt.Errorf("value must be empty when operator is '%s'", corev1.TolerationOpExists)
+		}
+	default:
+		return fmt.Errorf("unsupported operator '%s'", toleration.Operator)
+	}
+
+	if toleration.Key != "" {
+		if msgs := validation.IsQualifiedName(toleration.Key); len(msgs) > 0 {
+			return fmt.Errorf("invalid key '%s': %s", toleration.Key, msgs[0])
+		}
+	}
+
+	switch toleration.Effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule:
+		if toleration.TolerationSeconds != nil {
+			return fmt.Errorf("tolerationSeconds requires the '%s' effect", corev1.TaintEffectNoExecute)
+		}
+	case corev1.TaintEffectNoExecute:
+	default:
+		return fmt.Errorf("unsupported effect '%s'", toleration.Effect)
+	}
+
+	return nil
+}
+
+// applyPodOverrides merges the pod overrides of a ClusterLink component into its pod spec.
+// Resources and the container security context apply to the first (component) container.
+func applyPodOverrides(podSpec *corev1.PodSpec, overrides *clusterlink.PodOverrides) {
+	overrides = overrides.DeepCopy()
+
+	podSpec.NodeSelector = overrides.NodeSelector
+	podSpec.Tolerations = overrides.Tolerations
+	podSpec.Affinity = overrides.Affinity
+	podSpec.ImagePullSecrets = overrides.ImagePullSecrets
+	podSpec.SecurityContext = overrides.SecurityContext
+	podSpec.PriorityClassName = overrides.PriorityClassName
+
+	if len(podSpec.Containers) > 0 {
+		podSpec.Containers[0].Resources = overrides.Resources
+		podSpec.Containers[0].SecurityContext = overrides.ContainerSecurityContext
+	}
+}
@@ -19,6 +19,7 @@ import (
 	logrusr "github.com/bombsimon/logrusr/v4"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -39,8 +40,10 @@ type Platform struct {
 	//	endpointReconciler *Reconciler
 	serviceReconciler *Reconciler
 	client            client.Client
-	namespace         string
-	logger            *logrus.Entry
+	// reader reads objects directly from the API server, bypassing the cache.
+	reader    client.Reader
+	namespace string
+	logger    *logrus.Entry
 }
 
 func (p *Platform) setExternalNameService(host, externalName string) *corev1.Service {
@@ -113,6 +116,44 @@ func (p *Platform) DeleteService(name, host string) {
 	go p.serviceReconciler.DeleteResource(name, serviceSpec)
 }
 
+// ApplyService creates a service, or updates it if it already exists.
+func (p *Platform) ApplyService(name, host, targetApp string, port, targetPort uint16) error {
+	serviceSpec := p.setClusterIPService(host, targetApp, port, targetPort)
+	p.logger.Infof("Applying K8s service at %s:%d.", host, port)
+	return p.serviceReconciler.ApplyResource(name, serviceSpec)
+}
+
+// ApplyExternalService creates an external service, or updates it if it already exists.
+func (p *Platform) ApplyExternalService(name, host, externalName string) error {
+	serviceSpec := p.setExternalNameService(host, externalName)
+	p.logger.Infof("Applying Kubernetes service %s of type ExternalName linked to %s.", host, externalName)
+	return p.serviceReconciler.ApplyResource(name, serviceSpec)
+}
+
+// RemoveService deletes a service, if it exists.
+func (p *Platform) RemoveService(name, host string) error {
+	serviceSpec := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: host, Namespace: p.namespace},
+	}
+
+	p.logger.Infof("Removing K8s service %s.", host)
+	return p.serviceReconciler.RemoveResource(name, serviceSpec)
+}
+
+// GetService returns a service, or nil if it does not exist.
+func (p *Platform) GetService(host string) (*corev1.Service, error) {
+	service := &corev1.Service{}
+	err := p.reader.Get(context.Background(), client.ObjectKey{Name: host, Namespace: p.namespace}, service)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
 // GetLabelsFromIP return all the labels for specific ip.
 func (p *Platform) GetLabelsFromIP(ip string) map[string]string {
 	return p.podReconciler.GetLabelsFromIP(ip)
@@ -153,8 +194,9 @@ func NewPlatform(namespace string) (*Platform, error) {
 
 	return &Platform{
 		client:            manager.GetClient(),
+		reader:            manager.GetAPIReader(),
 		podReconciler:     podReconciler,
-		serviceReconciler: NewReconciler(manager.GetClient()),
+		serviceReconciler: NewReconciler(manager.GetClient(), manager.GetAPIReader()),
 		namespace:         namespace,
 		logger:            logger,
 	}, nil
@@ -15,9 +15,12 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -26,9 +29,14 @@ type reconcileObj struct {
 	op   string
 }
 
+// requestTimeout is the timeout for applying (or removing) a k8s resource.
+const requestTimeout = 30 * time.Second
+
 // Reconciler contain list of k8s objects and their state.
 type Reconciler struct {
-	client     client.Client
+	client client.Client
+	// reader reads objects directly from the API server, bypassing the cache.
+	reader     client.Reader
 	list       map[string]client.Object
 	failedList map[string]reconcileObj
 	logger     *logrus.Entry
@@ -91,12 +99,60 @@ func (r *Reconciler) DeleteResource(name string, obj client.Object) {
 	delete(r.list, name)
 }
 
+// ApplyResource creates a k8s resource, or updates it if it already exists.
+// Unlike CreateResource, failures are returned to the caller rather than recorded.
+func (r *Reconciler) ApplyResource(name string, obj client.Object) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	err := r.client.Create(ctx, obj)
+	if apierrors.IsAlreadyExists(err) {
+		existing, ok := obj.DeepCopyObject().(client.Object)
+		if !ok {
+			return fmt.Errorf("cannot copy K8s %v", reflect.TypeOf(obj).String())
+		}
+
+		// read directly, as caching would require watching all objects of the kind
+		if err := r.reader.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+			return err
+		}
+
+		obj.SetResourceVersion(existing.GetResourceVersion())
+		err = r.client.Update(ctx, obj)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.list[name] = obj
+	delete(r.failedList, name)
+	return nil
+}
+
+// RemoveResource deletes a k8s resource, if it exists.
+// Unlike DeleteResource, failures are returned to the caller rather than recorded.
+func (r *Reconciler) RemoveResource(name string, obj client.Object) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	if err := client.IgnoreNotFound(r.client.Delete(ctx, obj)); err != nil {
+		return err
+	}
+
+	if existing, ok := r.list[name]; ok && existing.GetName() == obj.GetName() {
+		delete(r.list, name)
+	}
+	return nil
+}
+
 // NewReconciler returns reconciler for k8s objects.
-func NewReconciler(cl client.Client) *Reconciler {
+// Objects are written using the given client, and read using the given reader.
+func NewReconciler(cl client.Client, reader client.Reader) *Reconciler {
 	logger := logrus.WithField("component", "reconciler.k8s")
 
 	return &Reconciler{
 		client:     cl,
+		reader:     reader,
 		list:       make(map[string]client.Object),
 		failedList: make(map[string]reconcileObj),
 		logger:     logger,
@@ -0,0 +1,33 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Platform represents the platform (e.g. k8s) on which the controlplane runs,
+// managing the services of imports and exports.
+type Platform interface {
+	// ApplyService creates a service, or updates it if it already exists.
+	ApplyService(name, host, targetApp string, port, targetPort uint16) error
+	// ApplyExternalService creates an external service, or updates it if it already exists.
+	ApplyExternalService(name, host, externalName string) error
+	// RemoveService deletes a service, if it exists.
+	RemoveService(name, host string) error
+	// GetService returns a service, or nil if it does not exist.
+	GetService(host string) (*corev1.Service, error)
+	// GetLabelsFromIP return all the labels for specific ip.
+	GetLabelsFromIP(ip string) map[string]string
+}
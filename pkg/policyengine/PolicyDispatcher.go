@@ -31,6 +31,11 @@ const (
 
 	ServiceNameLabel = "clusterlink/metadata.serviceName"
 	GatewayNameLabel = "clusterlink/metadata.gatewayName"
+
+	// Attributes of a client workload authenticated using a SPIFFE SVID.
+	SpiffeTrustDomainLabel    = "clusterlink/spiffe.trustDomain"
+	SpiffeNamespaceLabel      = "clusterlink/spiffe.namespace"
+	SpiffeServiceAccountLabel = "clusterlink/spiffe.serviceAccount"
 )
 
 var plog = logrus.WithField("component", "PolicyEngine")
@@ -104,9 +109,9 @@ func (pH *PolicyHandler) decideIncomingConnection(req *policytypes.ConnectionReq
 		return policytypes.ConnectionResponse{Action: policytypes.ActionDeny}, err
 	}
 	if decisions[0].Decision == policytypes.DecisionAllow {
-		return policytypes.ConnectionResponse{Action: policytypes.ActionAllow}, nil
+		return policytypes.ConnectionResponse{Action: policytypes.ActionAllow, MatchedBy: decisions[0].MatchedBy}, nil
 	}
-	return policytypes.ConnectionResponse{Action: policytypes.ActionDeny}, nil
+	return policytypes.ConnectionResponse{Action: policytypes.ActionDeny, MatchedBy: decisions[0].MatchedBy}, nil
 }
 
 func (pH *PolicyHandler) decideOutgoingConnection(req *policytypes.ConnectionRequest) (policytypes.ConnectionResponse, error) {
@@ -128,8 +133,10 @@ func (pH *PolicyHandler) decideOutgoingConnection(req *policytypes.ConnectionReq
 	}
 
 	allowedPeers := []string{}
+	matchedBy := map[string]string{}
 	for _, decision := range decisions {
 		dstPeer := decision.Destination[GatewayNameLabel]
+		matchedBy[dstPeer] = decision.MatchedBy
 		if decision.Decision == policytypes.DecisionAllow {
 			allowedPeers = append(allowedPeers, dstPeer)
 		}
@@ -137,7 +144,11 @@ func (pH *PolicyHandler) decideOutgoingConnection(req *policytypes.ConnectionReq
 
 	if len(allowedPeers) == 0 {
 		plog.Infof("access policies deny connections to service %s in all peers", req.DstSvcName)
-		return policytypes.ConnectionResponse{Action: policytypes.ActionDeny}, nil
+		resp := policytypes.ConnectionResponse{Action: policytypes.ActionDeny}
+		if len(decisions) > 0 {
+			resp.MatchedBy = decisions[0].MatchedBy
+		}
+		return resp, nil
 	}
 
 	// Perform load-balancing using the filtered peer list
@@ -146,7 +157,11 @@ func (pH *PolicyHandler) decideOutgoingConnection(req *policytypes.ConnectionReq
 	if err != nil {
 		return policytypes.ConnectionResponse{Action: policytypes.ActionDeny}, err
 	}
-	return policytypes.ConnectionResponse{Action: policytypes.ActionAllow, DstPeer: targetPeer}, nil
+	return policytypes.ConnectionResponse{
+		Action:    policytypes.ActionAllow,
+		DstPeer:   targetPeer,
+		MatchedBy: matchedBy[targetPeer],
+	}, nil
 }
 
 func (pH *PolicyHandler) AuthorizeAndRouteConnection(req *policytypes.ConnectionRequest) (
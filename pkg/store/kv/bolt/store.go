@@ -16,6 +16,7 @@ package bolt
 import (
 	"bytes"
 	"fmt"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 	"go.etcd.io/bbolt"
@@ -28,22 +29,49 @@ const (
 )
 
 // Store implements a store backed by Bolt.
+// Revisions of the store are the IDs of bolt write transactions.
 type Store struct {
 	db *bbolt.DB
+	// lock is held while committing a write transaction and notifying its events,
+	// so that watchers receive events in revision order.
+	lock        sync.Mutex
+	broadcaster *kv.Broadcaster
 
 	logger *logrus.Entry
 }
 
+// update calls f within a write transaction, and notifies watchers of the changes once committed.
+func (s *Store) update(f func(*txStore) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var events []kv.Event
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		txStore := &txStore{
+			bucket:   tx.Bucket([]byte(bucketName)),
+			revision: uint64(tx.ID()),
+		}
+		if err := f(txStore); err != nil {
+			return err
+		}
+
+		events = txStore.events
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.broadcaster.Notify(events...)
+	return nil
+}
+
 // Create a (key, value) in the store.
 func (s *Store) Create(key, value []byte) error {
 	s.logger.Debugf("Creating key: %v.", key)
 
-	return s.db.Update(func(tx *bbolt.Tx) error {
-		bucket := tx.Bucket([]byte(bucketName))
-		if bucket.Get(key) != nil {
-			return &kv.KeyExistsError{}
-		}
-		return bucket.Put(key, value)
+	return s.update(func(tx *txStore) error {
+		return tx.Create(key, value)
 	})
 }
 
@@ -51,21 +79,8 @@ func (s *Store) Create(key, value []byte) error {
 func (s *Store) Update(key []byte, mutator func([]byte) ([]byte, error)) error {
 	s.logger.Debugf("Updating key: %v.", key)
 
-	return s.db.Update(func(tx *bbolt.Tx) error {
-		bucket := tx.Bucket([]byte(bucketName))
-
-		value := bucket.Get(key)
-		if value == nil {
-			return &kv.KeyNotFoundError{}
-		}
-
-		// update value
-		updated, err := mutator(value)
-		if err != nil {
-			return err
-		}
-
-		return bucket.Put(key, updated)
+	return s.update(func(tx *txStore) error {
+		return tx.Update(key, mutator)
 	})
 }
 
@@ -73,8 +88,8 @@ func (s *Store) Update(key []byte, mutator func([]byte) ([]byte, error)) error {
 func (s *Store) Delete(key []byte) error {
 	s.logger.Debugf("Deleting key: %v.", key)
 
-	return s.db.Update(func(tx *bbolt.Tx) error {
-		return tx.Bucket([]byte(bucketName)).Delete(key)
+	return s.update(func(tx *txStore) error {
+		return tx.Delete(key)
 	})
 }
 
@@ -95,9 +110,42 @@ func (s *Store) Range(prefix []byte, f func(key, value []byte) error) error {
 	})
 }
 
+// Transaction calls f with a store whose changes are persisted atomically when f returns nil,
+// and discarded if f returns an error.
+func (s *Store) Transaction(f func(kv.Store) error) error {
+	s.logger.Debug("Starting transaction.")
+
+	return s.update(func(tx *txStore) error {
+		tx.logger = s.logger
+		return f(tx)
+	})
+}
+
+// Recover completes a transaction which was interrupted after being committed.
+// Bolt transactions are atomic, so there is nothing to recover.
+func (s *Store) Recover() error {
+	return nil
+}
+
+// Watch returns a channel of changes to keys starting with the given prefix, and a function to stop watching.
+func (s *Store) Watch(prefix []byte) (<-chan kv.Event, func()) {
+	return s.broadcaster.Watch(prefix)
+}
+
+// Revision returns the current revision of the store, which is the ID of the last write transaction.
+func (s *Store) Revision() (uint64, error) {
+	var revision uint64
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		revision = uint64(tx.ID())
+		return nil
+	})
+	return revision, err
+}
+
 // Close frees all resources (e.g. file handles, network sockets) used by the store.
 func (s *Store) Close() error {
 	s.logger.Info("Closing store.")
+	s.broadcaster.Reset()
 	return s.db.Close()
 }
 
@@ -119,7 +167,8 @@ func Open(path string) (*Store, error) {
 	}
 
 	return &Store{
-		db,
-		logrus.WithField("component", "store.kv.bolt"),
+		db:          db,
+		broadcaster: kv.NewBroadcaster(),
+		logger:      logrus.WithField("component", "store.kv.bolt"),
 	}, nil
 }
@@ -0,0 +1,70 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bolt_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv/bolt"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv/kvtest"
+)
+
+func TestTransaction(t *testing.T) {
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.Nil(t, err)
+	defer s.Close()
+
+	kvtest.TestTransaction(t, s)
+}
+
+func TestWatch(t *testing.T) {
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.Nil(t, err)
+	defer s.Close()
+
+	kvtest.TestWatch(t, s)
+}
+
+func TestWatchOrder(t *testing.T) {
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.Nil(t, err)
+	defer s.Close()
+
+	events, stop := s.Watch([]byte("peer."))
+	defer stop()
+
+	// concurrent writers
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			require.Nil(t, s.Create([]byte(fmt.Sprintf("peer.%d", i)), []byte("1")))
+		}(i)
+	}
+	wg.Wait()
+
+	// events are received in revision order
+	var revision uint64
+	for i := 0; i < 50; i++ {
+		event := kvtest.NextEvent(t, events)
+		require.Greater(t, event.Revision, revision)
+		revision = event.Revision
+	}
+}
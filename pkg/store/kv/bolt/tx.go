@@ -0,0 +1,146 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bolt
+
+import (
+	"bytes"
+
+	"github.com/sirupsen/logrus"
+	"go.etcd.io/bbolt"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+)
+
+// txStore implements a store operating within a bolt write transaction.
+// Changes are recorded as events, to be sent to watchers once the transaction is committed.
+type txStore struct {
+	bucket   *bbolt.Bucket
+	revision uint64
+	events   []kv.Event
+
+	// logger is set for explicit transactions, as single changes are logged by the store.
+	logger *logrus.Entry
+}
+
+// debugf logs a change made within an explicit transaction.
+func (s *txStore) debugf(format string, args ...any) {
+	if s.logger != nil {
+		s.logger.Debugf(format, args...)
+	}
+}
+
+// record a change made by the transaction.
+func (s *txStore) record(eventType kv.EventType, key, value []byte) {
+	s.events = append(s.events, kv.Event{
+		Type:     eventType,
+		Key:      append([]byte{}, key...),
+		Value:    append([]byte{}, value...),
+		Revision: s.revision,
+	})
+}
+
+// Create a (key, value) in the store.
+func (s *txStore) Create(key, value []byte) error {
+	s.debugf("Creating key in transaction: %v.", key)
+
+	if s.bucket.Get(key) != nil {
+		return &kv.KeyExistsError{}
+	}
+
+	if err := s.bucket.Put(key, value); err != nil {
+		return err
+	}
+
+	s.record(kv.EventCreate, key, value)
+	return nil
+}
+
+// Update a (key, value) in the store.
+func (s *txStore) Update(key []byte, mutator func([]byte) ([]byte, error)) error {
+	s.debugf("Updating key in transaction: %v.", key)
+
+	value := s.bucket.Get(key)
+	if value == nil {
+		return &kv.KeyNotFoundError{}
+	}
+
+	updated, err := mutator(value)
+	if err != nil {
+		return err
+	}
+
+	if err := s.bucket.Put(key, updated); err != nil {
+		return err
+	}
+
+	s.record(kv.EventUpdate, key, updated)
+	return nil
+}
+
+// Delete a key (with its respective value) from the store.
+func (s *txStore) Delete(key []byte) error {
+	s.debugf("Deleting key in transaction: %v.", key)
+
+	value := s.bucket.Get(key)
+	if value == nil {
+		return nil
+	}
+
+	// copy the value, as it is only valid while the transaction is open
+	value = append([]byte{}, value...)
+	if err := s.bucket.Delete(key); err != nil {
+		return err
+	}
+
+	s.record(kv.EventDelete, key, value)
+	return nil
+}
+
+// Range calls f sequentially for each (key, value) where key starts with the given prefix.
+func (s *txStore) Range(prefix []byte, f func(key, value []byte) error) error {
+	c := s.bucket.Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		if err := f(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Transaction calls f with the store, as nested transactions are part of the enclosing transaction.
+func (s *txStore) Transaction(f func(kv.Store) error) error {
+	return f(s)
+}
+
+// Recover is a no-op, as the enclosing transaction is not committed yet.
+func (s *txStore) Recover() error {
+	return nil
+}
+
+// Watch is not supported within a transaction, so it returns a closed channel.
+func (s *txStore) Watch([]byte) (<-chan kv.Event, func()) {
+	events := make(chan kv.Event)
+	close(events)
+	return events, func() {}
+}
+
+// Revision returns the revision of the transaction.
+func (s *txStore) Revision() (uint64, error) {
+	return s.revision, nil
+}
+
+// Close is a no-op, as the transaction is closed by the store which created it.
+func (s *txStore) Close() error {
+	return nil
+}
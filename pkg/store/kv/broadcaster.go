@@ -0,0 +1,101 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"bytes"
+	"sync"
+)
+
+// watchBufferSize is the number of events buffered for a watcher, before it is considered to fall behind.
+const watchBufferSize = 1024
+
+// watcher of changes to keys with a specific prefix.
+type watcher struct {
+	prefix []byte
+	events chan Event
+}
+
+// Broadcaster distributes store events to watchers.
+type Broadcaster struct {
+	lock     sync.Mutex
+	watchers map[*watcher]struct{}
+}
+
+// Watch returns a channel of events for keys starting with the given prefix, and a function to stop watching.
+func (b *Broadcaster) Watch(prefix []byte) (<-chan Event, func()) {
+	w := &watcher{
+		prefix: prefix,
+		events: make(chan Event, watchBufferSize),
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.watchers[w] = struct{}{}
+	return w.events, func() {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		b.remove(w)
+	}
+}
+
+// Notify all relevant watchers of the given events.
+// A watcher whose buffer is full is removed, closing its channel.
+func (b *Broadcaster) Notify(events ...Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for w := range b.watchers {
+		for _, event := range events {
+			if !bytes.HasPrefix(event.Key, w.prefix) {
+				continue
+			}
+
+			select {
+			case w.events <- event:
+			default:
+				b.remove(w)
+			}
+
+			if _, ok := b.watchers[w]; !ok {
+				break
+			}
+		}
+	}
+}
+
+// Reset removes all watchers, closing their channels.
+// It is used when events may have been missed, so watchers must re-read the store.
+func (b *Broadcaster) Reset() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for w := range b.watchers {
+		b.remove(w)
+	}
+}
+
+// remove a watcher, closing its channel. The lock must be held by the caller.
+func (b *Broadcaster) remove(w *watcher) {
+	if _, ok := b.watchers[w]; ok {
+		delete(b.watchers, w)
+		close(w.events)
+	}
+}
+
+// NewBroadcaster returns a new broadcaster of store events.
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{watchers: make(map[*watcher]struct{})}
+}
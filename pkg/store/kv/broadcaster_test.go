@@ -0,0 +1,74 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+)
+
+func TestBroadcaster(t *testing.T) {
+	b := kv.NewBroadcaster()
+
+	peers, stopPeers := b.Watch([]byte("peer."))
+	all, stopAll := b.Watch(nil)
+
+	b.Notify(
+		kv.Event{Type: kv.EventCreate, Key: []byte("peer.a"), Revision: 1},
+		kv.Event{Type: kv.EventCreate, Key: []byte("export.a"), Revision: 1},
+	)
+	b.Notify(kv.Event{Type: kv.EventDelete, Key: []byte("peer.a"), Revision: 2})
+
+	// events are filtered by prefix, and kept in order
+	require.Equal(t, []string{"peer.a", "peer.a"}, []string{string((<-peers).Key), string((<-peers).Key)})
+	require.Equal(t, []uint64{1, 1, 2}, []uint64{(<-all).Revision, (<-all).Revision, (<-all).Revision})
+
+	// stopping closes the channel, and stops notifications
+	stopPeers()
+	_, ok := <-peers
+	require.False(t, ok)
+	stopPeers()
+
+	b.Notify(kv.Event{Type: kv.EventCreate, Key: []byte("peer.b"), Revision: 3})
+	require.Equal(t, uint64(3), (<-all).Revision)
+	stopAll()
+}
+
+func TestBroadcasterSlowWatcher(t *testing.T) {
+	b := kv.NewBroadcaster()
+
+	slow, stopSlow := b.Watch(nil)
+	defer stopSlow()
+
+	// a watcher which falls behind is removed, after receiving all buffered events
+	for revision := 1; revision <= cap(slow)+1; revision++ {
+		b.Notify(kv.Event{Type: kv.EventCreate, Key: []byte("peer.a"), Revision: uint64(revision)})
+	}
+
+	received := 0
+	for range slow {
+		received++
+	}
+	require.Equal(t, cap(slow), received)
+
+	// reset closes all channels
+	events, stop := b.Watch(nil)
+	defer stop()
+	b.Reset()
+	_, ok := <-events
+	require.False(t, ok)
+}
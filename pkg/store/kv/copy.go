@@ -0,0 +1,41 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"errors"
+)
+
+// Copy all (key, value) pairs from src to dst, overwriting existing keys in dst.
+// Returns the number of copied keys.
+func Copy(dst, src Store) (int, error) {
+	count := 0
+	err := src.Range(nil, func(key, value []byte) error {
+		err := dst.Create(key, value)
+		var keyExistsError *KeyExistsError
+		if errors.As(err, &keyExistsError) {
+			err = dst.Update(key, func([]byte) ([]byte, error) {
+				return value, nil
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		count++
+		return nil
+	})
+
+	return count, err
+}
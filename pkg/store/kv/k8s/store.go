@@ -0,0 +1,235 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+)
+
+const (
+	// StoreLabel is the label set on all ConfigMaps of a store, holding the store name.
+	StoreLabel = "clusterlink.net/store"
+
+	// keyField is the ConfigMap data field holding the key.
+	keyField = "key"
+	// valueField is the ConfigMap binary data field holding the value.
+	valueField = "value"
+
+	// requestTimeout is the timeout for a single k8s API request.
+	requestTimeout = 30 * time.Second
+)
+
+// Store implements a store backed by k8s ConfigMaps, one ConfigMap per key.
+// ConfigMap names are derived from a hash of the key, as keys are not necessarily valid k8s object names.
+// Updates use the ConfigMap resourceVersion for optimistic concurrency, retrying on conflicts.
+// Changes are watched using the k8s watch API, so watchers are notified of changes made by other replicas.
+type Store struct {
+	client    client.WithWatch
+	namespace string
+	name      string
+
+	// txLock serializes transactions (and their recovery) within the process.
+	txLock sync.Mutex
+
+	broadcaster *kv.Broadcaster
+	watchOnce   sync.Once
+	// revision counts the watched changes. It starts at the creation time of the store,
+	// so that the revisions of a restarted store are newer than the revisions of a previous one.
+	revision atomic.Uint64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	logger *logrus.Entry
+}
+
+// objectName returns the name of the ConfigMap holding the given key.
+func (s *Store) objectName(key []byte) string {
+	hash := sha256.Sum256(key)
+	return s.name + "-" + hex.EncodeToString(hash[:16])
+}
+
+// get returns the ConfigMap holding the given key, or nil if the key does not exist.
+func (s *Store) get(ctx context.Context, key []byte) (*corev1.ConfigMap, error) {
+	var configMap corev1.ConfigMap
+	err := s.client.Get(ctx, types.NamespacedName{Namespace: s.namespace, Name: s.objectName(key)}, &configMap)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if configMap.Data[keyField] != string(key) {
+		return nil, fmt.Errorf("ConfigMap '%s' holds a different key", configMap.Name)
+	}
+
+	return &configMap, nil
+}
+
+// Create a (key, value) in the store.
+func (s *Store) Create(key, value []byte) error {
+	s.logger.Debugf("Creating key: %v.", key)
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.objectName(key),
+			Namespace: s.namespace,
+			Labels:    map[string]string{StoreLabel: s.name},
+		},
+		Data:       map[string]string{keyField: string(key)},
+		BinaryData: map[string][]byte{valueField: value},
+	}
+
+	if err := s.client.Create(ctx, configMap); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return &kv.KeyExistsError{}
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Update a (key, value) in the store.
+func (s *Store) Update(key []byte, mutator func([]byte) ([]byte, error)) error {
+	s.logger.Debugf("Updating key: %v.", key)
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := s.get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if configMap == nil {
+			return &kv.KeyNotFoundError{}
+		}
+
+		// update value
+		updated, err := mutator(configMap.BinaryData[valueField])
+		if err != nil {
+			return err
+		}
+
+		if configMap.BinaryData == nil {
+			configMap.BinaryData = make(map[string][]byte)
+		}
+		configMap.BinaryData[valueField] = updated
+		return s.client.Update(ctx, configMap)
+	})
+}
+
+// Delete a key (with its respective value) from the store.
+func (s *Store) Delete(key []byte) error {
+	s.logger.Debugf("Deleting key: %v.", key)
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.objectName(key),
+			Namespace: s.namespace,
+		},
+	}
+
+	return client.IgnoreNotFound(s.client.Delete(ctx, configMap))
+}
+
+// Range calls f sequentially for each (key, value) where key starts with the given prefix.
+func (s *Store) Range(prefix []byte, f func(key, value []byte) error) error {
+	s.logger.Infof("Iterating over all items with key prefix '%s'.", prefix)
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	var configMaps corev1.ConfigMapList
+	err := s.client.List(ctx, &configMaps, client.InNamespace(s.namespace), client.MatchingLabels{StoreLabel: s.name})
+	if err != nil {
+		return err
+	}
+
+	// iterate in key order, similar to other stores
+	sort.Slice(configMaps.Items, func(i, j int) bool {
+		return configMaps.Items[i].Data[keyField] < configMaps.Items[j].Data[keyField]
+	})
+
+	for i := range configMaps.Items {
+		key := []byte(configMaps.Items[i].Data[keyField])
+		if !bytes.HasPrefix(key, prefix) {
+			continue
+		}
+
+		s.logger.Debugf("Read key from store: %v.", key)
+
+		if err := f(key, configMaps.Items[i].BinaryData[valueField]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close frees all resources (e.g. file handles, network sockets) used by the store.
+func (s *Store) Close() error {
+	s.logger.Info("Closing store.")
+	s.cancel()
+	s.broadcaster.Reset()
+	return nil
+}
+
+// NewStore returns a store persisted in ConfigMaps in the given namespace.
+// The name identifies the store, and prefixes the names of its ConfigMaps.
+func NewStore(c client.WithWatch, namespace, name string) *Store {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Store{
+		client:      c,
+		namespace:   namespace,
+		name:        name,
+		broadcaster: kv.NewBroadcaster(),
+		ctx:         ctx,
+		cancel:      cancel,
+		logger: logrus.WithFields(logrus.Fields{
+			"component": "store.kv.k8s",
+			"namespace": namespace,
+			"name":      name,
+		}),
+	}
+
+	s.revision.Store(uint64(time.Now().UnixNano()))
+	return s
+}
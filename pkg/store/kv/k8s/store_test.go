@@ -0,0 +1,203 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv/bolt"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv/k8s"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv/kvtest"
+)
+
+func newStore(t *testing.T) *k8s.Store {
+	t.Helper()
+
+	s, _ := newStoreWithClient(t)
+	return s
+}
+
+func newStoreWithClient(t *testing.T) (*k8s.Store, client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.Nil(t, corev1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	return k8s.NewStore(c, "default", "test-store"), c
+}
+
+func TestStore(t *testing.T) {
+	s := newStore(t)
+
+	// create
+	require.Nil(t, s.Create([]byte("peer.Peer_1"), []byte("v1")))
+	require.Nil(t, s.Create([]byte("peer.peer0"), []byte("v0")))
+	require.Nil(t, s.Create([]byte("export.svc"), []byte("e")))
+
+	err := s.Create([]byte("peer.Peer_1"), []byte("v2"))
+	require.IsType(t, &kv.KeyExistsError{}, err)
+
+	// range
+	require.Equal(t, [][2]string{{"peer.Peer_1", "v1"}, {"peer.peer0", "v0"}}, kvtest.RangeAll(t, s, "peer."))
+	require.Len(t, kvtest.RangeAll(t, s, ""), 3)
+
+	// update
+	require.Nil(t, s.Update([]byte("peer.Peer_1"), func(value []byte) ([]byte, error) {
+		return append(value, '+'), nil
+	}))
+	require.Equal(t, [][2]string{{"peer.Peer_1", "v1+"}, {"peer.peer0", "v0"}}, kvtest.RangeAll(t, s, "peer."))
+
+	err = s.Update([]byte("peer.missing"), func(value []byte) ([]byte, error) {
+		return value, nil
+	})
+	require.IsType(t, &kv.KeyNotFoundError{}, err)
+
+	// delete
+	require.Nil(t, s.Delete([]byte("peer.Peer_1")))
+	require.Nil(t, s.Delete([]byte("peer.Peer_1")))
+	require.Equal(t, [][2]string{{"peer.peer0", "v0"}}, kvtest.RangeAll(t, s, "peer."))
+
+	require.Nil(t, s.Close())
+}
+
+func TestCopyFromBolt(t *testing.T) {
+	src, err := bolt.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.Nil(t, err)
+	defer src.Close()
+
+	require.Nil(t, src.Create([]byte("peer.a"), []byte("1")))
+	require.Nil(t, src.Create([]byte("peer.b"), []byte("2")))
+
+	dst := newStore(t)
+	require.Nil(t, dst.Create([]byte("peer.b"), []byte("old")))
+
+	count, err := kv.Copy(dst, src)
+	require.Nil(t, err)
+	require.Equal(t, 2, count)
+	require.Equal(t, [][2]string{{"peer.a", "1"}, {"peer.b", "2"}}, kvtest.RangeAll(t, dst, ""))
+}
+
+func TestTransaction(t *testing.T) {
+	kvtest.TestTransaction(t, newStore(t))
+}
+
+func TestConcurrentTransactions(t *testing.T) {
+	s := newStore(t)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.Transaction(func(tx kv.Store) error {
+				return tx.Create([]byte(fmt.Sprintf("peer.%d", i)), []byte("1"))
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.Nil(t, err)
+	}
+	require.Len(t, kvtest.RangeAll(t, s, "peer."), len(errs))
+}
+
+func TestUnappliedTransaction(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.Nil(t, corev1.AddToScheme(scheme))
+
+	// fail creating keys, but not the transaction journal
+	var fail atomic.Bool
+	c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if fail.Load() && obj.GetName() != "test-store-journal" {
+				return errors.New("failed")
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+	s := k8s.NewStore(c, "default", "test-store")
+
+	// a committed transaction succeeds, even if its changes are not applied yet
+	fail.Store(true)
+	require.Nil(t, s.Transaction(func(tx kv.Store) error {
+		return tx.Create([]byte("peer.a"), []byte("1"))
+	}))
+	require.Empty(t, kvtest.RangeAll(t, s, ""))
+
+	// changes are applied on recovery
+	fail.Store(false)
+	require.Nil(t, s.Recover())
+	require.Equal(t, [][2]string{{"peer.a", "1"}}, kvtest.RangeAll(t, s, ""))
+}
+
+func TestRecover(t *testing.T) {
+	s, c := newStoreWithClient(t)
+	require.Nil(t, s.Create([]byte("peer.a"), []byte("1")))
+	require.Nil(t, s.Create([]byte("peer.b"), []byte("2")))
+
+	// nothing to recover
+	require.Nil(t, s.Recover())
+
+	// simulate a transaction interrupted after being committed
+	journal, err := json.Marshal([]map[string]any{
+		{"Key": []byte("peer.a"), "Delete": true},
+		{"Key": []byte("peer.b"), "Value": []byte("2+")},
+		{"Key": []byte("peer.c"), "Value": []byte("3")},
+	})
+	require.Nil(t, err)
+	require.Nil(t, c.Create(context.Background(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-store-journal", Namespace: "default"},
+		BinaryData: map[string][]byte{"journal": journal},
+	}))
+
+	// journal is not part of the store
+	require.Equal(t, [][2]string{{"peer.a", "1"}, {"peer.b", "2"}}, kvtest.RangeAll(t, s, ""))
+
+	// a transaction first completes the interrupted transaction
+	require.Nil(t, s.Transaction(func(tx kv.Store) error {
+		require.Equal(t, [][2]string{{"peer.b", "2+"}, {"peer.c", "3"}}, kvtest.RangeAll(t, tx, ""))
+		return nil
+	}))
+	require.Equal(t, [][2]string{{"peer.b", "2+"}, {"peer.c", "3"}}, kvtest.RangeAll(t, s, ""))
+
+	// recovering again is a no-op
+	require.Nil(t, s.Recover())
+	require.Equal(t, [][2]string{{"peer.b", "2+"}, {"peer.c", "3"}}, kvtest.RangeAll(t, s, ""))
+}
+
+func TestWatch(t *testing.T) {
+	s := newStore(t)
+	defer s.Close()
+
+	kvtest.TestWatch(t, s)
+}
@@ -0,0 +1,309 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+)
+
+// journalField is the journal ConfigMap binary data field holding the journaled changes.
+const journalField = "journal"
+
+// journalEntry is a single change of a committed transaction.
+type journalEntry struct {
+	Key    []byte
+	Value  []byte
+	Delete bool
+}
+
+// journalName returns the name of the ConfigMap holding the journal of a committed transaction.
+// The journal ConfigMap is not labeled as part of the store, so it is ignored by Range.
+func (s *Store) journalName() string {
+	return s.name + "-journal"
+}
+
+// Transaction calls f with a store whose changes are persisted atomically when f returns nil,
+// and discarded if f returns an error.
+// Changes are buffered until f returns, and then written to a journal before being applied.
+// If applying the changes fails (or is interrupted), the transaction is still committed, and its changes are
+// re-applied by the next call to Recover (or Transaction).
+// Transactions of the store are serialized, but are not isolated from concurrent writers outside of transactions,
+// and assume a single writer (e.g. the leader replica).
+func (s *Store) Transaction(f func(kv.Store) error) error {
+	s.logger.Debug("Starting transaction.")
+
+	s.txLock.Lock()
+	defer s.txLock.Unlock()
+
+	if err := s.recover(); err != nil {
+		return err
+	}
+
+	tx := &txStore{
+		store:   s,
+		changes: make(map[string]*journalEntry),
+	}
+	if err := f(tx); err != nil {
+		return err
+	}
+
+	if len(tx.changes) == 0 {
+		return nil
+	}
+
+	// write changes in key order, for deterministic journals
+	journal := make([]*journalEntry, 0, len(tx.changes))
+	for _, entry := range tx.changes {
+		journal = append(journal, entry)
+	}
+	sort.Slice(journal, func(i, j int) bool { return bytes.Compare(journal[i].Key, journal[j].Key) < 0 })
+
+	encoded, err := json.Marshal(journal)
+	if err != nil {
+		return fmt.Errorf("unable to serialize journal: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	// commit
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.journalName(),
+			Namespace: s.namespace,
+		},
+		BinaryData: map[string][]byte{journalField: encoded},
+	}
+	if err := s.client.Create(ctx, configMap); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("another transaction is in progress")
+		}
+		return fmt.Errorf("unable to commit transaction: %w", err)
+	}
+
+	if err := s.apply(ctx, journal); err != nil {
+		s.logger.Warnf("Transaction committed, but not applied (to be completed by recovery): %v.", err)
+	}
+
+	return nil
+}
+
+// Recover completes a transaction which was interrupted after being committed.
+func (s *Store) Recover() error {
+	s.txLock.Lock()
+	defer s.txLock.Unlock()
+
+	return s.recover()
+}
+
+// recover completes a committed transaction, assuming no other transaction is running.
+func (s *Store) recover() error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	var configMap corev1.ConfigMap
+	err := s.client.Get(ctx, types.NamespacedName{Namespace: s.namespace, Name: s.journalName()}, &configMap)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("unable to get journal: %w", err)
+	}
+
+	s.logger.Info("Recovering an interrupted transaction.")
+
+	var journal []*journalEntry
+	if err := json.Unmarshal(configMap.BinaryData[journalField], &journal); err != nil {
+		return fmt.Errorf("unable to decode journal: %w", err)
+	}
+
+	return s.apply(ctx, journal)
+}
+
+// apply the changes of a committed transaction, and then delete its journal.
+// Changes are idempotent, so a journal may be applied more than once.
+func (s *Store) apply(ctx context.Context, journal []*journalEntry) error {
+	for _, entry := range journal {
+		if entry.Delete {
+			if err := s.Delete(entry.Key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value := entry.Value
+		err := s.Create(entry.Key, value)
+		var keyExistsError *kv.KeyExistsError
+		if errors.As(err, &keyExistsError) {
+			err = s.Update(entry.Key, func([]byte) ([]byte, error) {
+				return value, nil
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.journalName(),
+			Namespace: s.namespace,
+		},
+	}
+
+	return client.IgnoreNotFound(s.client.Delete(ctx, configMap))
+}
+
+// txStore implements a store whose changes are buffered until the transaction is committed.
+type txStore struct {
+	store   *Store
+	changes map[string]*journalEntry
+}
+
+// get returns the value of a key, as seen by the transaction, or nil if the key does not exist.
+func (s *txStore) get(key []byte) ([]byte, error) {
+	if entry, ok := s.changes[string(key)]; ok {
+		if entry.Delete {
+			return nil, nil
+		}
+		return entry.Value, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	configMap, err := s.store.get(ctx, key)
+	if err != nil || configMap == nil {
+		return nil, err
+	}
+
+	return configMap.BinaryData[valueField], nil
+}
+
+// Create a (key, value) in the store.
+func (s *txStore) Create(key, value []byte) error {
+	existing, err := s.get(key)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return &kv.KeyExistsError{}
+	}
+
+	s.changes[string(key)] = &journalEntry{Key: key, Value: value}
+	return nil
+}
+
+// Update a (key, value) in the store.
+func (s *txStore) Update(key []byte, mutator func([]byte) ([]byte, error)) error {
+	value, err := s.get(key)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return &kv.KeyNotFoundError{}
+	}
+
+	updated, err := mutator(value)
+	if err != nil {
+		return err
+	}
+
+	s.changes[string(key)] = &journalEntry{Key: key, Value: updated}
+	return nil
+}
+
+// Delete a key (with its respective value) from the store.
+func (s *txStore) Delete(key []byte) error {
+	s.changes[string(key)] = &journalEntry{Key: key, Delete: true}
+	return nil
+}
+
+// Range calls f sequentially for each (key, value) where key starts with the given prefix.
+func (s *txStore) Range(prefix []byte, f func(key, value []byte) error) error {
+	values := make(map[string][]byte)
+	err := s.store.Range(prefix, func(key, value []byte) error {
+		values[string(key)] = value
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for key, entry := range s.changes {
+		if !bytes.HasPrefix(entry.Key, prefix) {
+			continue
+		}
+
+		if entry.Delete {
+			delete(values, key)
+		} else {
+			values[key] = entry.Value
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := f([]byte(key), values[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Transaction calls f with the store, as nested transactions are part of the enclosing transaction.
+func (s *txStore) Transaction(f func(kv.Store) error) error {
+	return f(s)
+}
+
+// Recover is a no-op, as the enclosing transaction is not committed yet.
+func (s *txStore) Recover() error {
+	return nil
+}
+
+// Watch is not supported within a transaction, so it returns a closed channel.
+func (s *txStore) Watch([]byte) (<-chan kv.Event, func()) {
+	events := make(chan kv.Event)
+	close(events)
+	return events, func() {}
+}
+
+// Revision returns the current revision of the store, as the transaction is not committed yet.
+func (s *txStore) Revision() (uint64, error) {
+	return s.store.Revision()
+}
+
+// Close is a no-op, as the transaction is closed by the store which created it.
+func (s *txStore) Close() error {
+	return nil
+}
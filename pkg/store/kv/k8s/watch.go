@@ -0,0 +1,165 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+)
+
+// watchRetryInterval is the time to wait before restarting a failed k8s watch.
+const watchRetryInterval = time.Second
+
+// Watch returns a channel of changes to keys starting with the given prefix, and a function to stop watching.
+// Revisions are counted by the store instance, as k8s resource versions are opaque.
+// Only changes received by the watch advance the revision, so a store which is not watched keeps its revision.
+// Following a k8s watch failure, in which events may have been missed, all watch channels are closed.
+func (s *Store) Watch(prefix []byte) (<-chan kv.Event, func()) {
+	events, stop := s.broadcaster.Watch(prefix)
+
+	// the k8s watch is started before returning, so no later change is missed
+	s.watchOnce.Do(func() {
+		resourceVersion, err := s.listResourceVersion()
+		var w watch.Interface
+		if err == nil {
+			w, err = s.startWatch(resourceVersion)
+		}
+		if err != nil {
+			s.logger.Errorf("Cannot watch store: %v.", err)
+		}
+
+		go s.watch(w, resourceVersion)
+	})
+
+	return events, stop
+}
+
+// Revision returns the current revision of the store, which is the revision of the latest watched change.
+func (s *Store) Revision() (uint64, error) {
+	return s.revision.Load(), nil
+}
+
+// listResourceVersion returns the current resource version of the store ConfigMaps.
+func (s *Store) listResourceVersion() (string, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, requestTimeout)
+	defer cancel()
+
+	var configMaps corev1.ConfigMapList
+	err := s.client.List(ctx, &configMaps, client.InNamespace(s.namespace), client.MatchingLabels{StoreLabel: s.name})
+	if err != nil {
+		return "", err
+	}
+
+	return configMaps.ResourceVersion, nil
+}
+
+// startWatch starts a k8s watch of the store ConfigMaps, starting at the given resource version.
+func (s *Store) startWatch(resourceVersion string) (watch.Interface, error) {
+	return s.client.Watch(s.ctx, &corev1.ConfigMapList{}, &client.ListOptions{
+		Namespace:     s.namespace,
+		LabelSelector: labels.SelectorFromSet(labels.Set{StoreLabel: s.name}),
+		Raw:           &metav1.ListOptions{ResourceVersion: resourceVersion, AllowWatchBookmarks: true},
+	})
+}
+
+// watch the store ConfigMaps until the store is closed, starting with the given k8s watch (if not nil).
+// The watch is restarted from the last received resource version.
+func (s *Store) watch(w watch.Interface, resourceVersion string) {
+	for s.ctx.Err() == nil {
+		var err error
+		if w == nil {
+			w, err = s.startWatch(resourceVersion)
+		}
+		if err == nil {
+			resourceVersion, err = s.handleWatch(w, resourceVersion)
+			w = nil
+		}
+		if err == nil || s.ctx.Err() != nil {
+			continue
+		}
+
+		s.logger.Warnf("Watch failed: %v.", err)
+		if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) || resourceVersion == "" {
+			// events were missed, so watchers must re-read the store
+			s.broadcaster.Reset()
+			if resourceVersion, err = s.listResourceVersion(); err != nil {
+				s.logger.Errorf("Cannot list store: %v.", err)
+			}
+		}
+
+		select {
+		case <-s.ctx.Done():
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
+
+// handleWatch sends watchers the events of a k8s watch, until it ends.
+// Returns the resource version of the last received event.
+func (s *Store) handleWatch(w watch.Interface, resourceVersion string) (string, error) {
+	defer w.Stop()
+
+	for event := range w.ResultChan() {
+		if event.Type == watch.Error {
+			if status, ok := event.Object.(*metav1.Status); ok {
+				return resourceVersion, apierrors.FromObject(status)
+			}
+			return resourceVersion, fmt.Errorf("unexpected watch error object %T", event.Object)
+		}
+
+		configMap, ok := event.Object.(*corev1.ConfigMap)
+		if !ok {
+			return resourceVersion, fmt.Errorf("unexpected watch object %T", event.Object)
+		}
+		resourceVersion = configMap.ResourceVersion
+
+		var eventType kv.EventType
+		switch event.Type {
+		case watch.Added:
+			eventType = kv.EventCreate
+		case watch.Modified:
+			eventType = kv.EventUpdate
+		case watch.Deleted:
+			eventType = kv.EventDelete
+		default:
+			// bookmarks only advance the resource version
+			continue
+		}
+
+		// ignore ConfigMaps which are not part of the store
+		key, ok := configMap.Data[keyField]
+		if !ok || configMap.Labels[StoreLabel] != s.name {
+			continue
+		}
+
+		s.broadcaster.Notify(kv.Event{
+			Type:     eventType,
+			Key:      []byte(key),
+			Value:    configMap.BinaryData[valueField],
+			Revision: s.revision.Add(1),
+		})
+	}
+
+	return resourceVersion, nil
+}
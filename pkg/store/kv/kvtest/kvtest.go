@@ -0,0 +1,126 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kvtest implements tests shared by the KV-store implementations.
+package kvtest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+)
+
+// RangeAll returns all (key, value) pairs with the given prefix, in iteration order.
+func RangeAll(t *testing.T, s kv.Store, prefix string) [][2]string {
+	t.Helper()
+
+	var items [][2]string
+	require.Nil(t, s.Range([]byte(prefix), func(key, value []byte) error {
+		items = append(items, [2]string{string(key), string(value)})
+		return nil
+	}))
+	return items
+}
+
+// TestTransaction tests committing and rolling back transactions of a store.
+func TestTransaction(t *testing.T, s kv.Store) {
+	t.Helper()
+
+	require.Nil(t, s.Create([]byte("peer.a"), []byte("1")))
+	require.Nil(t, s.Create([]byte("peer.b"), []byte("2")))
+
+	// rollback
+	err := s.Transaction(func(tx kv.Store) error {
+		require.Nil(t, tx.Create([]byte("peer.c"), []byte("3")))
+		require.Nil(t, tx.Delete([]byte("peer.a")))
+		return errors.New("rollback")
+	})
+	require.NotNil(t, err)
+	require.Equal(t, [][2]string{{"peer.a", "1"}, {"peer.b", "2"}}, RangeAll(t, s, ""))
+
+	// commit
+	require.Nil(t, s.Transaction(func(tx kv.Store) error {
+		require.Nil(t, tx.Create([]byte("peer.c"), []byte("3")))
+		require.IsType(t, &kv.KeyExistsError{}, tx.Create([]byte("peer.b"), []byte("x")))
+		require.Nil(t, tx.Update([]byte("peer.b"), func(value []byte) ([]byte, error) {
+			return append(value, '+'), nil
+		}))
+		require.Nil(t, tx.Delete([]byte("peer.a")))
+		require.IsType(t, &kv.KeyNotFoundError{}, tx.Update([]byte("peer.a"), func(value []byte) ([]byte, error) {
+			return value, nil
+		}))
+
+		// changes are visible within the transaction
+		require.Equal(t, [][2]string{{"peer.b", "2+"}, {"peer.c", "3"}}, RangeAll(t, tx, "peer."))
+		return nil
+	}))
+	require.Equal(t, [][2]string{{"peer.b", "2+"}, {"peer.c", "3"}}, RangeAll(t, s, ""))
+}
+
+// NextEvent returns the next watched event.
+func NextEvent(t *testing.T, events <-chan kv.Event) kv.Event {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		require.True(t, ok)
+		return event
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout waiting for event")
+		return kv.Event{}
+	}
+}
+
+// TestWatch tests watching the changes of a store, which must hold no keys other than the ones it creates.
+func TestWatch(t *testing.T, s kv.Store) {
+	t.Helper()
+
+	require.Nil(t, s.Create([]byte("peer.a"), []byte("0")))
+
+	events, stop := s.Watch([]byte("peer."))
+
+	require.Nil(t, s.Create([]byte("export.a"), []byte("e")))
+	require.Nil(t, s.Create([]byte("peer.b"), []byte("1")))
+	require.Nil(t, s.Update([]byte("peer.a"), func([]byte) ([]byte, error) {
+		return []byte("2"), nil
+	}))
+	require.Nil(t, s.Delete([]byte("peer.b")))
+
+	var revision uint64
+	for _, expected := range []kv.Event{
+		{Type: kv.EventCreate, Key: []byte("peer.b"), Value: []byte("1")},
+		{Type: kv.EventUpdate, Key: []byte("peer.a"), Value: []byte("2")},
+		{Type: kv.EventDelete, Key: []byte("peer.b"), Value: []byte("1")},
+	} {
+		event := NextEvent(t, events)
+		require.Greater(t, event.Revision, revision)
+		revision = event.Revision
+
+		expected.Revision = event.Revision
+		require.Equal(t, expected, event)
+	}
+
+	// the store revision is the revision of the latest change
+	current, err := s.Revision()
+	require.Nil(t, err)
+	require.Equal(t, revision, current)
+
+	// stopping closes the channel
+	stop()
+	_, ok := <-events
+	require.False(t, ok)
+}
@@ -25,6 +25,19 @@ func (m *Manager) GetObjectStore(name string, sampleObject any) store.ObjectStor
 	return NewObjectStore(name, m.store, sampleObject)
 }
 
+// Transaction calls f with a manager whose object stores are changed atomically.
+// Changes are persisted when f returns nil, and discarded if f returns an error.
+func (m *Manager) Transaction(f func(store.Manager) error) error {
+	return m.store.Transaction(func(tx Store) error {
+		return f(NewManager(tx))
+	})
+}
+
+// Recover completes a transaction which was interrupted after being committed.
+func (m *Manager) Recover() error {
+	return m.store.Recover()
+}
+
 // NewManager returns a new manager.
 func NewManager(s Store) *Manager {
 	return &Manager{store: s}
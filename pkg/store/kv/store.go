@@ -14,10 +14,13 @@
 package kv
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 
@@ -41,9 +44,14 @@ func (s *ObjectStore) kvKey(name string) []byte {
 }
 
 // Create an object.
+// The resource version of a versioned object is set to 1.
 func (s *ObjectStore) Create(name string, value any) error {
 	s.logger.Infof("Creating: '%s'.", name)
 
+	if versioned, ok := value.(store.VersionedObject); ok {
+		versioned.SetResourceVersion(1)
+	}
+
 	// serialize
 	encoded, err := json.Marshal(value)
 	if err != nil {
@@ -63,9 +71,15 @@ func (s *ObjectStore) Create(name string, value any) error {
 }
 
 // Update an object.
+// The resource version of a versioned object is incremented. If the mutator returns a new versioned object
+// with a non-zero resource version, it must match the stored resource version, or ObjectConflictError is returned.
 func (s *ObjectStore) Update(name string, mutator func(any) any) error {
 	s.logger.Infof("Updating: '%s'.", name)
 
+	// the expected version is taken once, as the store may call the mutator again on retries
+	var expectedVersion uint64
+	expectedVersionSet := false
+
 	// persist to store
 	err := s.store.Update(s.kvKey(name), func(value []byte) ([]byte, error) {
 		// de-serialize old value
@@ -74,8 +88,27 @@ func (s *ObjectStore) Update(name string, mutator func(any) any) error {
 			return nil, fmt.Errorf("unable to decode value for object '%s': %w", name, err)
 		}
 
+		var currentVersion uint64
+		if versioned, ok := decoded.(store.VersionedObject); ok {
+			currentVersion = versioned.GetResourceVersion()
+		}
+
+		mutated := mutator(decoded)
+		if versioned, ok := mutated.(store.VersionedObject); ok {
+			if !expectedVersionSet && mutated != decoded {
+				expectedVersion = versioned.GetResourceVersion()
+				expectedVersionSet = true
+			}
+
+			if expectedVersion != 0 && expectedVersion != currentVersion {
+				return nil, &store.ObjectConflictError{}
+			}
+
+			versioned.SetResourceVersion(currentVersion + 1)
+		}
+
 		// serialize mutated value
-		encoded, err := json.Marshal(mutator(decoded))
+		encoded, err := json.Marshal(mutated)
 		if err != nil {
 			return nil, fmt.Errorf("unable to serialize mutated object '%s': %w", name, err)
 		}
@@ -99,6 +132,45 @@ func (s *ObjectStore) Delete(name string) error {
 	return s.store.Delete(s.kvKey(name))
 }
 
+// DeleteIfMatch deletes an object identified by the given name, if its resource version matches the given
+// resource version. The resource version is checked and the object is deleted in a single transaction.
+func (s *ObjectStore) DeleteIfMatch(name string, version uint64) error {
+	if version == 0 {
+		return s.Delete(name)
+	}
+
+	s.logger.Infof("Deleting: '%s' (resource version %d).", name, version)
+
+	key := s.kvKey(name)
+	return s.store.Transaction(func(tx Store) error {
+		var value []byte
+		err := tx.Range(key, func(k, v []byte) error {
+			if bytes.Equal(k, key) {
+				value = v
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if value == nil {
+			return nil
+		}
+
+		decoded := reflect.New(s.objectType).Interface()
+		if err := json.Unmarshal(value, decoded); err != nil {
+			return fmt.Errorf("unable to decode value for object '%s': %w", name, err)
+		}
+
+		if versioned, ok := decoded.(store.VersionedObject); ok && versioned.GetResourceVersion() != version {
+			return &store.ObjectConflictError{}
+		}
+
+		return tx.Delete(key)
+	})
+}
+
 // GetAll returns all of the objects in the store.
 func (s *ObjectStore) GetAll() ([]any, error) {
 	s.logger.Info("Getting all objects.")
@@ -122,6 +194,114 @@ func (s *ObjectStore) GetAll() ([]any, error) {
 	return objects, nil
 }
 
+// Migrate updates the stored objects in place.
+// All objects are migrated in a single transaction, so a failed migration leaves the store unchanged.
+func (s *ObjectStore) Migrate(migrator func(name string, object map[string]any) (bool, error)) error {
+	s.logger.Info("Migrating objects.")
+
+	return s.store.Transaction(func(tx Store) error {
+		// find objects which require migration, as the store cannot be updated while ranging over it
+		var names []string
+		err := tx.Range([]byte(s.keyPrefix), func(key, value []byte) error {
+			name := strings.TrimPrefix(string(key), s.keyPrefix)
+			_, changed, err := migrateValue(name, value, migrator)
+			if changed {
+				names = append(names, name)
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			s.logger.Infof("Migrating: '%s'.", name)
+
+			err := tx.Update(s.kvKey(name), func(value []byte) ([]byte, error) {
+				migrated, _, err := migrateValue(name, value, migrator)
+				return migrated, err
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Watch returns a channel of changes to objects whose name starts with the given prefix,
+// and a function to stop watching.
+func (s *ObjectStore) Watch(prefix string) (<-chan store.WatchEvent, func()) {
+	s.logger.Infof("Watching objects with prefix '%s'.", prefix)
+
+	events, stopWatch := s.store.Watch(s.kvKey(prefix))
+	objectEvents := make(chan store.WatchEvent)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(objectEvents)
+
+		for event := range events {
+			decoded := reflect.New(s.objectType).Interface()
+			if err := json.Unmarshal(event.Value, decoded); err != nil {
+				s.logger.Errorf("Unable to decode watched object for key %v: %v.", event.Key, err)
+				continue
+			}
+
+			objectEvent := store.WatchEvent{
+				Type:     store.EventType(event.Type),
+				Name:     strings.TrimPrefix(string(event.Key), s.keyPrefix),
+				Object:   decoded,
+				Revision: event.Revision,
+			}
+
+			select {
+			case objectEvents <- objectEvent:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return objectEvents, func() {
+		once.Do(func() {
+			close(done)
+			stopWatch()
+		})
+	}
+}
+
+// Revision returns the current revision of the store, which is the revision of its latest change.
+func (s *ObjectStore) Revision() (uint64, error) {
+	return s.store.Revision()
+}
+
+// migrateValue applies a migrator to a serialized object, returning the serialized migrated object.
+func migrateValue(name string, value []byte, migrator func(string, map[string]any) (bool, error)) ([]byte, bool, error) {
+	// de-serialize to a generic object, preserving numbers as is
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, false, fmt.Errorf("unable to decode value for object '%s': %w", name, err)
+	}
+
+	changed, err := migrator(name, object)
+	if err != nil || !changed {
+		return value, false, err
+	}
+
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to serialize migrated object '%s': %w", name, err)
+	}
+
+	return encoded, true, nil
+}
+
 // NewObjectStore returns a new object store backed by a KV-store.
 func NewObjectStore(name string, s Store, sampleObject any) *ObjectStore {
 	logger := logrus.WithFields(logrus.Fields{
@@ -25,10 +25,45 @@ type Store interface {
 	Delete(key []byte) error
 	// Range calls f sequentially for each (key, value) where key starts with the given prefix.
 	Range(prefix []byte, f func(key, value []byte) error) error
+	// Transaction calls f with a store whose changes are persisted atomically when f returns nil,
+	// and discarded if f returns an error.
+	Transaction(f func(Store) error) error
+	// Recover completes a transaction which was interrupted (e.g. by a crash) after being committed.
+	Recover() error
+	// Watch returns a channel of changes to keys starting with the given prefix, and a function to stop watching.
+	// The channel is closed when watching stops, or if the watcher falls behind, in which case
+	// the caller should re-read the store and watch again.
+	Watch(prefix []byte) (<-chan Event, func())
+	// Revision returns the current revision of the store, which is the revision of its latest change.
+	Revision() (uint64, error)
 	// Close frees all resources (e.g. file handles, network sockets) used by the Store.
 	Close() error
 }
 
+// EventType is the type of a change to a key.
+type EventType string
+
+const (
+	// EventCreate is the creation of a key.
+	EventCreate EventType = "Create"
+	// EventUpdate is the update of the value of an existing key.
+	EventUpdate EventType = "Update"
+	// EventDelete is the deletion of a key.
+	EventDelete EventType = "Delete"
+)
+
+// Event is a change to a (key, value) in the store.
+type Event struct {
+	// Type of the change.
+	Type EventType
+	// Key which was changed.
+	Key []byte
+	// Value of the key after the change, or the last value of a deleted key.
+	Value []byte
+	// Revision of the store after the change. Revisions are monotonically increasing.
+	Revision uint64
+}
+
 // KeyExistsError represents an error caused due to a key which exists.
 type KeyExistsError struct{}
 
@@ -0,0 +1,109 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// versionField is the name of the field holding the version of a persisted object.
+const versionField = "Version"
+
+// Migration upgrades an object, given in a generic form (a decoded JSON object), from one version to the next.
+type Migration func(object map[string]any) error
+
+// MigrationChain upgrades persisted objects of a specific type to their current version.
+type MigrationChain struct {
+	// Type of the objects, used for logging.
+	Type string
+	// Version is the current version of the objects.
+	Version uint32
+	// Migrations holds the upgrade steps, where Migrations[i] upgrades an object from version i+1 to version i+2.
+	Migrations []Migration
+}
+
+// Migrate upgrades all objects of the given store to the current version.
+// Objects with a version newer than the current version result in an error.
+func (c *MigrationChain) Migrate(s ObjectStore) error {
+	if len(c.Migrations) != int(c.Version)-1 {
+		return fmt.Errorf("%s migration chain has %d migrations, expected %d",
+			c.Type, len(c.Migrations), c.Version-1)
+	}
+
+	logger := logrus.WithFields(logrus.Fields{
+		"component": "store.migration",
+		"type":      c.Type,
+	})
+
+	return s.Migrate(func(name string, object map[string]any) (bool, error) {
+		version, err := objectVersion(object)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s '%s': %w", c.Type, name, err)
+		}
+
+		switch {
+		case version == c.Version:
+			return false, nil
+		case version > c.Version:
+			return false, fmt.Errorf(
+				"%s '%s' has version %d, but the latest supported version is %d "+
+					"(was it persisted by a newer controlplane?)",
+				c.Type, name, version, c.Version)
+		}
+
+		logger.Infof("Upgrading '%s' from version %d to %d.", name, version, c.Version)
+
+		for v := version; v < c.Version; v++ {
+			if err := c.Migrations[v-1](object); err != nil {
+				return false, fmt.Errorf("unable to upgrade %s '%s' from version %d: %w", c.Type, name, v, err)
+			}
+		}
+
+		object[versionField] = c.Version
+		return true, nil
+	})
+}
+
+// objectVersion returns the version of a generic object.
+// Objects persisted without a version are considered to be of version 1.
+func objectVersion(object map[string]any) (uint32, error) {
+	value, ok := object[versionField]
+	if !ok || value == nil {
+		return 1, nil
+	}
+
+	var version uint64
+	var err error
+	switch v := value.(type) {
+	case json.Number:
+		version, err = strconv.ParseUint(v.String(), 10, 32)
+	case float64:
+		version, err = strconv.ParseUint(strconv.FormatFloat(v, 'f', -1, 64), 10, 32)
+	default:
+		err = fmt.Errorf("unexpected type %T", value)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid version: %w", err)
+	}
+
+	if version == 0 {
+		return 1, nil
+	}
+
+	return uint32(version), nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv/bolt"
+)
+
+type objectV1 struct {
+	Name    string
+	Host    string
+	Version uint32
+}
+
+type objectV3 struct {
+	Name      string
+	Hosts     []string
+	Namespace string
+	Version   uint32
+}
+
+func TestMigrationChain(t *testing.T) {
+	kvStore, err := bolt.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.Nil(t, err)
+	defer kvStore.Close()
+
+	manager := kv.NewManager(kvStore)
+
+	v1Store := manager.GetObjectStore("object", objectV1{})
+	require.Nil(t, v1Store.Create("a", &objectV1{Name: "a", Host: "host-a", Version: 1}))
+	require.Nil(t, v1Store.Create("b", &objectV1{Name: "b", Host: "host-b", Version: 1}))
+
+	chain := &store.MigrationChain{
+		Type:    "object",
+		Version: 3,
+		Migrations: []store.Migration{
+			// version 1 -> 2: single host to a list of hosts
+			func(object map[string]any) error {
+				object["Hosts"] = []any{object["Host"]}
+				delete(object, "Host")
+				return nil
+			},
+			// version 2 -> 3: add namespace
+			func(object map[string]any) error {
+				object["Namespace"] = "default"
+				return nil
+			},
+		},
+	}
+
+	v3Store := manager.GetObjectStore("object", objectV3{})
+	require.Nil(t, chain.Migrate(v3Store))
+
+	objects, err := v3Store.GetAll()
+	require.Nil(t, err)
+	require.ElementsMatch(t, []any{
+		&objectV3{Name: "a", Hosts: []string{"host-a"}, Namespace: "default", Version: 3},
+		&objectV3{Name: "b", Hosts: []string{"host-b"}, Namespace: "default", Version: 3},
+	}, objects)
+
+	// migrating again is a no-op
+	require.Nil(t, chain.Migrate(v3Store))
+
+	// a failing migration
+	chain.Version = 4
+	chain.Migrations = append(chain.Migrations, func(object map[string]any) error {
+		return fmt.Errorf("failed")
+	})
+	require.NotNil(t, chain.Migrate(v3Store))
+
+	// an incomplete migration chain
+	chain.Version = 5
+	require.NotNil(t, chain.Migrate(v3Store))
+
+	// objects of a future version
+	chain.Version = 2
+	chain.Migrations = chain.Migrations[:1]
+	err = chain.Migrate(v3Store)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "version 3")
+}
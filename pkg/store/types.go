@@ -17,6 +17,11 @@ package store
 type Manager interface {
 	// GetObjectStore returns a store for a specific object type.
 	GetObjectStore(name string, sampleObject any) ObjectStore
+	// Transaction calls f with a manager whose object stores are changed atomically.
+	// Changes are persisted when f returns nil, and discarded if f returns an error.
+	Transaction(f func(Manager) error) error
+	// Recover completes a transaction which was interrupted (e.g. by a crash) after being committed.
+	Recover() error
 }
 
 // ObjectStore represents a persistent store of objects.
@@ -29,8 +34,48 @@ type ObjectStore interface {
 	Update(name string, mutator func(any) any) error
 	// Delete an object identified by the given name.
 	Delete(name string) error
+	// DeleteIfMatch deletes an object identified by the given name, if its resource version matches the given
+	// resource version. A zero resource version deletes the object unconditionally.
+	// Returns ObjectConflictError if the stored resource version differs.
+	DeleteIfMatch(name string, version uint64) error
 	// GetAll returns all of the objects in the store.
 	GetAll() ([]any, error)
+	// Migrate updates the stored objects in place.
+	// The migrator is given each object in a generic form (a decoded JSON object),
+	// and returns true if it modified the object, in which case the object is persisted.
+	// All objects are migrated in a single transaction.
+	Migrate(migrator func(name string, object map[string]any) (bool, error)) error
+	// Watch returns a channel of changes to objects whose name starts with the given prefix,
+	// and a function to stop watching.
+	// The channel is closed when watching stops, or if the watcher falls behind, in which case
+	// the caller should re-read the store and watch again.
+	Watch(prefix string) (<-chan WatchEvent, func())
+	// Revision returns the current revision of the store, which is the revision of its latest change.
+	Revision() (uint64, error)
+}
+
+// EventType is the type of a change to an object.
+type EventType string
+
+const (
+	// EventCreate is the creation of an object.
+	EventCreate EventType = "Create"
+	// EventUpdate is the update of an existing object.
+	EventUpdate EventType = "Update"
+	// EventDelete is the deletion of an object.
+	EventDelete EventType = "Delete"
+)
+
+// WatchEvent is a change to an object in the store.
+type WatchEvent struct {
+	// Type of the change.
+	Type EventType
+	// Name of the changed object.
+	Name string
+	// Object after the change, or the last state of a deleted object.
+	Object any
+	// Revision of the store after the change. Revisions are monotonically increasing.
+	Revision uint64
 }
 
 // ObjectExistsError represents an error caused due to an object which exists.
@@ -46,3 +91,38 @@ type ObjectNotFoundError struct{}
 func (e *ObjectNotFoundError) Error() string {
 	return "object not found"
 }
+
+// VersionedObject is an object carrying a resource version, which is incremented on every update.
+// Stores set the resource version of created objects to 1.
+// An updated object with a non-zero resource version is expected to match the stored resource version,
+// and is rejected with ObjectConflictError otherwise.
+type VersionedObject interface {
+	// GetResourceVersion returns the resource version of the object.
+	GetResourceVersion() uint64
+	// SetResourceVersion sets the resource version of the object.
+	SetResourceVersion(version uint64)
+}
+
+// Versioned implements VersionedObject, and can be embedded in stored objects.
+type Versioned struct {
+	// ResourceVersion is the version of the object, incremented on every update.
+	ResourceVersion uint64
+}
+
+// GetResourceVersion returns the resource version of the object.
+func (v *Versioned) GetResourceVersion() uint64 {
+	return v.ResourceVersion
+}
+
+// SetResourceVersion sets the resource version of the object.
+func (v *Versioned) SetResourceVersion(version uint64) {
+	v.ResourceVersion = version
+}
+
+// ObjectConflictError represents an error caused due to an object which was changed
+// since the expected resource version.
+type ObjectConflictError struct{}
+
+func (e *ObjectConflictError) Error() string {
+	return "object has been modified"
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv/bolt"
+)
+
+type versionedObject struct {
+	store.Versioned
+	Name string
+	Host string
+}
+
+func getVersionedObject(t *testing.T, objectStore store.ObjectStore) *versionedObject {
+	objects, err := objectStore.GetAll()
+	require.Nil(t, err)
+	require.Len(t, objects, 1)
+	return objects[0].(*versionedObject)
+}
+
+func TestResourceVersion(t *testing.T) {
+	kvStore, err := bolt.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.Nil(t, err)
+	defer kvStore.Close()
+
+	objectStore := kv.NewManager(kvStore).GetObjectStore("object", versionedObject{})
+
+	// create sets the initial version
+	object := &versionedObject{Name: "a", Host: "host-a"}
+	require.Nil(t, objectStore.Create("a", object))
+	require.Equal(t, uint64(1), object.ResourceVersion)
+	require.Equal(t, uint64(1), getVersionedObject(t, objectStore).ResourceVersion)
+
+	// unconditional update
+	object = &versionedObject{Name: "a", Host: "host-b"}
+	require.Nil(t, objectStore.Update("a", func(any) any { return object }))
+	require.Equal(t, uint64(2), object.ResourceVersion)
+
+	// update of a stale version
+	stale := &versionedObject{Versioned: store.Versioned{ResourceVersion: 1}, Name: "a", Host: "host-c"}
+	err = objectStore.Update("a", func(any) any { return stale })
+	var conflictErr *store.ObjectConflictError
+	require.True(t, errors.As(err, &conflictErr))
+	require.Equal(t, "host-b", getVersionedObject(t, objectStore).Host)
+
+	// update of the current version
+	current := &versionedObject{Versioned: store.Versioned{ResourceVersion: 2}, Name: "a", Host: "host-c"}
+	require.Nil(t, objectStore.Update("a", func(any) any { return current }))
+	require.Equal(t, uint64(3), current.ResourceVersion)
+
+	// in-place update of the stored object
+	err = objectStore.Update("a", func(a any) any {
+		a.(*versionedObject).Host = "host-d"
+		return a
+	})
+	require.Nil(t, err)
+
+	stored := getVersionedObject(t, objectStore)
+	require.Equal(t, uint64(4), stored.ResourceVersion)
+	require.Equal(t, "host-d", stored.Host)
+}
@@ -30,8 +30,9 @@ import (
 
 // Client for issuing HTTP requests.
 type Client struct {
-	client    *http.Client
-	serverURL string
+	client       *http.Client
+	streamClient *http.Client
+	serverURL    string
 
 	logger *logrus.Entry
 }
@@ -39,27 +40,46 @@ type Client struct {
 // Response for a request.
 type Response struct {
 	Status int
+	Header http.Header
 	Body   []byte
 }
 
 // Get sends an HTTP GET request.
 func (c *Client) Get(path string) (*Response, error) {
-	return c.do(http.MethodGet, path, nil)
+	return c.do(http.MethodGet, path, nil, nil)
 }
 
 // Post sends an HTTP POST request.
 func (c *Client) Post(path string, body []byte) (*Response, error) {
-	return c.do(http.MethodPost, path, body)
+	return c.do(http.MethodPost, path, body, nil)
 }
 
 // Put sends an HTTP PUT request.
 func (c *Client) Put(path string, body []byte) (*Response, error) {
-	return c.do(http.MethodPut, path, body)
+	return c.do(http.MethodPut, path, body, nil)
 }
 
 // Delete sends an HTTP DELETE request.
 func (c *Client) Delete(path string, body []byte) (*Response, error) {
-	return c.do(http.MethodDelete, path, body)
+	return c.do(http.MethodDelete, path, body, nil)
+}
+
+// Do sends an HTTP request with the given headers.
+func (c *Client) Do(method, path string, body []byte, header http.Header) (*Response, error) {
+	return c.do(method, path, body, header)
+}
+
+// Stream sends an HTTP GET request for a streamed response, which is not subject to the client timeout.
+// The caller must close the response body.
+func (c *Client) Stream(path string) (*http.Response, error) {
+	c.logger.WithField("path", path).Debugf("Issuing stream request.")
+
+	resp, err := c.streamClient.Get(c.serverURL + path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to perform http request: %w", err)
+	}
+
+	return resp, nil
 }
 
 // ServerURL returns the server URL configured for this client.
@@ -67,7 +87,7 @@ func (c *Client) ServerURL() string {
 	return c.serverURL
 }
 
-func (c *Client) do(method, path string, body []byte) (*Response, error) {
+func (c *Client) do(method, path string, body []byte, header http.Header) (*Response, error) {
 	requestLogger := c.logger.WithFields(logrus.Fields{"method": method, "path": path})
 
 	requestLogger.WithField("body-length", len(body)).Debugf("Issuing request.")
@@ -78,6 +98,12 @@ func (c *Client) do(method, path string, body []byte) (*Response, error) {
 		return nil, fmt.Errorf("unable to create http request: %w", err)
 	}
 
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	}
@@ -115,6 +141,7 @@ func (c *Client) do(method, path string, body []byte) (*Response, error) {
 
 	return &Response{
 		Status: resp.StatusCode,
+		Header: resp.Header,
 		Body:   body,
 	}, nil
 }
@@ -127,6 +154,9 @@ func NewClient(host string, port uint16, tlsConfig *tls.Config) *Client {
 			Transport: &http.Transport{TLSClientConfig: tlsConfig},
 			Timeout:   3 * time.Second,
 		},
+		streamClient: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
 		serverURL: serverURL,
 		logger: logrus.WithFields(logrus.Fields{
 			"component":  "http-client",
@@ -14,10 +14,15 @@
 package rest
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"reflect"
+	"strconv"
+	"sync"
 
 	"github.com/clusterlink-net/clusterlink/pkg/util/jsonapi"
 )
@@ -64,16 +69,27 @@ func (c *Client) Create(object any) error {
 
 // Update an object.
 func (c *Client) Update(object any) error {
+	return c.UpdateIfMatch(object, 0)
+}
+
+// UpdateIfMatch updates an object, only if its current resource version matches the given version.
+// A zero version updates the object unconditionally.
+// Returns ConflictError if the object was modified since the given version.
+func (c *Client) UpdateIfMatch(object any, version uint64) error {
 	encoded, err := json.Marshal(object)
 	if err != nil {
 		return fmt.Errorf("unable to encode object: %w", err)
 	}
 
-	resp, err := c.client.Put(c.basePath, encoded)
+	resp, err := c.client.Do(http.MethodPut, c.basePath, encoded, ifMatchHeader(version))
 	if err != nil {
 		return fmt.Errorf("unable to update object: %w", err)
 	}
 
+	if resp.Status == http.StatusConflict {
+		return &ConflictError{ResourceVersion: version, Message: string(bytes.TrimSpace(resp.Body))}
+	}
+
 	if resp.Status != http.StatusNoContent {
 		return fmt.Errorf("unable to update object (%d), server returned: %s",
 			resp.Status, resp.Body)
@@ -84,26 +100,49 @@ func (c *Client) Update(object any) error {
 
 // Get an object.
 func (c *Client) Get(name string) (any, error) {
+	object, _, err := c.GetWithVersion(name)
+	return object, err
+}
+
+// GetWithVersion gets an object, together with its resource version.
+// The resource version is 0 if the server does not version objects of this type.
+func (c *Client) GetWithVersion(name string) (any, uint64, error) {
 	resp, err := c.client.Get(c.basePath + "/" + name)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get object: %w", err)
+		return nil, 0, fmt.Errorf("unable to get object: %w", err)
 	}
 
 	if resp.Status != http.StatusOK {
-		return nil, fmt.Errorf("unable to get object (%d), server returned: %s",
+		return nil, 0, fmt.Errorf("unable to get object (%d), server returned: %s",
 			resp.Status, resp.Body)
 	}
 
 	decoded := reflect.New(c.objectType).Interface()
 	if err := json.Unmarshal(resp.Body, decoded); err != nil {
-		return nil, fmt.Errorf("unable to decode object %v: %w", decoded, err)
+		return nil, 0, fmt.Errorf("unable to decode object %v: %w", decoded, err)
+	}
+
+	var version uint64
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		version, err = ParseETag(etag)
+		if err != nil {
+			return nil, 0, err
+		}
 	}
 
-	return decoded, nil
+	return decoded, version, nil
 }
 
 // Delete an object, either by name or the object itself.
 func (c *Client) Delete(object any) error {
+	return c.DeleteIfMatch(object, 0)
+}
+
+// DeleteIfMatch deletes an object (either by name or the object itself),
+// only if its current resource version matches the given version.
+// A zero version deletes the object unconditionally.
+// Returns ConflictError if the object was modified since the given version.
+func (c *Client) DeleteIfMatch(object any, version uint64) error {
 	var body []byte
 	path := c.basePath
 
@@ -119,11 +158,15 @@ func (c *Client) Delete(object any) error {
 		body = encoded
 	}
 
-	resp, err := c.client.Delete(path, body)
+	resp, err := c.client.Do(http.MethodDelete, path, body, ifMatchHeader(version))
 	if err != nil {
 		return fmt.Errorf("unable to delete object: %w", err)
 	}
 
+	if resp.Status == http.StatusConflict {
+		return &ConflictError{ResourceVersion: version, Message: string(bytes.TrimSpace(resp.Body))}
+	}
+
 	if resp.Status != http.StatusNoContent {
 		return fmt.Errorf("unable to delete object (%d), server returned: %s",
 			resp.Status, resp.Body)
@@ -134,22 +177,109 @@ func (c *Client) Delete(object any) error {
 
 // List all objects.
 func (c *Client) List() (any, error) {
-	resp, err := c.client.Get(c.basePath)
+	list, _, err := c.ListWithOptions(nil)
+	return list, err
+}
+
+// ListWithOptions lists the objects selected by the given options.
+// Returns the token for listing the next page of objects, or an empty string if there are no more objects.
+func (c *Client) ListWithOptions(opts *ListOptions) (any, string, error) {
+	path := c.basePath
+	if query := opts.query(); len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp, err := c.client.Get(path)
 	if err != nil {
-		return nil, fmt.Errorf("unable to list objects: %w", err)
+		return nil, "", fmt.Errorf("unable to list objects: %w", err)
 	}
 
 	if resp.Status != http.StatusOK {
-		return nil, fmt.Errorf("unable to list objects (%d), server returned: %s",
+		return nil, "", fmt.Errorf("unable to list objects (%d), server returned: %s",
 			resp.Status, resp.Body)
 	}
 
 	decoded := reflect.New(c.listType).Interface()
 	if err := json.Unmarshal(resp.Body, decoded); err != nil {
-		return nil, fmt.Errorf("unable to decode object list %v: %w", decoded, err)
+		return nil, "", fmt.Errorf("unable to decode object list %v: %w", decoded, err)
+	}
+
+	return decoded, resp.Header.Get(ContinueHeader), nil
+}
+
+// ifMatchHeader returns the request headers for expecting the given resource version.
+func ifMatchHeader(version uint64) http.Header {
+	if version == 0 {
+		return nil
+	}
+
+	return http.Header{"If-Match": []string{FormatETag(version)}}
+}
+
+// Watch streams changes to the objects, and returns a function to stop watching.
+// If revision is 0, the current objects are first sent as added. Otherwise, the changes after the revision are sent,
+// or ExpiredError is returned if the revision is no longer available.
+// The channel is closed when the stream ends, after which the watch can be resumed from the last received revision.
+func (c *Client) Watch(revision uint64) (<-chan WatchEvent, func(), error) {
+	query := url.Values{WatchParam: []string{"true"}}
+	if revision != 0 {
+		query.Set(RevisionParam, strconv.FormatUint(revision, 10))
+	}
+
+	resp, err := c.client.Stream(c.basePath + "?" + query.Encode())
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to watch objects: %w", err)
 	}
 
-	return decoded, nil
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusGone {
+			return nil, nil, &ExpiredError{Revision: revision}
+		}
+
+		return nil, nil, fmt.Errorf("unable to watch objects (%d), server returned: %s",
+			resp.StatusCode, body)
+	}
+
+	events := make(chan WatchEvent)
+	done := make(chan struct{})
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var event struct {
+				Type     WatchEventType
+				Object   json.RawMessage
+				Revision uint64
+			}
+			if err := decoder.Decode(&event); err != nil {
+				return
+			}
+
+			decoded := reflect.New(c.listType.Elem()).Interface()
+			if err := json.Unmarshal(event.Object, decoded); err != nil {
+				return
+			}
+
+			select {
+			case events <- WatchEvent{Type: event.Type, Object: decoded, Revision: event.Revision}:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return events, func() {
+		once.Do(func() {
+			close(done)
+			resp.Body.Close()
+		})
+	}, nil
 }
 
 // NewClient returns a new REST-JSON client.
@@ -0,0 +1,173 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// LabelSelectorParam is the query parameter for selecting listed objects by their labels (e.g. "env=prod,!test").
+	LabelSelectorParam = "labelSelector"
+	// FieldSelectorParam is the query parameter for selecting listed objects by their fields (e.g. "spec.peer=p1").
+	FieldSelectorParam = "fieldSelector"
+	// PrefixParam is the query parameter for selecting listed objects by a prefix of their name.
+	PrefixParam = "prefix"
+	// LimitParam is the query parameter for the maximal number of listed objects.
+	LimitParam = "limit"
+	// ContinueParam is the query parameter for listing the next page of objects.
+	ContinueParam = "continue"
+
+	// ContinueHeader is the response header holding the token for listing the next page of objects.
+	// The header is missing if there are no more objects.
+	ContinueHeader = "X-Continue"
+)
+
+// ListOptions select and paginate the listed objects.
+type ListOptions struct {
+	// LabelSelector selects objects by their labels.
+	LabelSelector string
+	// FieldSelector selects objects by their fields.
+	FieldSelector string
+	// Prefix selects objects whose name starts with the given prefix.
+	Prefix string
+	// Limit is the maximal number of objects to return. If 0, all objects are returned.
+	Limit int
+	// Continue is the token returned by a previous list, for listing the next page of objects.
+	Continue string
+}
+
+// query returns the options as query parameters.
+func (o *ListOptions) query() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+
+	setParam := func(key, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	setParam(LabelSelectorParam, o.LabelSelector)
+	setParam(FieldSelectorParam, o.FieldSelector)
+	setParam(PrefixParam, o.Prefix)
+	setParam(ContinueParam, o.Continue)
+	if o.Limit > 0 {
+		query.Set(LimitParam, strconv.Itoa(o.Limit))
+	}
+
+	return query
+}
+
+// parseListOptions returns the list options given by query parameters.
+func parseListOptions(query url.Values) (*ListOptions, error) {
+	opts := &ListOptions{
+		LabelSelector: query.Get(LabelSelectorParam),
+		FieldSelector: query.Get(FieldSelectorParam),
+		Prefix:        query.Get(PrefixParam),
+		Continue:      query.Get(ContinueParam),
+	}
+
+	if limit := query.Get(LimitParam); limit != "" {
+		var err error
+		opts.Limit, err = strconv.Atoi(limit)
+		if err != nil || opts.Limit < 0 {
+			return nil, fmt.Errorf("invalid %s '%s'", LimitParam, limit)
+		}
+	}
+
+	return opts, nil
+}
+
+// filterList returns the objects of a list (a slice) which are selected by the given options,
+// and the token for listing the next page of objects (if any).
+func filterList(spec *ServerObjectSpec, list any, opts *ListOptions) (any, string, error) {
+	if *opts == (ListOptions{}) {
+		return list, "", nil
+	}
+
+	if spec.Key == nil {
+		return nil, "", fmt.Errorf("selecting objects is not supported")
+	}
+
+	labelSelector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid label selector: %w", err)
+	}
+	if !labelSelector.Empty() && spec.Labels == nil {
+		return nil, "", fmt.Errorf("selecting objects by labels is not supported")
+	}
+
+	fieldSelector, err := fields.ParseSelector(opts.FieldSelector)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid field selector: %w", err)
+	}
+	if !fieldSelector.Empty() && spec.Fields == nil {
+		return nil, "", fmt.Errorf("selecting objects by fields is not supported")
+	}
+
+	var after string
+	if opts.Continue != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(opts.Continue)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid continue token")
+		}
+		after = string(decoded)
+	}
+
+	listValue := reflect.ValueOf(list)
+	selected := reflect.MakeSlice(listValue.Type(), 0, listValue.Len())
+	for i := 0; i < listValue.Len(); i++ {
+		object := listValue.Index(i).Interface()
+		key := spec.Key(object)
+
+		switch {
+		case !strings.HasPrefix(key, opts.Prefix):
+			continue
+		case opts.Continue != "" && key <= after:
+			continue
+		case !labelSelector.Empty() && !labelSelector.Matches(labels.Set(spec.Labels(object))):
+			continue
+		case !fieldSelector.Empty() && !fieldSelector.Matches(fields.Set(spec.Fields(object))):
+			continue
+		}
+
+		selected = reflect.Append(selected, listValue.Index(i))
+	}
+
+	// pages are ordered by the object keys
+	sort.Slice(selected.Interface(), func(i, j int) bool {
+		return spec.Key(selected.Index(i).Interface()) < spec.Key(selected.Index(j).Interface())
+	})
+
+	var next string
+	if opts.Limit > 0 && selected.Len() > opts.Limit {
+		selected = selected.Slice(0, opts.Limit)
+		last := spec.Key(selected.Index(opts.Limit - 1).Interface())
+		next = base64.RawURLEncoding.EncodeToString([]byte(last))
+	}
+
+	return selected.Interface(), next, nil
+}
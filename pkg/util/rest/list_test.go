@@ -0,0 +1,100 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/util/rest"
+)
+
+func TestListOptions(t *testing.T) {
+	handler := &objectHandler{objects: make(map[string]*object)}
+	for i := 0; i < 5; i++ {
+		env := "test"
+		if i%2 == 0 {
+			env = "prod"
+		}
+
+		name := "obj-" + strconv.Itoa(i)
+		handler.objects[name] = &object{Name: name, Labels: map[string]string{"env": env}, Value: i}
+	}
+	handler.objects["other"] = &object{Name: "other", Value: 1}
+
+	client, stopServer := startServer(t, &rest.ServerObjectSpec{
+		BasePath: "/objects",
+		Handler:  handler,
+		Key:      func(o any) string { return o.(*object).Name },
+		Labels:   func(o any) map[string]string { return o.(*object).Labels },
+		Fields: func(o any) map[string]string {
+			return map[string]string{"value": strconv.Itoa(o.(*object).Value)}
+		},
+	})
+	defer stopServer()
+
+	names := func(list any) []string {
+		var result []string
+		for _, o := range *list.(*[]object) {
+			result = append(result, o.Name)
+		}
+		return result
+	}
+
+	// no options
+	list, next, err := client.ListWithOptions(nil)
+	require.Nil(t, err)
+	require.Len(t, names(list), 6)
+	require.Empty(t, next)
+
+	// label selector
+	list, _, err = client.ListWithOptions(&rest.ListOptions{LabelSelector: "env=prod"})
+	require.Nil(t, err)
+	require.Equal(t, []string{"obj-0", "obj-2", "obj-4"}, names(list))
+
+	list, _, err = client.ListWithOptions(&rest.ListOptions{LabelSelector: "!env"})
+	require.Nil(t, err)
+	require.Equal(t, []string{"other"}, names(list))
+
+	// field selector and prefix
+	list, _, err = client.ListWithOptions(&rest.ListOptions{FieldSelector: "value=1"})
+	require.Nil(t, err)
+	require.Equal(t, []string{"obj-1", "other"}, names(list))
+
+	list, _, err = client.ListWithOptions(&rest.ListOptions{FieldSelector: "value=1", Prefix: "obj-"})
+	require.Nil(t, err)
+	require.Equal(t, []string{"obj-1"}, names(list))
+
+	// pagination
+	opts := &rest.ListOptions{Prefix: "obj-", Limit: 2}
+	var pages [][]string
+	for {
+		list, next, err = client.ListWithOptions(opts)
+		require.Nil(t, err)
+		pages = append(pages, names(list))
+		if next == "" {
+			break
+		}
+		opts.Continue = next
+	}
+	require.Equal(t, [][]string{{"obj-0", "obj-1"}, {"obj-2", "obj-3"}, {"obj-4"}}, pages)
+
+	// invalid options
+	_, _, err = client.ListWithOptions(&rest.ListOptions{LabelSelector: "env in (prod"})
+	require.NotNil(t, err)
+	_, _, err = client.ListWithOptions(&rest.ListOptions{Continue: "%%%"})
+	require.NotNil(t, err)
+}
@@ -14,6 +14,7 @@
 package rest
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -40,13 +41,16 @@ type Handler interface {
 	// Decode and validate an object.
 	Decode(data []byte) (any, error)
 	// Create an object.
-	Create(object any) error
+	Create(ctx context.Context, object any) error
 	// Update an object.
-	Update(object any) error
+	// The resource version expected by the request (if any) is given by IfMatch(ctx).
+	Update(ctx context.Context, object any) error
 	// Get an object.
+	// The object may be returned as a *Versioned, in which case its resource version is returned as the ETag.
 	Get(name string) (any, error)
 	// Delete an object.
-	Delete(object any) (any, error)
+	// The resource version expected by the request (if any) is given by IfMatch(ctx).
+	Delete(ctx context.Context, object any) (any, error)
 	// List all objects.
 	List() (any, error)
 }
@@ -59,6 +63,15 @@ type ServerObjectSpec struct {
 	Handler Handler
 	// DeleteByValue is true for object types which are deletable by sending their value, instead of their name.
 	DeleteByValue bool
+	// Key returns the key identifying an object returned by Handler.List.
+	// It is required for watching, selecting and paginating objects.
+	Key func(object any) string
+	// Labels returns the labels of an object returned by Handler.List, for selecting objects by labels.
+	Labels func(object any) map[string]string
+	// Fields returns the selectable fields of an object returned by Handler.List, for selecting objects by fields.
+	Fields func(object any) map[string]string
+	// Watch, if set, allows watching the objects (GET <BasePath>?watch=true).
+	Watch *WatchSpec
 }
 
 func (s *Server) create(spec *ServerObjectSpec, w http.ResponseWriter, r *http.Request) {
@@ -80,7 +93,7 @@ func (s *Server) create(spec *ServerObjectSpec, w http.ResponseWriter, r *http.R
 		return
 	}
 
-	if err := spec.Handler.Create(object); err != nil {
+	if err := spec.Handler.Create(r.Context(), object); err != nil {
 		var objectExistsErr *store.ObjectExistsError
 		if errors.As(err, &objectExistsErr) {
 			requestLogger.Errorf("Object already exists.")
@@ -116,7 +129,14 @@ func (s *Server) update(spec *ServerObjectSpec, w http.ResponseWriter, r *http.R
 		return
 	}
 
-	if err := spec.Handler.Update(object); err != nil {
+	ctx, err := ifMatchContext(r)
+	if err != nil {
+		requestLogger.Errorf("Invalid precondition: %v.", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := spec.Handler.Update(ctx, object); err != nil {
 		var objectNotFoundError *store.ObjectNotFoundError
 		if errors.As(err, &objectNotFoundError) {
 			requestLogger.Errorf("Object not found.")
@@ -124,6 +144,11 @@ func (s *Server) update(spec *ServerObjectSpec, w http.ResponseWriter, r *http.R
 			return
 		}
 
+		if writeConflict(w, err) {
+			requestLogger.Errorf("Object was modified.")
+			return
+		}
+
 		requestLogger.Errorf("Cannot update object: %v.", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -151,6 +176,12 @@ func (s *Server) get(spec *ServerObjectSpec, w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	var version uint64
+	if versioned, ok := result.(*Versioned); ok {
+		result = versioned.Object
+		version = versioned.ResourceVersion
+	}
+
 	encoded, err := json.Marshal(result)
 	if err != nil {
 		requestLogger.Errorf("Cannot encode object: %v.", err)
@@ -158,8 +189,11 @@ func (s *Server) get(spec *ServerObjectSpec, w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if version != 0 {
+		w.Header().Set("ETag", FormatETag(version))
+	}
+	w.WriteHeader(http.StatusOK)
 
 	if _, err := w.Write(encoded); err != nil {
 		s.logger.Errorf("Cannot write http response: %v.", err)
@@ -185,8 +219,20 @@ func (s *Server) deleteObject(spec *ServerObjectSpec, w http.ResponseWriter, r *
 		return
 	}
 
-	result, err := spec.Handler.Delete(object)
+	ctx, err := ifMatchContext(r)
 	if err != nil {
+		requestLogger.Errorf("Invalid precondition: %v.", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := spec.Handler.Delete(ctx, object)
+	if err != nil {
+		if writeConflict(w, err) {
+			requestLogger.Errorf("Object was modified.")
+			return
+		}
+
 		requestLogger.Errorf("Cannot delete object: %v.", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -206,8 +252,20 @@ func (s *Server) delete(spec *ServerObjectSpec, w http.ResponseWriter, r *http.R
 
 	name := chi.URLParam(r, "name")
 
-	result, err := spec.Handler.Delete(name)
+	ctx, err := ifMatchContext(r)
+	if err != nil {
+		requestLogger.Errorf("Invalid precondition: %v.", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := spec.Handler.Delete(ctx, name)
 	if err != nil {
+		if writeConflict(w, err) {
+			requestLogger.Errorf("Object was modified.")
+			return
+		}
+
 		requestLogger.Errorf("Cannot delete object: %v.", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -225,6 +283,13 @@ func (s *Server) list(spec *ServerObjectSpec, w http.ResponseWriter, r *http.Req
 	requestLogger := s.logger.WithFields(logrus.Fields{"method": "list", "path": r.URL.Path})
 	requestLogger.Infof("Handling request.")
 
+	opts, err := parseListOptions(r.URL.Query())
+	if err != nil {
+		requestLogger.Errorf("Invalid list options: %v.", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	result, err := spec.Handler.List()
 	if err != nil {
 		requestLogger.Errorf("Cannot list objects: %v.", err)
@@ -232,6 +297,13 @@ func (s *Server) list(spec *ServerObjectSpec, w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	result, next, err := filterList(spec, result, opts)
+	if err != nil {
+		requestLogger.Errorf("Cannot select objects: %v.", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	encoded, err := json.Marshal(result)
 	if err != nil {
 		requestLogger.Errorf("Cannot encode objects: %v.", err)
@@ -239,18 +311,37 @@ func (s *Server) list(spec *ServerObjectSpec, w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if next != "" {
+		w.Header().Set(ContinueHeader, next)
+	}
+	w.WriteHeader(http.StatusOK)
 
 	if _, err := w.Write(encoded); err != nil {
 		s.logger.Errorf("Cannot write http response: %v.", err)
 	}
 }
 
+// writeConflict writes a conflict response if the given error is an ObjectConflictError.
+func writeConflict(w http.ResponseWriter, err error) bool {
+	var objectConflictError *store.ObjectConflictError
+	if !errors.As(err, &objectConflictError) {
+		return false
+	}
+
+	http.Error(w, objectConflictError.Error(), http.StatusConflict)
+	return true
+}
+
 // AddObjectHandlers adds the server a handlers for managing a specific object type.
 func (s *Server) AddObjectHandlers(spec *ServerObjectSpec) {
 	router := s.Router()
 
+	var hub *watchHub
+	if spec.Watch != nil && spec.Key != nil {
+		hub = newWatchHub(spec, s.logger.WithField("path", spec.BasePath))
+	}
+
 	router.Route(spec.BasePath, func(cr chi.Router) {
 		cr.Post("/", func(w http.ResponseWriter, r *http.Request) {
 			s.create(spec, w, r)
@@ -262,6 +353,16 @@ func (s *Server) AddObjectHandlers(spec *ServerObjectSpec) {
 			s.get(spec, w, r)
 		})
 		cr.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get(WatchParam) == "true" {
+				if hub == nil {
+					http.Error(w, "watch is not supported", http.StatusBadRequest)
+					return
+				}
+
+				s.watch(hub, w, r)
+				return
+			}
+
 			s.list(spec, w, r)
 		})
 
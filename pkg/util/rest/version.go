@@ -0,0 +1,95 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ifMatchKey is the context key of the resource version expected by a request.
+type ifMatchKey struct{}
+
+// Versioned is an object returned by Handler.Get, together with its resource version.
+// The resource version is returned to clients as the ETag of the object.
+type Versioned struct {
+	// Object returned to the client.
+	Object any
+	// ResourceVersion of the object.
+	ResourceVersion uint64
+}
+
+// ConflictError is returned by the client when the resource version given for an update or delete
+// does not match the current resource version of the object.
+type ConflictError struct {
+	// ResourceVersion expected by the request.
+	ResourceVersion uint64
+	// Message returned by the server.
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("object was modified since resource version %d: %s", e.ResourceVersion, e.Message)
+}
+
+// IfMatch returns the resource version which an update or delete request expects the object to have.
+// Returns 0 if the request has no precondition.
+func IfMatch(ctx context.Context) uint64 {
+	version, _ := ctx.Value(ifMatchKey{}).(uint64)
+	return version
+}
+
+// WithIfMatch returns a context of a request expecting the given resource version.
+func WithIfMatch(ctx context.Context, version uint64) context.Context {
+	return context.WithValue(ctx, ifMatchKey{}, version)
+}
+
+// FormatETag returns the ETag of a resource version.
+func FormatETag(version uint64) string {
+	return strconv.Quote(strconv.FormatUint(version, 10))
+}
+
+// ParseETag returns the resource version of an ETag.
+func ParseETag(etag string) (uint64, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+
+	version, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || version == 0 {
+		return 0, fmt.Errorf("invalid resource version '%s'", etag)
+	}
+
+	return version, nil
+}
+
+// ifMatchContext returns the request context, holding the resource version given by the If-Match header.
+// A missing header, or '*', means the request has no precondition.
+func ifMatchContext(r *http.Request) (context.Context, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return r.Context(), nil
+	}
+
+	version, err := ParseETag(ifMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	return WithIfMatch(r.Context(), version), nil
+}
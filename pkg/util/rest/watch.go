@@ -0,0 +1,366 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store"
+)
+
+const (
+	// WatchParam is the query parameter for watching objects, instead of listing them.
+	WatchParam = "watch"
+	// RevisionParam is the query parameter for resuming a watch after a given revision.
+	RevisionParam = "resourceVersion"
+
+	// watchHistorySize is the number of past events kept for resuming watches.
+	watchHistorySize = 1024
+	// watchBufferSize is the number of events buffered for a watcher, before it is dropped.
+	watchBufferSize = 256
+	// watchContentType is the content type of a watch stream, which is a sequence of JSON lines.
+	watchContentType = "application/x-ndjson"
+)
+
+// WatchEventType is the type of a change to an object.
+type WatchEventType string
+
+const (
+	// WatchAdded is the addition of an object.
+	WatchAdded WatchEventType = "ADDED"
+	// WatchModified is the modification of an existing object.
+	WatchModified WatchEventType = "MODIFIED"
+	// WatchDeleted is the deletion of an object.
+	WatchDeleted WatchEventType = "DELETED"
+)
+
+// WatchEvent is a change to an object, streamed to watchers.
+type WatchEvent struct {
+	// Type of the change.
+	Type WatchEventType
+	// Object after the change, or the last state of a deleted object.
+	Object any
+	// Revision after the change. A watch can be resumed after a given revision.
+	Revision uint64
+}
+
+// WatchSpec enables watching the objects of a specific type, identified by ServerObjectSpec.Key.
+// Changes are taken from the store holding the objects, and carry the store revisions.
+type WatchSpec struct {
+	// Store holding the objects.
+	Store store.ObjectStore
+	// Convert returns the object served for a stored object.
+	Convert func(object any) any
+}
+
+// ExpiredError is returned when resuming a watch after a revision which is no longer available.
+// The caller should list the objects, or watch without a revision.
+type ExpiredError struct {
+	// Revision requested.
+	Revision uint64
+}
+
+func (e *ExpiredError) Error() string {
+	return fmt.Sprintf("revision %d is no longer available", e.Revision)
+}
+
+// watcher receives the events of a watch hub.
+type watcher struct {
+	events chan *WatchEvent
+}
+
+// watchHub tracks changes to the objects of a specific type by watching their store,
+// and streams the changes to watchers.
+type watchHub struct {
+	spec *ServerObjectSpec
+
+	startOnce sync.Once
+
+	lock sync.Mutex
+	// revision is the store revision of the tracked objects.
+	revision uint64
+	// oldest is the revision after which all events are kept in the history.
+	oldest   uint64
+	objects  map[string]json.RawMessage
+	history  []*WatchEvent
+	watchers map[*watcher]struct{}
+
+	logger *logrus.Entry
+}
+
+// start tracking changes to the objects, once the first watcher arrives.
+func (h *watchHub) start() {
+	h.startOnce.Do(func() {
+		events, stop := h.spec.Watch.Store.Watch("")
+		h.sync()
+
+		go h.run(events, stop)
+	})
+}
+
+// run applies the watched changes, and watches again whenever changes may have been missed.
+func (h *watchHub) run(events <-chan store.WatchEvent, stop func()) {
+	for {
+		for event := range events {
+			h.apply(&event)
+		}
+		stop()
+
+		h.logger.Info("Store watch closed, re-listing objects.")
+		events, stop = h.spec.Watch.Store.Watch("")
+		h.sync()
+	}
+}
+
+// sync lists the objects in the store, and notifies watchers of any change since the tracked objects.
+// Changes which are not watched (e.g. missed by a watch which fell behind) cannot be resumed, as their
+// revisions are unknown, so they are published at the current revision of the store, and the history is reset.
+func (h *watchHub) sync() {
+	revision, err := h.spec.Watch.Store.Revision()
+	if err != nil {
+		h.logger.Errorf("Cannot get store revision: %v.", err)
+		return
+	}
+
+	stored, err := h.spec.Watch.Store.GetAll()
+	if err != nil {
+		h.logger.Errorf("Cannot list objects: %v.", err)
+		return
+	}
+
+	objects := make(map[string]json.RawMessage, len(stored))
+	for _, object := range stored {
+		key, encoded, err := h.encode(object)
+		if err != nil {
+			h.logger.Errorf("Cannot encode object: %v.", err)
+			return
+		}
+		objects[key] = encoded
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.revision = max(h.revision, revision)
+
+	for _, key := range SortedKeys(objects) {
+		old, ok := h.objects[key]
+		switch {
+		case !ok:
+			h.publish(WatchAdded, key, objects[key], h.revision)
+		case !bytes.Equal(old, objects[key]):
+			h.publish(WatchModified, key, objects[key], h.revision)
+		}
+	}
+
+	for _, key := range SortedKeys(h.objects) {
+		if _, ok := objects[key]; !ok {
+			h.publish(WatchDeleted, key, h.objects[key], h.revision)
+		}
+	}
+
+	// the history is kept only for events which were watched
+	h.oldest = h.revision
+	h.history = nil
+}
+
+// apply a watched change, notifying watchers if it changes the tracked objects.
+// Changes which are already tracked (e.g. made before the objects were listed) are ignored.
+func (h *watchHub) apply(event *store.WatchEvent) {
+	key, encoded, err := h.encode(event.Object)
+	if err != nil {
+		h.logger.Errorf("Cannot encode object: %v.", err)
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	// keep revisions monotonic, even for changes made before the objects were listed
+	revision := max(h.revision, event.Revision)
+
+	old, ok := h.objects[key]
+	switch {
+	case event.Type == store.EventDelete:
+		if ok {
+			h.publish(WatchDeleted, key, old, revision)
+		}
+	case !ok:
+		h.publish(WatchAdded, key, encoded, revision)
+	case !bytes.Equal(old, encoded):
+		h.publish(WatchModified, key, encoded, revision)
+	}
+
+	h.revision = revision
+}
+
+// encode returns the key and the encoding of the object served for a stored object.
+func (h *watchHub) encode(object any) (string, json.RawMessage, error) {
+	if h.spec.Watch.Convert != nil {
+		object = h.spec.Watch.Convert(object)
+	}
+
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return h.spec.Key(object), encoded, nil
+}
+
+// publish records a change to the tracked objects, and sends it to all watchers. Must be called with the lock held.
+func (h *watchHub) publish(eventType WatchEventType, key string, object json.RawMessage, revision uint64) {
+	if eventType == WatchDeleted {
+		delete(h.objects, key)
+	} else {
+		h.objects[key] = object
+	}
+
+	h.revision = revision
+	event := &WatchEvent{Type: eventType, Object: object, Revision: revision}
+
+	h.history = append(h.history, event)
+	if len(h.history) > watchHistorySize {
+		h.oldest = h.history[len(h.history)-watchHistorySize-1].Revision
+		h.history = h.history[len(h.history)-watchHistorySize:]
+	}
+
+	for w := range h.watchers {
+		select {
+		case w.events <- event:
+		default:
+			// drop a watcher which falls behind, it may resume from its last revision
+			h.logger.Warn("Dropping a slow watcher.")
+			delete(h.watchers, w)
+			close(w.events)
+		}
+	}
+}
+
+// watch returns the events to send a new watcher, and registers it for later events.
+// If resume is false, the watcher is sent all current objects. Otherwise, it is sent all events after the revision.
+func (h *watchHub) watch(revision uint64, resume bool) ([]*WatchEvent, *watcher, error) {
+	h.start()
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	var initial []*WatchEvent
+	if resume {
+		if revision < h.oldest || revision > h.revision {
+			return nil, nil, &ExpiredError{Revision: revision}
+		}
+
+		for _, event := range h.history {
+			if event.Revision > revision {
+				initial = append(initial, event)
+			}
+		}
+	} else {
+		for _, key := range SortedKeys(h.objects) {
+			initial = append(initial, &WatchEvent{Type: WatchAdded, Object: h.objects[key], Revision: h.revision})
+		}
+	}
+
+	w := &watcher{events: make(chan *WatchEvent, watchBufferSize)}
+	h.watchers[w] = struct{}{}
+	return initial, w, nil
+}
+
+// unwatch unregisters a watcher.
+func (h *watchHub) unwatch(w *watcher) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if _, ok := h.watchers[w]; ok {
+		delete(h.watchers, w)
+		close(w.events)
+	}
+}
+
+// newWatchHub returns a new hub for watching the objects of a handler.
+func newWatchHub(spec *ServerObjectSpec, logger *logrus.Entry) *watchHub {
+	return &watchHub{
+		spec:     spec,
+		objects:  make(map[string]json.RawMessage),
+		watchers: make(map[*watcher]struct{}),
+		logger:   logger,
+	}
+}
+
+func (s *Server) watch(hub *watchHub, w http.ResponseWriter, r *http.Request) {
+	requestLogger := s.logger.WithFields(logrus.Fields{"method": "watch", "path": r.URL.Path})
+	requestLogger.Infof("Handling request.")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	var revision uint64
+	param := r.URL.Query().Get(RevisionParam)
+	if param != "" {
+		var err error
+		revision, err = strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s: %v", RevisionParam, err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	initial, watcher, err := hub.watch(revision, param != "")
+	if err != nil {
+		requestLogger.Infof("Cannot watch: %v.", err)
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	}
+	defer hub.unwatch(watcher)
+
+	w.Header().Set("Content-Type", watchContentType)
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	for _, event := range initial {
+		if err := encoder.Encode(event); err != nil {
+			requestLogger.Debugf("Cannot write event: %v.", err)
+			return
+		}
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case event, ok := <-watcher.events:
+			if !ok {
+				return
+			}
+
+			if err := encoder.Encode(event); err != nil {
+				requestLogger.Debugf("Cannot write event: %v.", err)
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv"
+	"github.com/clusterlink-net/clusterlink/pkg/store/kv/bolt"
+	"github.com/clusterlink-net/clusterlink/pkg/util/jsonapi"
+	"github.com/clusterlink-net/clusterlink/pkg/util/rest"
+)
+
+type object struct {
+	Name   string
+	Labels map[string]string
+	Value  int
+}
+
+// objectHandler is an in-memory handler.
+type objectHandler struct {
+	lock    sync.Mutex
+	objects map[string]*object
+}
+
+func (h *objectHandler) Decode([]byte) (any, error)               { return nil, nil }
+func (h *objectHandler) Create(context.Context, any) error        { return nil }
+func (h *objectHandler) Update(context.Context, any) error        { return nil }
+func (h *objectHandler) Get(string) (any, error)                  { return nil, nil }
+func (h *objectHandler) Delete(context.Context, any) (any, error) { return nil, nil }
+
+func (h *objectHandler) List() (any, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	objects := make([]*object, 0, len(h.objects))
+	for _, o := range h.objects {
+		objects = append(objects, &object{Name: o.Name, Labels: o.Labels, Value: o.Value})
+	}
+	return objects, nil
+}
+
+// startServer starts a test server for the given objects, and returns a client for the objects.
+func startServer(t *testing.T, spec *rest.ServerObjectSpec) (*rest.Client, func()) {
+	server := rest.NewServer("test", nil)
+	server.AddObjectHandlers(spec)
+
+	httpServer := httptest.NewTLSServer(server.Router())
+
+	host, portStr, err := net.SplitHostPort(httpServer.Listener.Addr().String())
+	require.Nil(t, err)
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	require.Nil(t, err)
+
+	tlsConfig := httpServer.Client().Transport.(*http.Transport).TLSClientConfig
+	client := rest.NewClient(&rest.Config{
+		Client:       jsonapi.NewClient(host, uint16(port), tlsConfig),
+		BasePath:     spec.BasePath,
+		SampleObject: object{},
+		SampleList:   []object{},
+	})
+
+	return client, httpServer.Close
+}
+
+func nextEvent(t *testing.T, events <-chan rest.WatchEvent) rest.WatchEvent {
+	select {
+	case event, ok := <-events:
+		require.True(t, ok)
+		return event
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout waiting for event")
+		return rest.WatchEvent{}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	kvStore, err := bolt.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.Nil(t, err)
+	defer kvStore.Close()
+
+	objectStore := kv.NewObjectStore("object", kvStore, object{})
+	require.Nil(t, objectStore.Create("a", &object{Name: "a", Value: 1}))
+
+	set := func(name string, value int) {
+		require.Nil(t, objectStore.Update(name, func(any) any { return &object{Name: name, Value: value} }))
+	}
+
+	client, stopServer := startServer(t, &rest.ServerObjectSpec{
+		BasePath: "/objects",
+		Handler:  &objectHandler{},
+		Key:      func(o any) string { return o.(*object).Name },
+		Watch:    &rest.WatchSpec{Store: objectStore},
+	})
+	defer stopServer()
+
+	// existing objects are sent first, at the current store revision
+	events, stop, err := client.Watch(0)
+	require.Nil(t, err)
+
+	revision, err := objectStore.Revision()
+	require.Nil(t, err)
+
+	event := nextEvent(t, events)
+	require.Equal(t, rest.WatchAdded, event.Type)
+	require.Equal(t, &object{Name: "a", Value: 1}, event.Object)
+	require.Equal(t, revision, event.Revision)
+	start := event.Revision
+
+	// changes carry the store revisions
+	require.Nil(t, objectStore.Create("b", &object{Name: "b", Value: 2}))
+	event = nextEvent(t, events)
+	require.Equal(t, rest.WatchAdded, event.Type)
+	require.Equal(t, &object{Name: "b", Value: 2}, event.Object)
+	revision, err = objectStore.Revision()
+	require.Nil(t, err)
+	require.Equal(t, revision, event.Revision)
+
+	set("a", 3)
+	event = nextEvent(t, events)
+	require.Equal(t, rest.WatchModified, event.Type)
+	require.Equal(t, &object{Name: "a", Value: 3}, event.Object)
+
+	require.Nil(t, objectStore.Delete("b"))
+	event = nextEvent(t, events)
+	require.Equal(t, rest.WatchDeleted, event.Type)
+	require.Equal(t, &object{Name: "b", Value: 2}, event.Object)
+	last := event.Revision
+	stop()
+
+	// resume after a revision
+	events, stop, err = client.Watch(start)
+	require.Nil(t, err)
+	defer stop()
+
+	for _, expected := range []rest.WatchEventType{rest.WatchAdded, rest.WatchModified, rest.WatchDeleted} {
+		require.Equal(t, expected, nextEvent(t, events).Type)
+	}
+
+	// changes to other objects in the store are not watched
+	require.Nil(t, kvStore.Create([]byte("other.c"), []byte("{}")))
+	require.Nil(t, objectStore.Create("c", &object{Name: "c", Value: 4}))
+	event = nextEvent(t, events)
+	require.Equal(t, rest.WatchAdded, event.Type)
+	require.Equal(t, &object{Name: "c", Value: 4}, event.Object)
+	require.Greater(t, event.Revision, last+1)
+
+	// resume after an unknown revision
+	_, _, err = client.Watch(event.Revision + 100)
+	var expiredErr *rest.ExpiredError
+	require.True(t, errors.As(err, &expiredErr))
+
+	// resume after a revision which precedes the watch
+	_, _, err = client.Watch(start - 1)
+	require.True(t, errors.As(err, &expiredErr))
+}
@@ -0,0 +1,31 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffe
+
+import (
+	"strings"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+// KubernetesIdentity returns the namespace and service account of a SPIFFE ID
+// following the /ns/<namespace>/sa/<service account> path convention.
+func KubernetesIdentity(id spiffeid.ID) (namespace, serviceAccount string, ok bool) {
+	segments := strings.Split(strings.TrimPrefix(id.Path(), "/"), "/")
+	if len(segments) != 4 || segments[0] != "ns" || segments[2] != "sa" {
+		return "", "", false
+	}
+
+	return segments[1], segments[3], true
+}
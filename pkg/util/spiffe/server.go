@@ -0,0 +1,131 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffe
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/jwtbundle"
+	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// workloadAPIHeader is the metadata header required by Workload API servers.
+const workloadAPIHeader = "workload.spiffe.io"
+
+// Server is a minimal SPIFFE Workload API server, serving a static X.509 SVID and trust bundles.
+// It can stand in for a SPIFFE implementation (such as SPIRE) in tests and development setups.
+type Server struct {
+	workload.UnimplementedSpiffeWorkloadAPIServer
+
+	x509Response *workload.X509SVIDResponse
+	jwtResponse  *workload.JWTBundlesResponse
+	server       *grpc.Server
+}
+
+// Serve serves Workload API requests on the given listener, until Stop is called.
+func (s *Server) Serve(lis net.Listener) error {
+	return s.server.Serve(lis)
+}
+
+// Stop stops the server, closing all open streams.
+func (s *Server) Stop() {
+	s.server.Stop()
+}
+
+// FetchX509SVID streams the X.509 SVID and its trust bundle.
+func (s *Server) FetchX509SVID(
+	_ *workload.X509SVIDRequest,
+	stream workload.SpiffeWorkloadAPI_FetchX509SVIDServer,
+) error {
+	return s.serve(stream, s.x509Response)
+}
+
+// FetchJWTBundles streams the JWT bundles.
+func (s *Server) FetchJWTBundles(
+	_ *workload.JWTBundlesRequest,
+	stream workload.SpiffeWorkloadAPI_FetchJWTBundlesServer,
+) error {
+	return s.serve(stream, s.jwtResponse)
+}
+
+func (s *Server) serve(stream grpc.ServerStream, resp any) error {
+	md, ok := metadata.FromIncomingContext(stream.Context())
+	if !ok || len(md.Get(workloadAPIHeader)) != 1 || md.Get(workloadAPIHeader)[0] != "true" {
+		return status.Error(codes.InvalidArgument, "security header missing from request")
+	}
+
+	if err := stream.SendMsg(resp); err != nil {
+		return err
+	}
+
+	// responses are static, so just hold the stream open
+	<-stream.Context().Done()
+	return nil
+}
+
+// NewServer returns a Workload API server, serving the given X.509 SVID (with the CA certificates of its trust domain),
+// and the JWT bundles of trust domains.
+func NewServer(svid *tls.Certificate, bundle []*x509.Certificate, jwtBundles []*jwtbundle.Bundle) (*Server, error) {
+	if len(svid.Certificate) == 0 {
+		return nil, fmt.Errorf("missing X.509 SVID")
+	}
+
+	leaf, err := x509.ParseCertificate(svid.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := x509svid.IDFromCert(leaf)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.MarshalPKCS8PrivateKey(svid.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	x509SVID := &workload.X509SVID{SpiffeId: id.String(), X509SvidKey: key}
+	for _, cert := range svid.Certificate {
+		x509SVID.X509Svid = append(x509SVID.X509Svid, cert...)
+	}
+	for _, cert := range bundle {
+		x509SVID.Bundle = append(x509SVID.Bundle, cert.Raw...)
+	}
+
+	jwtResp := &workload.JWTBundlesResponse{Bundles: make(map[string][]byte)}
+	for _, jwtBundle := range jwtBundles {
+		jwtResp.Bundles[jwtBundle.TrustDomain().IDString()], err = jwtBundle.Marshal()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s := &Server{
+		x509Response: &workload.X509SVIDResponse{Svids: []*workload.X509SVID{x509SVID}},
+		jwtResponse:  jwtResp,
+		server:       grpc.NewServer(),
+	}
+	workload.RegisterSpiffeWorkloadAPIServer(s.server, s)
+
+	return s, nil
+}
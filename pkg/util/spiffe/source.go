@@ -0,0 +1,115 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffe
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/jwtsvid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+)
+
+// Source holds the workload X.509 SVID and the trust bundles, as fetched (and kept up to date)
+// from a SPIFFE Workload API endpoint.
+type Source struct {
+	client     *workloadapi.Client
+	x509Source *workloadapi.X509Source
+	jwtSource  *workloadapi.JWTSource
+}
+
+// ID returns the SPIFFE ID of the workload X.509 SVID.
+func (s *Source) ID() spiffeid.ID {
+	svid, err := s.x509Source.GetX509SVID()
+	if err != nil {
+		return spiffeid.ID{}
+	}
+	return svid.ID
+}
+
+// VerifyX509SVID verifies an X.509 SVID certificate chain (leaf first) against the trust bundles,
+// returning its SPIFFE ID.
+func (s *Source) VerifyX509SVID(certs []*x509.Certificate) (spiffeid.ID, error) {
+	id, _, err := x509svid.Verify(certs, s.x509Source)
+	if err != nil {
+		return spiffeid.ID{}, fmt.Errorf("cannot verify X.509 SVID: %w", err)
+	}
+
+	return id, nil
+}
+
+// VerifyJWTSVID verifies a JWT-SVID issued for the given audience against the trust bundles,
+// returning its SPIFFE ID.
+func (s *Source) VerifyJWTSVID(token, audience string) (spiffeid.ID, error) {
+	svid, err := jwtsvid.ParseAndValidate(token, s.jwtSource, []string{audience})
+	if err != nil {
+		return spiffeid.ID{}, fmt.Errorf("cannot verify JWT-SVID: %w", err)
+	}
+
+	return svid.ID, nil
+}
+
+// ServerTLSConfig returns a TLS configuration for a server, presenting the workload X.509 SVID,
+// and accepting only clients presenting a valid X.509 SVID.
+func (s *Source) ServerTLSConfig() *tls.Config {
+	return tlsconfig.MTLSServerConfig(s.x509Source, s.x509Source, tlsconfig.AuthorizeAny())
+}
+
+// Close stops watching for updates from the Workload API.
+func (s *Source) Close() error {
+	return errors.Join(s.jwtSource.Close(), s.x509Source.Close(), s.client.Close())
+}
+
+// PeerID returns the SPIFFE ID of the peer of a TLS connection accepted using ServerTLSConfig.
+func PeerID(state *tls.ConnectionState) (spiffeid.ID, error) {
+	if len(state.PeerCertificates) == 0 {
+		return spiffeid.ID{}, fmt.Errorf("peer did not present an X.509 SVID")
+	}
+
+	return x509svid.IDFromCert(state.PeerCertificates[0])
+}
+
+// NewSource connects to a SPIFFE Workload API endpoint (e.g. unix:///run/spire/sockets/agent.sock),
+// and waits until the workload X.509 SVID and the trust bundles are received.
+func NewSource(ctx context.Context, endpoint string) (*Source, error) {
+	client, err := workloadapi.New(ctx, workloadapi.WithAddr(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to the workload API: %w", err)
+	}
+
+	x509Source, err := workloadapi.NewX509Source(ctx, workloadapi.WithClient(client))
+	if err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("cannot fetch X.509 SVID from the workload API: %w", err)
+	}
+
+	jwtSource, err := workloadapi.NewJWTSource(ctx, workloadapi.WithClient(client))
+	if err != nil {
+		_ = x509Source.Close()
+		_ = client.Close()
+		return nil, fmt.Errorf("cannot fetch JWT bundles from the workload API: %w", err)
+	}
+
+	return &Source{
+		client:     client,
+		x509Source: x509Source,
+		jwtSource:  jwtSource,
+	}, nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 The ClusterLink Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffe_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/spiffe/go-spiffe/v2/bundle/jwtbundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clusterlink-net/clusterlink/pkg/util/spiffe"
+)
+
+const (
+	trustDomain = "example.org"
+	workloadID  = "spiffe://example.org/ns/default/sa/client"
+	audience    = "clusterlink"
+)
+
+// createCertificate creates a certificate for the given SPIFFE ID, signed by the parent (or self-signed).
+func createCertificate(t *testing.T, id string, isCA bool, parent *x509.Certificate, parentKey crypto.Signer) (
+	*x509.Certificate, crypto.Signer,
+) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	uri, err := url.Parse(id)
+	require.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: id},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		URIs:                  []*url.URL{uri},
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+	}
+	if isCA {
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+
+	if parent == nil {
+		parent = template
+		parentKey = key
+	}
+
+	raw, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.Nil(t, err)
+
+	cert, err := x509.ParseCertificate(raw)
+	require.Nil(t, err)
+
+	return cert, key
+}
+
+func TestKubernetesIdentity(t *testing.T) {
+	id := spiffeid.RequireFromString(workloadID)
+	namespace, serviceAccount, ok := spiffe.KubernetesIdentity(id)
+	require.True(t, ok)
+	require.Equal(t, "default", namespace)
+	require.Equal(t, "client", serviceAccount)
+
+	for _, other := range []string{
+		"spiffe://example.org/workload",
+		"spiffe://example.org/ns/default/sa",
+		"spiffe://example.org/ns/default/sa/client/extra",
+		"spiffe://example.org/namespace/default/sa/client",
+	} {
+		_, _, ok := spiffe.KubernetesIdentity(spiffeid.RequireFromString(other))
+		require.False(t, ok, other)
+	}
+}
+
+func TestSource(t *testing.T) {
+	ca, caKey := createCertificate(t, "spiffe://"+trustDomain, true, nil, nil)
+	server, serverKey := createCertificate(t, "spiffe://example.org/clusterlink/dataplane", false, ca, caKey)
+	client, clientKey := createCertificate(t, workloadID, false, ca, caKey)
+
+	otherCA, otherCAKey := createCertificate(t, "spiffe://"+trustDomain, true, nil, nil)
+	otherClient, _ := createCertificate(t, workloadID, false, otherCA, otherCAKey)
+
+	// JWT signing key of the trust domain
+	jwtKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	jwtSignKey, err := jwk.New(jwtKey)
+	require.Nil(t, err)
+	require.Nil(t, jwtSignKey.Set(jwk.KeyIDKey, "key-1"))
+	jwtBundle := jwtbundle.New(spiffeid.RequireTrustDomainFromString(trustDomain))
+	require.Nil(t, jwtBundle.AddJWTAuthority("key-1", jwtKey.Public()))
+
+	// start a stand-in workload API server
+	workloadAPI, err := spiffe.NewServer(
+		&tls.Certificate{Certificate: [][]byte{server.Raw}, PrivateKey: serverKey},
+		[]*x509.Certificate{ca},
+		[]*jwtbundle.Bundle{jwtBundle})
+	require.Nil(t, err)
+
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+	lis, err := net.Listen("unix", socket)
+	require.Nil(t, err)
+	go func() { _ = workloadAPI.Serve(lis) }()
+	defer workloadAPI.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	source, err := spiffe.NewSource(ctx, "unix://"+socket)
+	require.Nil(t, err)
+	defer source.Close()
+
+	require.Equal(t, "spiffe://example.org/clusterlink/dataplane", source.ID().String())
+
+	// X.509 SVIDs
+	id, err := source.VerifyX509SVID([]*x509.Certificate{client})
+	require.Nil(t, err)
+	require.Equal(t, workloadID, id.String())
+
+	_, err = source.VerifyX509SVID([]*x509.Certificate{otherClient})
+	require.NotNil(t, err)
+
+	_, err = source.VerifyX509SVID([]*x509.Certificate{ca})
+	require.NotNil(t, err)
+
+	// JWT-SVIDs
+	sign := func(sub, aud string, exp time.Time) string {
+		token := jwt.New()
+		require.Nil(t, token.Set(jwt.SubjectKey, sub))
+		require.Nil(t, token.Set(jwt.AudienceKey, aud))
+		require.Nil(t, token.Set(jwt.ExpirationKey, exp))
+		signed, err := jwt.Sign(token, jwa.ES256, jwtSignKey)
+		require.Nil(t, err)
+		return string(signed)
+	}
+
+	id, err = source.VerifyJWTSVID(sign(workloadID, audience, time.Now().Add(time.Minute)), audience)
+	require.Nil(t, err)
+	require.Equal(t, workloadID, id.String())
+
+	_, err = source.VerifyJWTSVID(sign(workloadID, "other", time.Now().Add(time.Minute)), audience)
+	require.NotNil(t, err)
+
+	_, err = source.VerifyJWTSVID(sign(workloadID, audience, time.Now().Add(-time.Minute)), audience)
+	require.NotNil(t, err)
+
+	_, err = source.VerifyJWTSVID(sign("spiffe://other.org/workload", audience, time.Now().Add(time.Minute)), audience)
+	require.NotNil(t, err)
+
+	// mTLS using X.509 SVIDs
+	tlsLis, err := tls.Listen("tcp", "127.0.0.1:0", source.ServerTLSConfig())
+	require.Nil(t, err)
+	defer tlsLis.Close()
+
+	peerID := make(chan string, 1)
+	go func() {
+		conn, err := tlsLis.Accept()
+		if err != nil {
+			peerID <- ""
+			return
+		}
+		defer conn.Close()
+
+		tlsConn := conn.(*tls.Conn)
+		if err := tlsConn.Handshake(); err != nil {
+			peerID <- ""
+			return
+		}
+
+		state := tlsConn.ConnectionState()
+		id, err := spiffe.PeerID(&state)
+		if err != nil {
+			peerID <- ""
+			return
+		}
+		peerID <- id.String()
+	}()
+
+	conn, err := tls.Dial("tcp", tlsLis.Addr().String(), &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		Certificates:       []tls.Certificate{{Certificate: [][]byte{client.Raw}, PrivateKey: clientKey}},
+		InsecureSkipVerify: true, //nolint:gosec // server SVID carries no DNS name
+	})
+	require.Nil(t, err)
+	require.Nil(t, conn.Handshake())
+	require.Equal(t, workloadID, <-peerID)
+	conn.Close()
+}
@@ -37,6 +37,11 @@ func ParseFiles(ca, cert, key string) (*ParsedCertData, error) {
 		return nil, fmt.Errorf("unable to read private key file: %w", err)
 	}
 
+	return ParseData(rawCA, rawCertificate, rawPrivateKey)
+}
+
+// ParseData parses the given PEM-encoded CA, certificate and private key.
+func ParseData(rawCA, rawCertificate, rawPrivateKey []byte) (*ParsedCertData, error) {
 	certificate, err := tls.X509KeyPair(rawCertificate, rawPrivateKey)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse certificate keypair: %w", err)
@@ -44,7 +49,7 @@ func ParseFiles(ca, cert, key string) (*ParsedCertData, error) {
 
 	caCertPool := x509.NewCertPool()
 	if !caCertPool.AppendCertsFromPEM(rawCA) {
-		return nil, fmt.Errorf("unable to parse CA file")
+		return nil, fmt.Errorf("unable to parse CA")
 	}
 
 	x509cert, err := x509.ParseCertificate(certificate.Certificate[0])
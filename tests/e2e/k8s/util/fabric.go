@@ -52,7 +52,7 @@ type peer struct {
 // CreateControlplaneCertificate creates the controlplane certificate.
 func (p *peer) CreateControlplaneCertificate() {
 	p.Run(func() error {
-		cert, err := bootstrap.CreateControlplaneCertificate(p.cluster.Name(), p.peerCert)
+		cert, err := bootstrap.CreateControlplaneCertificate(p.cluster.Name(), p.peerCert, 0)
 		if err != nil {
 			return fmt.Errorf("cannot create controlplane certificate: %w", err)
 		}
@@ -65,7 +65,7 @@ func (p *peer) CreateControlplaneCertificate() {
 // CreateDataplaneCertificate creates the dataplane certificate.
 func (p *peer) CreateDataplaneCertificate() {
 	p.Run(func() error {
-		cert, err := bootstrap.CreateDataplaneCertificate(p.cluster.Name(), p.peerCert)
+		cert, err := bootstrap.CreateDataplaneCertificate(p.cluster.Name(), p.peerCert, 0)
 		if err != nil {
 			return fmt.Errorf("cannot create dataplane certificate: %w", err)
 		}
@@ -78,7 +78,7 @@ func (p *peer) CreateDataplaneCertificate() {
 // CreateGWCTLCertificate creates the gwctl certificate.
 func (p *peer) CreateGWCTLCertificate() {
 	p.Run(func() error {
-		cert, err := bootstrap.CreateGWCTLCertificate(p.peerCert)
+		cert, err := bootstrap.CreateGWCTLCertificate(p.peerCert, 0)
 		if err != nil {
 			return fmt.Errorf("cannot create controlplane certificate: %w", err)
 		}
@@ -103,7 +103,7 @@ func (f *Fabric) CreatePeer(cluster *KindCluster) {
 	p := &peer{cluster: cluster}
 	f.peers = append(f.peers, p)
 	f.Run(func() error {
-		cert, err := bootstrap.CreatePeerCertificate(p.cluster.Name(), f.cert)
+		cert, err := bootstrap.CreatePeerCertificate(p.cluster.Name(), f.cert, 0)
 		if err != nil {
 			return fmt.Errorf("cannot create peer certificate: %w", err)
 		}
@@ -254,7 +254,7 @@ func (f *Fabric) DeployClusterlinks(peerCount uint8, cfg *PeerConfig) ([]*Cluste
 
 // NewFabric returns a new empty fabric.
 func NewFabric() (*Fabric, error) {
-	cert, err := bootstrap.CreateFabricCertificate()
+	cert, err := bootstrap.CreateFabricCertificate(0)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create fabric certificate: %w", err)
 	}